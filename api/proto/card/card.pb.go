@@ -9,6 +9,7 @@ package card
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -250,6 +251,8 @@ type GetCardResponse struct {
 	ExpiryDate    string                 `protobuf:"bytes,5,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
 	CreditLimit   float32                `protobuf:"fixed32,6,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"`
 	Balance       float32                `protobuf:"fixed32,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	MaskedNumber  string                 `protobuf:"bytes,8,opt,name=masked_number,json=maskedNumber,proto3" json:"masked_number,omitempty"`
+	IsActive      bool                   `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -333,6 +336,20 @@ func (x *GetCardResponse) GetBalance() float32 {
 	return 0
 }
 
+func (x *GetCardResponse) GetMaskedNumber() string {
+	if x != nil {
+		return x.MaskedNumber
+	}
+	return ""
+}
+
+func (x *GetCardResponse) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
 type UpdateCardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1005,11 +1022,463 @@ func (x *RemoveCardResponse) GetSuccess() bool {
 	return false
 }
 
+type SetCardStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardStatusRequest) Reset() {
+	*x = SetCardStatusRequest{}
+	mi := &file_api_proto_card_card_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardStatusRequest) ProtoMessage() {}
+
+func (x *SetCardStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetCardStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetCardStatusRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetCardStatusRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetCardStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardStatusResponse) Reset() {
+	*x = SetCardStatusResponse{}
+	mi := &file_api_proto_card_card_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardStatusResponse) ProtoMessage() {}
+
+func (x *SetCardStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetCardStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetCardStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AuthorizePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PaymentId     uint32                 `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizePaymentRequest) Reset() {
+	*x = AuthorizePaymentRequest{}
+	mi := &file_api_proto_card_card_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizePaymentRequest) ProtoMessage() {}
+
+func (x *AuthorizePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizePaymentRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AuthorizePaymentRequest) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *AuthorizePaymentRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AuthorizePaymentRequest) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *AuthorizePaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type CardHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     uint32                 `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardHoldRequest) Reset() {
+	*x = CardHoldRequest{}
+	mi := &file_api_proto_card_card_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardHoldRequest) ProtoMessage() {}
+
+func (x *CardHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardHoldRequest.ProtoReflect.Descriptor instead.
+func (*CardHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CardHoldRequest) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+type CardHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     uint32                 `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	CardId        uint32                 `protobuf:"varint,2,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "HELD", "CAPTURED" or "RELEASED"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardHoldResponse) Reset() {
+	*x = CardHoldResponse{}
+	mi := &file_api_proto_card_card_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardHoldResponse) ProtoMessage() {}
+
+func (x *CardHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardHoldResponse.ProtoReflect.Descriptor instead.
+func (*CardHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CardHoldResponse) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *CardHoldResponse) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *CardHoldResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CardHoldResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type WatchCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // Optional; the first message is a snapshot when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCardRequest) Reset() {
+	*x = WatchCardRequest{}
+	mi := &file_api_proto_card_card_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCardRequest) ProtoMessage() {}
+
+func (x *WatchCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCardRequest.ProtoReflect.Descriptor instead.
+func (*WatchCardRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchCardRequest) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *WatchCardRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type CardUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaskedNumber  string                 `protobuf:"bytes,3,opt,name=masked_number,json=maskedNumber,proto3" json:"masked_number,omitempty"`
+	CardType      string                 `protobuf:"bytes,4,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`
+	CreditLimit   float64                `protobuf:"fixed64,5,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"`
+	Balance       float64                `protobuf:"fixed64,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Removed       bool                   `protobuf:"varint,8,opt,name=removed,proto3" json:"removed,omitempty"` // The card was removed; the stream ends after this update
+	Sequence      uint64                 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ResumeToken   string                 `protobuf:"bytes,11,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardUpdate) Reset() {
+	*x = CardUpdate{}
+	mi := &file_api_proto_card_card_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardUpdate) ProtoMessage() {}
+
+func (x *CardUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_card_card_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardUpdate.ProtoReflect.Descriptor instead.
+func (*CardUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_card_card_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CardUpdate) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *CardUpdate) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CardUpdate) GetMaskedNumber() string {
+	if x != nil {
+		return x.MaskedNumber
+	}
+	return ""
+}
+
+func (x *CardUpdate) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *CardUpdate) GetCreditLimit() float64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *CardUpdate) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *CardUpdate) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *CardUpdate) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+func (x *CardUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CardUpdate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CardUpdate) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 var File_api_proto_card_card_proto protoreflect.FileDescriptor
 
 const file_api_proto_card_card_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/proto/card/card.proto\x12\x04card\"\xd0\x01\n" +
+	"\x19api/proto/card/card.proto\x12\x04card\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd0\x01\n" +
 	"\x11CreateCardRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\rR\n" +
 	"customerId\x12\x1f\n" +
@@ -1032,7 +1501,7 @@ const file_api_proto_card_card_proto_rawDesc = "" +
 	"\fcredit_limit\x18\x06 \x01(\x02R\vcreditLimit\x12\x18\n" +
 	"\abalance\x18\a \x01(\x02R\abalance\" \n" +
 	"\x0eGetCardRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"\xde\x01\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\xa0\x02\n" +
 	"\x0fGetCardResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\rR\n" +
@@ -1043,7 +1512,9 @@ const file_api_proto_card_card_proto_rawDesc = "" +
 	"\vexpiry_date\x18\x05 \x01(\tR\n" +
 	"expiryDate\x12!\n" +
 	"\fcredit_limit\x18\x06 \x01(\x02R\vcreditLimit\x12\x18\n" +
-	"\abalance\x18\a \x01(\x02R\abalance\"\xe0\x01\n" +
+	"\abalance\x18\a \x01(\x02R\abalance\x12#\n" +
+	"\rmasked_number\x18\b \x01(\tR\fmaskedNumber\x12\x1b\n" +
+	"\tis_active\x18\t \x01(\bR\bisActive\"\xe0\x01\n" +
 	"\x11UpdateCardRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\rR\n" +
@@ -1097,7 +1568,47 @@ const file_api_proto_card_card_proto_rawDesc = "" +
 	"\vcard_number\x18\x02 \x01(\tR\n" +
 	"cardNumber\".\n" +
 	"\x12RemoveCardResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\x92\x04\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"C\n" +
+	"\x14SetCardStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"1\n" +
+	"\x15SetCardStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8a\x01\n" +
+	"\x17AuthorizePaymentRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\rR\tpaymentId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\"0\n" +
+	"\x0fCardHoldRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\rR\tpaymentId\"z\n" +
+	"\x10CardHoldResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\rR\tpaymentId\x12\x17\n" +
+	"\acard_id\x18\x02 \x01(\rR\x06cardId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"N\n" +
+	"\x10WatchCardRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"\xf6\x02\n" +
+	"\n" +
+	"CardUpdate\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12#\n" +
+	"\rmasked_number\x18\x03 \x01(\tR\fmaskedNumber\x12\x1b\n" +
+	"\tcard_type\x18\x04 \x01(\tR\bcardType\x12!\n" +
+	"\fcredit_limit\x18\x05 \x01(\x01R\vcreditLimit\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x01R\abalance\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x18\n" +
+	"\aremoved\x18\b \x01(\bR\aremoved\x12\x1a\n" +
+	"\bsequence\x18\t \x01(\x04R\bsequence\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fresume_token\x18\v \x01(\tR\vresumeToken2\xe2\x06\n" +
 	"\vCardService\x12?\n" +
 	"\n" +
 	"CreateCard\x12\x17.card.CreateCardRequest\x1a\x18.card.CreateCardResponse\x126\n" +
@@ -1110,7 +1621,12 @@ const file_api_proto_card_card_proto_rawDesc = "" +
 	"\x10GetCustomerCards\x12\x1d.card.GetCustomerCardsRequest\x1a\x1e.card.GetCustomerCardsResponse\x126\n" +
 	"\aAddCard\x12\x14.card.AddCardRequest\x1a\x15.card.AddCardResponse\x12?\n" +
 	"\n" +
-	"RemoveCard\x12\x17.card.RemoveCardRequest\x1a\x18.card.RemoveCardResponseB\x15Z\x13govo/api/proto/cardb\x06proto3"
+	"RemoveCard\x12\x17.card.RemoveCardRequest\x1a\x18.card.RemoveCardResponse\x12H\n" +
+	"\rSetCardStatus\x12\x1a.card.SetCardStatusRequest\x1a\x1b.card.SetCardStatusResponse\x12I\n" +
+	"\x10AuthorizePayment\x12\x1d.card.AuthorizePaymentRequest\x1a\x16.card.CardHoldResponse\x12?\n" +
+	"\x0eCapturePayment\x12\x15.card.CardHoldRequest\x1a\x16.card.CardHoldResponse\x12?\n" +
+	"\x0eReleasePayment\x12\x15.card.CardHoldRequest\x1a\x16.card.CardHoldResponse\x127\n" +
+	"\tWatchCard\x12\x16.card.WatchCardRequest\x1a\x10.card.CardUpdate0\x01B\x15Z\x13govo/api/proto/cardb\x06proto3"
 
 var (
 	file_api_proto_card_card_proto_rawDescOnce sync.Once
@@ -1124,7 +1640,7 @@ func file_api_proto_card_card_proto_rawDescGZIP() []byte {
 	return file_api_proto_card_card_proto_rawDescData
 }
 
-var file_api_proto_card_card_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_proto_card_card_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_proto_card_card_proto_goTypes = []any{
 	(*CreateCardRequest)(nil),        // 0: card.CreateCardRequest
 	(*CreateCardResponse)(nil),       // 1: card.CreateCardResponse
@@ -1142,31 +1658,50 @@ var file_api_proto_card_card_proto_goTypes = []any{
 	(*AddCardResponse)(nil),          // 13: card.AddCardResponse
 	(*RemoveCardRequest)(nil),        // 14: card.RemoveCardRequest
 	(*RemoveCardResponse)(nil),       // 15: card.RemoveCardResponse
+	(*SetCardStatusRequest)(nil),     // 16: card.SetCardStatusRequest
+	(*SetCardStatusResponse)(nil),    // 17: card.SetCardStatusResponse
+	(*AuthorizePaymentRequest)(nil),  // 18: card.AuthorizePaymentRequest
+	(*CardHoldRequest)(nil),          // 19: card.CardHoldRequest
+	(*CardHoldResponse)(nil),         // 20: card.CardHoldResponse
+	(*WatchCardRequest)(nil),         // 21: card.WatchCardRequest
+	(*CardUpdate)(nil),               // 22: card.CardUpdate
+	(*timestamppb.Timestamp)(nil),    // 23: google.protobuf.Timestamp
 }
 var file_api_proto_card_card_proto_depIdxs = []int32{
 	3,  // 0: card.ListCardsResponse.cards:type_name -> card.GetCardResponse
 	3,  // 1: card.GetCustomerCardsResponse.cards:type_name -> card.GetCardResponse
-	0,  // 2: card.CardService.CreateCard:input_type -> card.CreateCardRequest
-	2,  // 3: card.CardService.GetCard:input_type -> card.GetCardRequest
-	4,  // 4: card.CardService.UpdateCard:input_type -> card.UpdateCardRequest
-	6,  // 5: card.CardService.DeleteCard:input_type -> card.DeleteCardRequest
-	8,  // 6: card.CardService.ListCards:input_type -> card.ListCardsRequest
-	10, // 7: card.CardService.GetCustomerCards:input_type -> card.GetCustomerCardsRequest
-	12, // 8: card.CardService.AddCard:input_type -> card.AddCardRequest
-	14, // 9: card.CardService.RemoveCard:input_type -> card.RemoveCardRequest
-	1,  // 10: card.CardService.CreateCard:output_type -> card.CreateCardResponse
-	3,  // 11: card.CardService.GetCard:output_type -> card.GetCardResponse
-	5,  // 12: card.CardService.UpdateCard:output_type -> card.UpdateCardResponse
-	7,  // 13: card.CardService.DeleteCard:output_type -> card.DeleteCardResponse
-	9,  // 14: card.CardService.ListCards:output_type -> card.ListCardsResponse
-	11, // 15: card.CardService.GetCustomerCards:output_type -> card.GetCustomerCardsResponse
-	13, // 16: card.CardService.AddCard:output_type -> card.AddCardResponse
-	15, // 17: card.CardService.RemoveCard:output_type -> card.RemoveCardResponse
-	10, // [10:18] is the sub-list for method output_type
-	2,  // [2:10] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	23, // 2: card.CardUpdate.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: card.CardService.CreateCard:input_type -> card.CreateCardRequest
+	2,  // 4: card.CardService.GetCard:input_type -> card.GetCardRequest
+	4,  // 5: card.CardService.UpdateCard:input_type -> card.UpdateCardRequest
+	6,  // 6: card.CardService.DeleteCard:input_type -> card.DeleteCardRequest
+	8,  // 7: card.CardService.ListCards:input_type -> card.ListCardsRequest
+	10, // 8: card.CardService.GetCustomerCards:input_type -> card.GetCustomerCardsRequest
+	12, // 9: card.CardService.AddCard:input_type -> card.AddCardRequest
+	14, // 10: card.CardService.RemoveCard:input_type -> card.RemoveCardRequest
+	16, // 11: card.CardService.SetCardStatus:input_type -> card.SetCardStatusRequest
+	18, // 12: card.CardService.AuthorizePayment:input_type -> card.AuthorizePaymentRequest
+	19, // 13: card.CardService.CapturePayment:input_type -> card.CardHoldRequest
+	19, // 14: card.CardService.ReleasePayment:input_type -> card.CardHoldRequest
+	21, // 15: card.CardService.WatchCard:input_type -> card.WatchCardRequest
+	1,  // 16: card.CardService.CreateCard:output_type -> card.CreateCardResponse
+	3,  // 17: card.CardService.GetCard:output_type -> card.GetCardResponse
+	5,  // 18: card.CardService.UpdateCard:output_type -> card.UpdateCardResponse
+	7,  // 19: card.CardService.DeleteCard:output_type -> card.DeleteCardResponse
+	9,  // 20: card.CardService.ListCards:output_type -> card.ListCardsResponse
+	11, // 21: card.CardService.GetCustomerCards:output_type -> card.GetCustomerCardsResponse
+	13, // 22: card.CardService.AddCard:output_type -> card.AddCardResponse
+	15, // 23: card.CardService.RemoveCard:output_type -> card.RemoveCardResponse
+	17, // 24: card.CardService.SetCardStatus:output_type -> card.SetCardStatusResponse
+	20, // 25: card.CardService.AuthorizePayment:output_type -> card.CardHoldResponse
+	20, // 26: card.CardService.CapturePayment:output_type -> card.CardHoldResponse
+	20, // 27: card.CardService.ReleasePayment:output_type -> card.CardHoldResponse
+	22, // 28: card.CardService.WatchCard:output_type -> card.CardUpdate
+	16, // [16:29] is the sub-list for method output_type
+	3,  // [3:16] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_card_card_proto_init() }
@@ -1180,7 +1715,7 @@ func file_api_proto_card_card_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_card_card_proto_rawDesc), len(file_api_proto_card_card_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
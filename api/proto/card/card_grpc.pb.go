@@ -27,6 +27,11 @@ const (
 	CardService_GetCustomerCards_FullMethodName = "/card.CardService/GetCustomerCards"
 	CardService_AddCard_FullMethodName          = "/card.CardService/AddCard"
 	CardService_RemoveCard_FullMethodName       = "/card.CardService/RemoveCard"
+	CardService_SetCardStatus_FullMethodName    = "/card.CardService/SetCardStatus"
+	CardService_AuthorizePayment_FullMethodName = "/card.CardService/AuthorizePayment"
+	CardService_CapturePayment_FullMethodName   = "/card.CardService/CapturePayment"
+	CardService_ReleasePayment_FullMethodName   = "/card.CardService/ReleasePayment"
+	CardService_WatchCard_FullMethodName        = "/card.CardService/WatchCard"
 )
 
 // CardServiceClient is the client API for CardService service.
@@ -41,6 +46,14 @@ type CardServiceClient interface {
 	GetCustomerCards(ctx context.Context, in *GetCustomerCardsRequest, opts ...grpc.CallOption) (*GetCustomerCardsResponse, error)
 	AddCard(ctx context.Context, in *AddCardRequest, opts ...grpc.CallOption) (*AddCardResponse, error)
 	RemoveCard(ctx context.Context, in *RemoveCardRequest, opts ...grpc.CallOption) (*RemoveCardResponse, error)
+	SetCardStatus(ctx context.Context, in *SetCardStatusRequest, opts ...grpc.CallOption) (*SetCardStatusResponse, error)
+	// Payment holds. All three are keyed by payment_id and safe to repeat.
+	AuthorizePayment(ctx context.Context, in *AuthorizePaymentRequest, opts ...grpc.CallOption) (*CardHoldResponse, error)
+	CapturePayment(ctx context.Context, in *CardHoldRequest, opts ...grpc.CallOption) (*CardHoldResponse, error)
+	ReleasePayment(ctx context.Context, in *CardHoldRequest, opts ...grpc.CallOption) (*CardHoldResponse, error)
+	// Streams the card's current state, then every balance, limit or status
+	// change. Pass the last resume_token received to continue after a reconnect.
+	WatchCard(ctx context.Context, in *WatchCardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CardUpdate], error)
 }
 
 type cardServiceClient struct {
@@ -131,6 +144,65 @@ func (c *cardServiceClient) RemoveCard(ctx context.Context, in *RemoveCardReques
 	return out, nil
 }
 
+func (c *cardServiceClient) SetCardStatus(ctx context.Context, in *SetCardStatusRequest, opts ...grpc.CallOption) (*SetCardStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCardStatusResponse)
+	err := c.cc.Invoke(ctx, CardService_SetCardStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) AuthorizePayment(ctx context.Context, in *AuthorizePaymentRequest, opts ...grpc.CallOption) (*CardHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardHoldResponse)
+	err := c.cc.Invoke(ctx, CardService_AuthorizePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) CapturePayment(ctx context.Context, in *CardHoldRequest, opts ...grpc.CallOption) (*CardHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardHoldResponse)
+	err := c.cc.Invoke(ctx, CardService_CapturePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) ReleasePayment(ctx context.Context, in *CardHoldRequest, opts ...grpc.CallOption) (*CardHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardHoldResponse)
+	err := c.cc.Invoke(ctx, CardService_ReleasePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) WatchCard(ctx context.Context, in *WatchCardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CardUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CardService_ServiceDesc.Streams[0], CardService_WatchCard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCardRequest, CardUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CardService_WatchCardClient = grpc.ServerStreamingClient[CardUpdate]
+
 // CardServiceServer is the server API for CardService service.
 // All implementations must embed UnimplementedCardServiceServer
 // for forward compatibility.
@@ -143,6 +215,14 @@ type CardServiceServer interface {
 	GetCustomerCards(context.Context, *GetCustomerCardsRequest) (*GetCustomerCardsResponse, error)
 	AddCard(context.Context, *AddCardRequest) (*AddCardResponse, error)
 	RemoveCard(context.Context, *RemoveCardRequest) (*RemoveCardResponse, error)
+	SetCardStatus(context.Context, *SetCardStatusRequest) (*SetCardStatusResponse, error)
+	// Payment holds. All three are keyed by payment_id and safe to repeat.
+	AuthorizePayment(context.Context, *AuthorizePaymentRequest) (*CardHoldResponse, error)
+	CapturePayment(context.Context, *CardHoldRequest) (*CardHoldResponse, error)
+	ReleasePayment(context.Context, *CardHoldRequest) (*CardHoldResponse, error)
+	// Streams the card's current state, then every balance, limit or status
+	// change. Pass the last resume_token received to continue after a reconnect.
+	WatchCard(*WatchCardRequest, grpc.ServerStreamingServer[CardUpdate]) error
 	mustEmbedUnimplementedCardServiceServer()
 }
 
@@ -177,6 +257,21 @@ func (UnimplementedCardServiceServer) AddCard(context.Context, *AddCardRequest)
 func (UnimplementedCardServiceServer) RemoveCard(context.Context, *RemoveCardRequest) (*RemoveCardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveCard not implemented")
 }
+func (UnimplementedCardServiceServer) SetCardStatus(context.Context, *SetCardStatusRequest) (*SetCardStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCardStatus not implemented")
+}
+func (UnimplementedCardServiceServer) AuthorizePayment(context.Context, *AuthorizePaymentRequest) (*CardHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizePayment not implemented")
+}
+func (UnimplementedCardServiceServer) CapturePayment(context.Context, *CardHoldRequest) (*CardHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CapturePayment not implemented")
+}
+func (UnimplementedCardServiceServer) ReleasePayment(context.Context, *CardHoldRequest) (*CardHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleasePayment not implemented")
+}
+func (UnimplementedCardServiceServer) WatchCard(*WatchCardRequest, grpc.ServerStreamingServer[CardUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCard not implemented")
+}
 func (UnimplementedCardServiceServer) mustEmbedUnimplementedCardServiceServer() {}
 func (UnimplementedCardServiceServer) testEmbeddedByValue()                     {}
 
@@ -342,6 +437,89 @@ func _CardService_RemoveCard_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CardService_SetCardStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).SetCardStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_SetCardStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).SetCardStatus(ctx, req.(*SetCardStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_AuthorizePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).AuthorizePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_AuthorizePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).AuthorizePayment(ctx, req.(*AuthorizePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_CapturePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CardHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).CapturePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_CapturePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).CapturePayment(ctx, req.(*CardHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_ReleasePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CardHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).ReleasePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_ReleasePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).ReleasePayment(ctx, req.(*CardHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_WatchCard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CardServiceServer).WatchCard(m, &grpc.GenericServerStream[WatchCardRequest, CardUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CardService_WatchCardServer = grpc.ServerStreamingServer[CardUpdate]
+
 // CardService_ServiceDesc is the grpc.ServiceDesc for CardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -381,7 +559,29 @@ var CardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveCard",
 			Handler:    _CardService_RemoveCard_Handler,
 		},
+		{
+			MethodName: "SetCardStatus",
+			Handler:    _CardService_SetCardStatus_Handler,
+		},
+		{
+			MethodName: "AuthorizePayment",
+			Handler:    _CardService_AuthorizePayment_Handler,
+		},
+		{
+			MethodName: "CapturePayment",
+			Handler:    _CardService_CapturePayment_Handler,
+		},
+		{
+			MethodName: "ReleasePayment",
+			Handler:    _CardService_ReleasePayment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCard",
+			Handler:       _CardService_WatchCard_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/card/card.proto",
 }
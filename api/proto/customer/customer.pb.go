@@ -9,6 +9,7 @@ package customer
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -709,11 +710,1528 @@ func (x *ListCustomersResponse) GetCustomers() []*GetCustomerResponse {
 	return nil
 }
 
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	AccountType   string                 `protobuf:"bytes,3,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"` // "CHECKING", "SAVINGS", "JOINT"
+	AccountNumber string                 `protobuf:"bytes,4,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	Iban          string                 `protobuf:"bytes,5,opt,name=iban,proto3" json:"iban,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Balance       float64                `protobuf:"fixed64,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // "ACTIVE", "FROZEN", "CLOSED"
+	HolderIds     []uint32               `protobuf:"varint,9,rep,packed,name=holder_ids,json=holderIds,proto3" json:"holder_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_customer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Account) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *Account) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *Account) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *Account) GetIban() string {
+	if x != nil {
+		return x.Iban
+	}
+	return ""
+}
+
+func (x *Account) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Account) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Account) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Account) GetHolderIds() []uint32 {
+	if x != nil {
+		return x.HolderIds
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId     uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	AccountType    string                 `protobuf:"bytes,2,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	Currency       string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	JointHolderIds []uint32               `protobuf:"varint,4,rep,packed,name=joint_holder_ids,json=jointHolderIds,proto3" json:"joint_holder_ids,omitempty"` // Only for JOINT accounts
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_customer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateAccountRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CreateAccountRequest) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetJointHolderIds() []uint32 {
+	if x != nil {
+		return x.JointHolderIds
+	}
+	return nil
+}
+
+type AccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountResponse) Reset() {
+	*x = AccountResponse{}
+	mi := &file_customer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountResponse) ProtoMessage() {}
+
+func (x *AccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountResponse.ProtoReflect.Descriptor instead.
+func (*AccountResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AccountResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_customer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetAccountRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_customer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAccountsRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_customer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type UpdateAccountStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountStatusRequest) Reset() {
+	*x = UpdateAccountStatusRequest{}
+	mi := &file_customer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountStatusRequest) ProtoMessage() {}
+
+func (x *UpdateAccountStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountStatusRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateAccountStatusRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAccountStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CloseAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAccountRequest) Reset() {
+	*x = CloseAccountRequest{}
+	mi := &file_customer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountRequest) ProtoMessage() {}
+
+func (x *CloseAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountRequest.ProtoReflect.Descriptor instead.
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CloseAccountRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CloseAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAccountResponse) Reset() {
+	*x = CloseAccountResponse{}
+	mi := &file_customer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountResponse) ProtoMessage() {}
+
+func (x *CloseAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountResponse.ProtoReflect.Descriptor instead.
+func (*CloseAccountResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CloseAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AdjustBalanceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId uint32                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount    float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Set when the call is made while handling an event; a repeated
+	// (consumer, event_id) pair does not change the balance again.
+	EventId  string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Consumer string `protobuf:"bytes,4,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	// Per-aggregate ordering: the balance is only changed when `sequence`
+	// follows the last sequence applied for (consumer, aggregate_id).
+	AggregateId string `protobuf:"bytes,5,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	Sequence    uint64 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Credit only: reverses the debit made with this event_id by the same
+	// consumer. If that debit was never applied it is fenced off instead, so a
+	// late retry of it no longer changes the balance, and nothing is credited.
+	ReversesEventId string `protobuf:"bytes,7,opt,name=reverses_event_id,json=reversesEventId,proto3" json:"reverses_event_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdjustBalanceRequest) Reset() {
+	*x = AdjustBalanceRequest{}
+	mi := &file_customer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceRequest) ProtoMessage() {}
+
+func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AdjustBalanceRequest) GetAccountId() uint32 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *AdjustBalanceRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AdjustBalanceRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AdjustBalanceRequest) GetReversesEventId() string {
+	if x != nil {
+		return x.ReversesEventId
+	}
+	return ""
+}
+
+type Beneficiary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId       uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Nickname         string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Iban             string                 `protobuf:"bytes,4,opt,name=iban,proto3" json:"iban,omitempty"`
+	TargetCustomerId uint32                 `protobuf:"varint,5,opt,name=target_customer_id,json=targetCustomerId,proto3" json:"target_customer_id,omitempty"` // Internal payee, alternative to iban
+	DefaultReference string                 `protobuf:"bytes,6,opt,name=default_reference,json=defaultReference,proto3" json:"default_reference,omitempty"`
+	RequireStepUp    bool                   `protobuf:"varint,7,opt,name=require_step_up,json=requireStepUp,proto3" json:"require_step_up,omitempty"`
+	Status           string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // "PENDING_CONFIRMATION", "COOLING_OFF", "ACTIVE", "LOCKED"
+	AvailableAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=available_at,json=availableAt,proto3" json:"available_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Beneficiary) Reset() {
+	*x = Beneficiary{}
+	mi := &file_customer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Beneficiary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Beneficiary) ProtoMessage() {}
+
+func (x *Beneficiary) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Beneficiary.ProtoReflect.Descriptor instead.
+func (*Beneficiary) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Beneficiary) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Beneficiary) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *Beneficiary) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Beneficiary) GetIban() string {
+	if x != nil {
+		return x.Iban
+	}
+	return ""
+}
+
+func (x *Beneficiary) GetTargetCustomerId() uint32 {
+	if x != nil {
+		return x.TargetCustomerId
+	}
+	return 0
+}
+
+func (x *Beneficiary) GetDefaultReference() string {
+	if x != nil {
+		return x.DefaultReference
+	}
+	return ""
+}
+
+func (x *Beneficiary) GetRequireStepUp() bool {
+	if x != nil {
+		return x.RequireStepUp
+	}
+	return false
+}
+
+func (x *Beneficiary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Beneficiary) GetAvailableAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AvailableAt
+	}
+	return nil
+}
+
+type CreateBeneficiaryRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId       uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Nickname         string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Iban             string                 `protobuf:"bytes,3,opt,name=iban,proto3" json:"iban,omitempty"`
+	TargetCustomerId uint32                 `protobuf:"varint,4,opt,name=target_customer_id,json=targetCustomerId,proto3" json:"target_customer_id,omitempty"`
+	DefaultReference string                 `protobuf:"bytes,5,opt,name=default_reference,json=defaultReference,proto3" json:"default_reference,omitempty"`
+	RequireStepUp    bool                   `protobuf:"varint,6,opt,name=require_step_up,json=requireStepUp,proto3" json:"require_step_up,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateBeneficiaryRequest) Reset() {
+	*x = CreateBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBeneficiaryRequest) ProtoMessage() {}
+
+func (x *CreateBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*CreateBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateBeneficiaryRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CreateBeneficiaryRequest) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *CreateBeneficiaryRequest) GetIban() string {
+	if x != nil {
+		return x.Iban
+	}
+	return ""
+}
+
+func (x *CreateBeneficiaryRequest) GetTargetCustomerId() uint32 {
+	if x != nil {
+		return x.TargetCustomerId
+	}
+	return 0
+}
+
+func (x *CreateBeneficiaryRequest) GetDefaultReference() string {
+	if x != nil {
+		return x.DefaultReference
+	}
+	return ""
+}
+
+func (x *CreateBeneficiaryRequest) GetRequireStepUp() bool {
+	if x != nil {
+		return x.RequireStepUp
+	}
+	return false
+}
+
+type BeneficiaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Beneficiary   *Beneficiary           `protobuf:"bytes,1,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeneficiaryResponse) Reset() {
+	*x = BeneficiaryResponse{}
+	mi := &file_customer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeneficiaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeneficiaryResponse) ProtoMessage() {}
+
+func (x *BeneficiaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeneficiaryResponse.ProtoReflect.Descriptor instead.
+func (*BeneficiaryResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BeneficiaryResponse) GetBeneficiary() *Beneficiary {
+	if x != nil {
+		return x.Beneficiary
+	}
+	return nil
+}
+
+type GetBeneficiaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBeneficiaryRequest) Reset() {
+	*x = GetBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBeneficiaryRequest) ProtoMessage() {}
+
+func (x *GetBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*GetBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetBeneficiaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBeneficiariesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBeneficiariesRequest) Reset() {
+	*x = ListBeneficiariesRequest{}
+	mi := &file_customer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBeneficiariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBeneficiariesRequest) ProtoMessage() {}
+
+func (x *ListBeneficiariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBeneficiariesRequest.ProtoReflect.Descriptor instead.
+func (*ListBeneficiariesRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListBeneficiariesRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+type ListBeneficiariesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Beneficiaries []*Beneficiary         `protobuf:"bytes,1,rep,name=beneficiaries,proto3" json:"beneficiaries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBeneficiariesResponse) Reset() {
+	*x = ListBeneficiariesResponse{}
+	mi := &file_customer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBeneficiariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBeneficiariesResponse) ProtoMessage() {}
+
+func (x *ListBeneficiariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBeneficiariesResponse.ProtoReflect.Descriptor instead.
+func (*ListBeneficiariesResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListBeneficiariesResponse) GetBeneficiaries() []*Beneficiary {
+	if x != nil {
+		return x.Beneficiaries
+	}
+	return nil
+}
+
+// Fields left unset are not changed
+type UpdateBeneficiaryRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nickname         *string                `protobuf:"bytes,2,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+	DefaultReference *string                `protobuf:"bytes,3,opt,name=default_reference,json=defaultReference,proto3,oneof" json:"default_reference,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateBeneficiaryRequest) Reset() {
+	*x = UpdateBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBeneficiaryRequest) ProtoMessage() {}
+
+func (x *UpdateBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateBeneficiaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBeneficiaryRequest) GetNickname() string {
+	if x != nil && x.Nickname != nil {
+		return *x.Nickname
+	}
+	return ""
+}
+
+func (x *UpdateBeneficiaryRequest) GetDefaultReference() string {
+	if x != nil && x.DefaultReference != nil {
+		return *x.DefaultReference
+	}
+	return ""
+}
+
+type DeleteBeneficiaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBeneficiaryRequest) Reset() {
+	*x = DeleteBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBeneficiaryRequest) ProtoMessage() {}
+
+func (x *DeleteBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteBeneficiaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteBeneficiaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBeneficiaryResponse) Reset() {
+	*x = DeleteBeneficiaryResponse{}
+	mi := &file_customer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBeneficiaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBeneficiaryResponse) ProtoMessage() {}
+
+func (x *DeleteBeneficiaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBeneficiaryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBeneficiaryResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteBeneficiaryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ConfirmBeneficiaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmBeneficiaryRequest) Reset() {
+	*x = ConfirmBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmBeneficiaryRequest) ProtoMessage() {}
+
+func (x *ConfirmBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConfirmBeneficiaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConfirmBeneficiaryRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Fails unless the beneficiary belongs to the customer and can be paid
+type ResolveBeneficiaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveBeneficiaryRequest) Reset() {
+	*x = ResolveBeneficiaryRequest{}
+	mi := &file_customer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveBeneficiaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveBeneficiaryRequest) ProtoMessage() {}
+
+func (x *ResolveBeneficiaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveBeneficiaryRequest.ProtoReflect.Descriptor instead.
+func (*ResolveBeneficiaryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResolveBeneficiaryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResolveBeneficiaryRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+type GetCustomerHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // Starts at 1
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20, at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerHistoryRequest) Reset() {
+	*x = GetCustomerHistoryRequest{}
+	mi := &file_customer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerHistoryRequest) ProtoMessage() {}
+
+func (x *GetCustomerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetCustomerHistoryRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *GetCustomerHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetCustomerHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	FromJson      string                 `protobuf:"bytes,2,opt,name=from_json,json=fromJson,proto3" json:"from_json,omitempty"`
+	ToJson        string                 `protobuf:"bytes,3,opt,name=to_json,json=toJson,proto3" json:"to_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_customer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetFromJson() string {
+	if x != nil {
+		return x.FromJson
+	}
+	return ""
+}
+
+func (x *FieldChange) GetToJson() string {
+	if x != nil {
+		return x.ToJson
+	}
+	return ""
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // "CREATE", "UPDATE", "DELETE", "ERASE"
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"` // "grpc", "rest", "admin"
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	BeforeJson    string                 `protobuf:"bytes,7,opt,name=before_json,json=beforeJson,proto3" json:"before_json,omitempty"`
+	AfterJson     string                 `protobuf:"bytes,8,opt,name=after_json,json=afterJson,proto3" json:"after_json,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,9,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_customer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AuditEntry) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetBeforeJson() string {
+	if x != nil {
+		return x.BeforeJson
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAfterJson() string {
+	if x != nil {
+		return x.AfterJson
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetCustomerHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerHistoryResponse) Reset() {
+	*x = GetCustomerHistoryResponse{}
+	mi := &file_customer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerHistoryResponse) ProtoMessage() {}
+
+func (x *GetCustomerHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_customer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetCustomerHistoryResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetCustomerHistoryResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetCustomerHistoryResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetCustomerHistoryResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 var File_customer_proto protoreflect.FileDescriptor
 
 const file_customer_proto_rawDesc = "" +
 	"\n" +
-	"\x0ecustomer.proto\x12\bcustomer\"\xb3\x01\n" +
+	"\x0ecustomer.proto\x12\bcustomer\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
 	"\x15CreateCustomerRequest\x12\x1d\n" +
 	"\n" +
 	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
@@ -769,13 +2287,149 @@ const file_customer_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x16\n" +
 	"\x14ListCustomersRequest\"T\n" +
 	"\x15ListCustomersResponse\x12;\n" +
-	"\tcustomers\x18\x01 \x03(\v2\x1d.customer.GetCustomerResponseR\tcustomers2\xae\x03\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x1d.customer.GetCustomerResponseR\tcustomers\"\x85\x02\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12!\n" +
+	"\faccount_type\x18\x03 \x01(\tR\vaccountType\x12%\n" +
+	"\x0eaccount_number\x18\x04 \x01(\tR\raccountNumber\x12\x12\n" +
+	"\x04iban\x18\x05 \x01(\tR\x04iban\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x18\n" +
+	"\abalance\x18\a \x01(\x01R\abalance\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"holder_ids\x18\t \x03(\rR\tholderIds\"\xa0\x01\n" +
+	"\x14CreateAccountRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12!\n" +
+	"\faccount_type\x18\x02 \x01(\tR\vaccountType\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12(\n" +
+	"\x10joint_holder_ids\x18\x04 \x03(\rR\x0ejointHolderIds\">\n" +
+	"\x0fAccountResponse\x12+\n" +
+	"\aaccount\x18\x01 \x01(\v2\x11.customer.AccountR\aaccount\"#\n" +
+	"\x11GetAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"6\n" +
+	"\x13ListAccountsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\"E\n" +
+	"\x14ListAccountsResponse\x12-\n" +
+	"\baccounts\x18\x01 \x03(\v2\x11.customer.AccountR\baccounts\"D\n" +
+	"\x1aUpdateAccountStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"%\n" +
+	"\x13CloseAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"0\n" +
+	"\x14CloseAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xef\x01\n" +
+	"\x14AdjustBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\rR\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1a\n" +
+	"\bconsumer\x18\x04 \x01(\tR\bconsumer\x12!\n" +
+	"\faggregate_id\x18\x05 \x01(\tR\vaggregateId\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x04R\bsequence\x12*\n" +
+	"\x11reverses_event_id\x18\a \x01(\tR\x0freversesEventId\"\xc8\x02\n" +
+	"\vBeneficiary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12\x1a\n" +
+	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x12\n" +
+	"\x04iban\x18\x04 \x01(\tR\x04iban\x12,\n" +
+	"\x12target_customer_id\x18\x05 \x01(\rR\x10targetCustomerId\x12+\n" +
+	"\x11default_reference\x18\x06 \x01(\tR\x10defaultReference\x12&\n" +
+	"\x0frequire_step_up\x18\a \x01(\bR\rrequireStepUp\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12=\n" +
+	"\favailable_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vavailableAt\"\xee\x01\n" +
+	"\x18CreateBeneficiaryRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x12\n" +
+	"\x04iban\x18\x03 \x01(\tR\x04iban\x12,\n" +
+	"\x12target_customer_id\x18\x04 \x01(\rR\x10targetCustomerId\x12+\n" +
+	"\x11default_reference\x18\x05 \x01(\tR\x10defaultReference\x12&\n" +
+	"\x0frequire_step_up\x18\x06 \x01(\bR\rrequireStepUp\"N\n" +
+	"\x13BeneficiaryResponse\x127\n" +
+	"\vbeneficiary\x18\x01 \x01(\v2\x15.customer.BeneficiaryR\vbeneficiary\"'\n" +
+	"\x15GetBeneficiaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\";\n" +
+	"\x18ListBeneficiariesRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\"X\n" +
+	"\x19ListBeneficiariesResponse\x12;\n" +
+	"\rbeneficiaries\x18\x01 \x03(\v2\x15.customer.BeneficiaryR\rbeneficiaries\"\xa0\x01\n" +
+	"\x18UpdateBeneficiaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\bnickname\x18\x02 \x01(\tH\x00R\bnickname\x88\x01\x01\x120\n" +
+	"\x11default_reference\x18\x03 \x01(\tH\x01R\x10defaultReference\x88\x01\x01B\v\n" +
+	"\t_nicknameB\x14\n" +
+	"\x12_default_reference\"*\n" +
+	"\x18DeleteBeneficiaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"5\n" +
+	"\x19DeleteBeneficiaryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"?\n" +
+	"\x19ConfirmBeneficiaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"L\n" +
+	"\x19ResolveBeneficiaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\"m\n" +
+	"\x19GetCustomerHistoryRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"Y\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\tfrom_json\x18\x02 \x01(\tR\bfromJson\x12\x17\n" +
+	"\ato_json\x18\x03 \x01(\tR\x06toJson\"\xce\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\x12\x1f\n" +
+	"\vbefore_json\x18\a \x01(\tR\n" +
+	"beforeJson\x12\x1d\n" +
+	"\n" +
+	"after_json\x18\b \x01(\tR\tafterJson\x12/\n" +
+	"\achanges\x18\t \x03(\v2\x15.customer.FieldChangeR\achanges\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x93\x01\n" +
+	"\x1aGetCustomerHistoryResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.customer.AuditEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xa0\r\n" +
 	"\x0fCustomerService\x12S\n" +
 	"\x0eCreateCustomer\x12\x1f.customer.CreateCustomerRequest\x1a .customer.CreateCustomerResponse\x12J\n" +
 	"\vGetCustomer\x12\x1c.customer.GetCustomerRequest\x1a\x1d.customer.GetCustomerResponse\x12S\n" +
 	"\x0eUpdateCustomer\x12\x1f.customer.UpdateCustomerRequest\x1a .customer.UpdateCustomerResponse\x12S\n" +
 	"\x0eDeleteCustomer\x12\x1f.customer.DeleteCustomerRequest\x1a .customer.DeleteCustomerResponse\x12P\n" +
-	"\rListCustomers\x12\x1e.customer.ListCustomersRequest\x1a\x1f.customer.ListCustomersResponseB\x19Z\x17govo/api/proto/customerb\x06proto3"
+	"\rListCustomers\x12\x1e.customer.ListCustomersRequest\x1a\x1f.customer.ListCustomersResponse\x12_\n" +
+	"\x12GetCustomerHistory\x12#.customer.GetCustomerHistoryRequest\x1a$.customer.GetCustomerHistoryResponse\x12J\n" +
+	"\rCreateAccount\x12\x1e.customer.CreateAccountRequest\x1a\x19.customer.AccountResponse\x12D\n" +
+	"\n" +
+	"GetAccount\x12\x1b.customer.GetAccountRequest\x1a\x19.customer.AccountResponse\x12M\n" +
+	"\fListAccounts\x12\x1d.customer.ListAccountsRequest\x1a\x1e.customer.ListAccountsResponse\x12V\n" +
+	"\x13UpdateAccountStatus\x12$.customer.UpdateAccountStatusRequest\x1a\x19.customer.AccountResponse\x12M\n" +
+	"\fCloseAccount\x12\x1d.customer.CloseAccountRequest\x1a\x1e.customer.CloseAccountResponse\x12I\n" +
+	"\fDebitAccount\x12\x1e.customer.AdjustBalanceRequest\x1a\x19.customer.AccountResponse\x12J\n" +
+	"\rCreditAccount\x12\x1e.customer.AdjustBalanceRequest\x1a\x19.customer.AccountResponse\x12V\n" +
+	"\x11CreateBeneficiary\x12\".customer.CreateBeneficiaryRequest\x1a\x1d.customer.BeneficiaryResponse\x12P\n" +
+	"\x0eGetBeneficiary\x12\x1f.customer.GetBeneficiaryRequest\x1a\x1d.customer.BeneficiaryResponse\x12\\\n" +
+	"\x11ListBeneficiaries\x12\".customer.ListBeneficiariesRequest\x1a#.customer.ListBeneficiariesResponse\x12V\n" +
+	"\x11UpdateBeneficiary\x12\".customer.UpdateBeneficiaryRequest\x1a\x1d.customer.BeneficiaryResponse\x12\\\n" +
+	"\x11DeleteBeneficiary\x12\".customer.DeleteBeneficiaryRequest\x1a#.customer.DeleteBeneficiaryResponse\x12X\n" +
+	"\x12ConfirmBeneficiary\x12#.customer.ConfirmBeneficiaryRequest\x1a\x1d.customer.BeneficiaryResponse\x12X\n" +
+	"\x12ResolveBeneficiary\x12#.customer.ResolveBeneficiaryRequest\x1a\x1d.customer.BeneficiaryResponseB\x19Z\x17govo/api/proto/customerb\x06proto3"
 
 var (
 	file_customer_proto_rawDescOnce sync.Once
@@ -789,36 +2443,100 @@ func file_customer_proto_rawDescGZIP() []byte {
 	return file_customer_proto_rawDescData
 }
 
-var file_customer_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_customer_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_customer_proto_goTypes = []any{
-	(*CreateCustomerRequest)(nil),  // 0: customer.CreateCustomerRequest
-	(*CreateCustomerResponse)(nil), // 1: customer.CreateCustomerResponse
-	(*GetCustomerRequest)(nil),     // 2: customer.GetCustomerRequest
-	(*GetCustomerResponse)(nil),    // 3: customer.GetCustomerResponse
-	(*UpdateCustomerRequest)(nil),  // 4: customer.UpdateCustomerRequest
-	(*UpdateCustomerResponse)(nil), // 5: customer.UpdateCustomerResponse
-	(*DeleteCustomerRequest)(nil),  // 6: customer.DeleteCustomerRequest
-	(*DeleteCustomerResponse)(nil), // 7: customer.DeleteCustomerResponse
-	(*ListCustomersRequest)(nil),   // 8: customer.ListCustomersRequest
-	(*ListCustomersResponse)(nil),  // 9: customer.ListCustomersResponse
+	(*CreateCustomerRequest)(nil),      // 0: customer.CreateCustomerRequest
+	(*CreateCustomerResponse)(nil),     // 1: customer.CreateCustomerResponse
+	(*GetCustomerRequest)(nil),         // 2: customer.GetCustomerRequest
+	(*GetCustomerResponse)(nil),        // 3: customer.GetCustomerResponse
+	(*UpdateCustomerRequest)(nil),      // 4: customer.UpdateCustomerRequest
+	(*UpdateCustomerResponse)(nil),     // 5: customer.UpdateCustomerResponse
+	(*DeleteCustomerRequest)(nil),      // 6: customer.DeleteCustomerRequest
+	(*DeleteCustomerResponse)(nil),     // 7: customer.DeleteCustomerResponse
+	(*ListCustomersRequest)(nil),       // 8: customer.ListCustomersRequest
+	(*ListCustomersResponse)(nil),      // 9: customer.ListCustomersResponse
+	(*Account)(nil),                    // 10: customer.Account
+	(*CreateAccountRequest)(nil),       // 11: customer.CreateAccountRequest
+	(*AccountResponse)(nil),            // 12: customer.AccountResponse
+	(*GetAccountRequest)(nil),          // 13: customer.GetAccountRequest
+	(*ListAccountsRequest)(nil),        // 14: customer.ListAccountsRequest
+	(*ListAccountsResponse)(nil),       // 15: customer.ListAccountsResponse
+	(*UpdateAccountStatusRequest)(nil), // 16: customer.UpdateAccountStatusRequest
+	(*CloseAccountRequest)(nil),        // 17: customer.CloseAccountRequest
+	(*CloseAccountResponse)(nil),       // 18: customer.CloseAccountResponse
+	(*AdjustBalanceRequest)(nil),       // 19: customer.AdjustBalanceRequest
+	(*Beneficiary)(nil),                // 20: customer.Beneficiary
+	(*CreateBeneficiaryRequest)(nil),   // 21: customer.CreateBeneficiaryRequest
+	(*BeneficiaryResponse)(nil),        // 22: customer.BeneficiaryResponse
+	(*GetBeneficiaryRequest)(nil),      // 23: customer.GetBeneficiaryRequest
+	(*ListBeneficiariesRequest)(nil),   // 24: customer.ListBeneficiariesRequest
+	(*ListBeneficiariesResponse)(nil),  // 25: customer.ListBeneficiariesResponse
+	(*UpdateBeneficiaryRequest)(nil),   // 26: customer.UpdateBeneficiaryRequest
+	(*DeleteBeneficiaryRequest)(nil),   // 27: customer.DeleteBeneficiaryRequest
+	(*DeleteBeneficiaryResponse)(nil),  // 28: customer.DeleteBeneficiaryResponse
+	(*ConfirmBeneficiaryRequest)(nil),  // 29: customer.ConfirmBeneficiaryRequest
+	(*ResolveBeneficiaryRequest)(nil),  // 30: customer.ResolveBeneficiaryRequest
+	(*GetCustomerHistoryRequest)(nil),  // 31: customer.GetCustomerHistoryRequest
+	(*FieldChange)(nil),                // 32: customer.FieldChange
+	(*AuditEntry)(nil),                 // 33: customer.AuditEntry
+	(*GetCustomerHistoryResponse)(nil), // 34: customer.GetCustomerHistoryResponse
+	(*timestamppb.Timestamp)(nil),      // 35: google.protobuf.Timestamp
 }
 var file_customer_proto_depIdxs = []int32{
-	3, // 0: customer.ListCustomersResponse.customers:type_name -> customer.GetCustomerResponse
-	0, // 1: customer.CustomerService.CreateCustomer:input_type -> customer.CreateCustomerRequest
-	2, // 2: customer.CustomerService.GetCustomer:input_type -> customer.GetCustomerRequest
-	4, // 3: customer.CustomerService.UpdateCustomer:input_type -> customer.UpdateCustomerRequest
-	6, // 4: customer.CustomerService.DeleteCustomer:input_type -> customer.DeleteCustomerRequest
-	8, // 5: customer.CustomerService.ListCustomers:input_type -> customer.ListCustomersRequest
-	1, // 6: customer.CustomerService.CreateCustomer:output_type -> customer.CreateCustomerResponse
-	3, // 7: customer.CustomerService.GetCustomer:output_type -> customer.GetCustomerResponse
-	5, // 8: customer.CustomerService.UpdateCustomer:output_type -> customer.UpdateCustomerResponse
-	7, // 9: customer.CustomerService.DeleteCustomer:output_type -> customer.DeleteCustomerResponse
-	9, // 10: customer.CustomerService.ListCustomers:output_type -> customer.ListCustomersResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: customer.ListCustomersResponse.customers:type_name -> customer.GetCustomerResponse
+	10, // 1: customer.AccountResponse.account:type_name -> customer.Account
+	10, // 2: customer.ListAccountsResponse.accounts:type_name -> customer.Account
+	35, // 3: customer.Beneficiary.available_at:type_name -> google.protobuf.Timestamp
+	20, // 4: customer.BeneficiaryResponse.beneficiary:type_name -> customer.Beneficiary
+	20, // 5: customer.ListBeneficiariesResponse.beneficiaries:type_name -> customer.Beneficiary
+	32, // 6: customer.AuditEntry.changes:type_name -> customer.FieldChange
+	35, // 7: customer.AuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	33, // 8: customer.GetCustomerHistoryResponse.entries:type_name -> customer.AuditEntry
+	0,  // 9: customer.CustomerService.CreateCustomer:input_type -> customer.CreateCustomerRequest
+	2,  // 10: customer.CustomerService.GetCustomer:input_type -> customer.GetCustomerRequest
+	4,  // 11: customer.CustomerService.UpdateCustomer:input_type -> customer.UpdateCustomerRequest
+	6,  // 12: customer.CustomerService.DeleteCustomer:input_type -> customer.DeleteCustomerRequest
+	8,  // 13: customer.CustomerService.ListCustomers:input_type -> customer.ListCustomersRequest
+	31, // 14: customer.CustomerService.GetCustomerHistory:input_type -> customer.GetCustomerHistoryRequest
+	11, // 15: customer.CustomerService.CreateAccount:input_type -> customer.CreateAccountRequest
+	13, // 16: customer.CustomerService.GetAccount:input_type -> customer.GetAccountRequest
+	14, // 17: customer.CustomerService.ListAccounts:input_type -> customer.ListAccountsRequest
+	16, // 18: customer.CustomerService.UpdateAccountStatus:input_type -> customer.UpdateAccountStatusRequest
+	17, // 19: customer.CustomerService.CloseAccount:input_type -> customer.CloseAccountRequest
+	19, // 20: customer.CustomerService.DebitAccount:input_type -> customer.AdjustBalanceRequest
+	19, // 21: customer.CustomerService.CreditAccount:input_type -> customer.AdjustBalanceRequest
+	21, // 22: customer.CustomerService.CreateBeneficiary:input_type -> customer.CreateBeneficiaryRequest
+	23, // 23: customer.CustomerService.GetBeneficiary:input_type -> customer.GetBeneficiaryRequest
+	24, // 24: customer.CustomerService.ListBeneficiaries:input_type -> customer.ListBeneficiariesRequest
+	26, // 25: customer.CustomerService.UpdateBeneficiary:input_type -> customer.UpdateBeneficiaryRequest
+	27, // 26: customer.CustomerService.DeleteBeneficiary:input_type -> customer.DeleteBeneficiaryRequest
+	29, // 27: customer.CustomerService.ConfirmBeneficiary:input_type -> customer.ConfirmBeneficiaryRequest
+	30, // 28: customer.CustomerService.ResolveBeneficiary:input_type -> customer.ResolveBeneficiaryRequest
+	1,  // 29: customer.CustomerService.CreateCustomer:output_type -> customer.CreateCustomerResponse
+	3,  // 30: customer.CustomerService.GetCustomer:output_type -> customer.GetCustomerResponse
+	5,  // 31: customer.CustomerService.UpdateCustomer:output_type -> customer.UpdateCustomerResponse
+	7,  // 32: customer.CustomerService.DeleteCustomer:output_type -> customer.DeleteCustomerResponse
+	9,  // 33: customer.CustomerService.ListCustomers:output_type -> customer.ListCustomersResponse
+	34, // 34: customer.CustomerService.GetCustomerHistory:output_type -> customer.GetCustomerHistoryResponse
+	12, // 35: customer.CustomerService.CreateAccount:output_type -> customer.AccountResponse
+	12, // 36: customer.CustomerService.GetAccount:output_type -> customer.AccountResponse
+	15, // 37: customer.CustomerService.ListAccounts:output_type -> customer.ListAccountsResponse
+	12, // 38: customer.CustomerService.UpdateAccountStatus:output_type -> customer.AccountResponse
+	18, // 39: customer.CustomerService.CloseAccount:output_type -> customer.CloseAccountResponse
+	12, // 40: customer.CustomerService.DebitAccount:output_type -> customer.AccountResponse
+	12, // 41: customer.CustomerService.CreditAccount:output_type -> customer.AccountResponse
+	22, // 42: customer.CustomerService.CreateBeneficiary:output_type -> customer.BeneficiaryResponse
+	22, // 43: customer.CustomerService.GetBeneficiary:output_type -> customer.BeneficiaryResponse
+	25, // 44: customer.CustomerService.ListBeneficiaries:output_type -> customer.ListBeneficiariesResponse
+	22, // 45: customer.CustomerService.UpdateBeneficiary:output_type -> customer.BeneficiaryResponse
+	28, // 46: customer.CustomerService.DeleteBeneficiary:output_type -> customer.DeleteBeneficiaryResponse
+	22, // 47: customer.CustomerService.ConfirmBeneficiary:output_type -> customer.BeneficiaryResponse
+	22, // 48: customer.CustomerService.ResolveBeneficiary:output_type -> customer.BeneficiaryResponse
+	29, // [29:49] is the sub-list for method output_type
+	9,  // [9:29] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_customer_proto_init() }
@@ -826,13 +2544,14 @@ func file_customer_proto_init() {
 	if File_customer_proto != nil {
 		return
 	}
+	file_customer_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_customer_proto_rawDesc), len(file_customer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
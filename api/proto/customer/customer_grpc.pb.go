@@ -18,11 +18,26 @@ import (
 // Requires gRPC-Go v1.64.0 or later.
 
 const (
-	CustomerService_CreateCustomer_FullMethodName = "/customer.CustomerService/CreateCustomer"
-	CustomerService_GetCustomer_FullMethodName    = "/customer.CustomerService/GetCustomer"
-	CustomerService_UpdateCustomer_FullMethodName = "/customer.CustomerService/UpdateCustomer"
-	CustomerService_DeleteCustomer_FullMethodName = "/customer.CustomerService/DeleteCustomer"
-	CustomerService_ListCustomers_FullMethodName  = "/customer.CustomerService/ListCustomers"
+	CustomerService_CreateCustomer_FullMethodName      = "/customer.CustomerService/CreateCustomer"
+	CustomerService_GetCustomer_FullMethodName         = "/customer.CustomerService/GetCustomer"
+	CustomerService_UpdateCustomer_FullMethodName      = "/customer.CustomerService/UpdateCustomer"
+	CustomerService_DeleteCustomer_FullMethodName      = "/customer.CustomerService/DeleteCustomer"
+	CustomerService_ListCustomers_FullMethodName       = "/customer.CustomerService/ListCustomers"
+	CustomerService_GetCustomerHistory_FullMethodName  = "/customer.CustomerService/GetCustomerHistory"
+	CustomerService_CreateAccount_FullMethodName       = "/customer.CustomerService/CreateAccount"
+	CustomerService_GetAccount_FullMethodName          = "/customer.CustomerService/GetAccount"
+	CustomerService_ListAccounts_FullMethodName        = "/customer.CustomerService/ListAccounts"
+	CustomerService_UpdateAccountStatus_FullMethodName = "/customer.CustomerService/UpdateAccountStatus"
+	CustomerService_CloseAccount_FullMethodName        = "/customer.CustomerService/CloseAccount"
+	CustomerService_DebitAccount_FullMethodName        = "/customer.CustomerService/DebitAccount"
+	CustomerService_CreditAccount_FullMethodName       = "/customer.CustomerService/CreditAccount"
+	CustomerService_CreateBeneficiary_FullMethodName   = "/customer.CustomerService/CreateBeneficiary"
+	CustomerService_GetBeneficiary_FullMethodName      = "/customer.CustomerService/GetBeneficiary"
+	CustomerService_ListBeneficiaries_FullMethodName   = "/customer.CustomerService/ListBeneficiaries"
+	CustomerService_UpdateBeneficiary_FullMethodName   = "/customer.CustomerService/UpdateBeneficiary"
+	CustomerService_DeleteBeneficiary_FullMethodName   = "/customer.CustomerService/DeleteBeneficiary"
+	CustomerService_ConfirmBeneficiary_FullMethodName  = "/customer.CustomerService/ConfirmBeneficiary"
+	CustomerService_ResolveBeneficiary_FullMethodName  = "/customer.CustomerService/ResolveBeneficiary"
 )
 
 // CustomerServiceClient is the client API for CustomerService service.
@@ -34,6 +49,21 @@ type CustomerServiceClient interface {
 	UpdateCustomer(ctx context.Context, in *UpdateCustomerRequest, opts ...grpc.CallOption) (*UpdateCustomerResponse, error)
 	DeleteCustomer(ctx context.Context, in *DeleteCustomerRequest, opts ...grpc.CallOption) (*DeleteCustomerResponse, error)
 	ListCustomers(ctx context.Context, in *ListCustomersRequest, opts ...grpc.CallOption) (*ListCustomersResponse, error)
+	GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*GetCustomerHistoryResponse, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	UpdateAccountStatus(ctx context.Context, in *UpdateAccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error)
+	DebitAccount(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	CreditAccount(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	CreateBeneficiary(ctx context.Context, in *CreateBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error)
+	GetBeneficiary(ctx context.Context, in *GetBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error)
+	ListBeneficiaries(ctx context.Context, in *ListBeneficiariesRequest, opts ...grpc.CallOption) (*ListBeneficiariesResponse, error)
+	UpdateBeneficiary(ctx context.Context, in *UpdateBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error)
+	DeleteBeneficiary(ctx context.Context, in *DeleteBeneficiaryRequest, opts ...grpc.CallOption) (*DeleteBeneficiaryResponse, error)
+	ConfirmBeneficiary(ctx context.Context, in *ConfirmBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error)
+	ResolveBeneficiary(ctx context.Context, in *ResolveBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error)
 }
 
 type customerServiceClient struct {
@@ -94,6 +124,156 @@ func (c *customerServiceClient) ListCustomers(ctx context.Context, in *ListCusto
 	return out, nil
 }
 
+func (c *customerServiceClient) GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*GetCustomerHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCustomerHistoryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_GetCustomerHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, CustomerService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) UpdateAccountStatus(ctx context.Context, in *UpdateAccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_UpdateAccountStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseAccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_CloseAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) DebitAccount(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_DebitAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) CreditAccount(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, CustomerService_CreditAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) CreateBeneficiary(ctx context.Context, in *CreateBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_CreateBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) GetBeneficiary(ctx context.Context, in *GetBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_GetBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ListBeneficiaries(ctx context.Context, in *ListBeneficiariesRequest, opts ...grpc.CallOption) (*ListBeneficiariesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBeneficiariesResponse)
+	err := c.cc.Invoke(ctx, CustomerService_ListBeneficiaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) UpdateBeneficiary(ctx context.Context, in *UpdateBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_UpdateBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) DeleteBeneficiary(ctx context.Context, in *DeleteBeneficiaryRequest, opts ...grpc.CallOption) (*DeleteBeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_DeleteBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ConfirmBeneficiary(ctx context.Context, in *ConfirmBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_ConfirmBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerServiceClient) ResolveBeneficiary(ctx context.Context, in *ResolveBeneficiaryRequest, opts ...grpc.CallOption) (*BeneficiaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeneficiaryResponse)
+	err := c.cc.Invoke(ctx, CustomerService_ResolveBeneficiary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CustomerServiceServer is the server API for CustomerService service.
 // All implementations must embed UnimplementedCustomerServiceServer
 // for forward compatibility.
@@ -103,6 +283,21 @@ type CustomerServiceServer interface {
 	UpdateCustomer(context.Context, *UpdateCustomerRequest) (*UpdateCustomerResponse, error)
 	DeleteCustomer(context.Context, *DeleteCustomerRequest) (*DeleteCustomerResponse, error)
 	ListCustomers(context.Context, *ListCustomersRequest) (*ListCustomersResponse, error)
+	GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*GetCustomerHistoryResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
+	GetAccount(context.Context, *GetAccountRequest) (*AccountResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	UpdateAccountStatus(context.Context, *UpdateAccountStatusRequest) (*AccountResponse, error)
+	CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error)
+	DebitAccount(context.Context, *AdjustBalanceRequest) (*AccountResponse, error)
+	CreditAccount(context.Context, *AdjustBalanceRequest) (*AccountResponse, error)
+	CreateBeneficiary(context.Context, *CreateBeneficiaryRequest) (*BeneficiaryResponse, error)
+	GetBeneficiary(context.Context, *GetBeneficiaryRequest) (*BeneficiaryResponse, error)
+	ListBeneficiaries(context.Context, *ListBeneficiariesRequest) (*ListBeneficiariesResponse, error)
+	UpdateBeneficiary(context.Context, *UpdateBeneficiaryRequest) (*BeneficiaryResponse, error)
+	DeleteBeneficiary(context.Context, *DeleteBeneficiaryRequest) (*DeleteBeneficiaryResponse, error)
+	ConfirmBeneficiary(context.Context, *ConfirmBeneficiaryRequest) (*BeneficiaryResponse, error)
+	ResolveBeneficiary(context.Context, *ResolveBeneficiaryRequest) (*BeneficiaryResponse, error)
 	mustEmbedUnimplementedCustomerServiceServer()
 }
 
@@ -128,6 +323,51 @@ func (UnimplementedCustomerServiceServer) DeleteCustomer(context.Context, *Delet
 func (UnimplementedCustomerServiceServer) ListCustomers(context.Context, *ListCustomersRequest) (*ListCustomersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCustomers not implemented")
 }
+func (UnimplementedCustomerServiceServer) GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*GetCustomerHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerHistory not implemented")
+}
+func (UnimplementedCustomerServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedCustomerServiceServer) GetAccount(context.Context, *GetAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedCustomerServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedCustomerServiceServer) UpdateAccountStatus(context.Context, *UpdateAccountStatusRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccountStatus not implemented")
+}
+func (UnimplementedCustomerServiceServer) CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedCustomerServiceServer) DebitAccount(context.Context, *AdjustBalanceRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebitAccount not implemented")
+}
+func (UnimplementedCustomerServiceServer) CreditAccount(context.Context, *AdjustBalanceRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreditAccount not implemented")
+}
+func (UnimplementedCustomerServiceServer) CreateBeneficiary(context.Context, *CreateBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBeneficiary not implemented")
+}
+func (UnimplementedCustomerServiceServer) GetBeneficiary(context.Context, *GetBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBeneficiary not implemented")
+}
+func (UnimplementedCustomerServiceServer) ListBeneficiaries(context.Context, *ListBeneficiariesRequest) (*ListBeneficiariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBeneficiaries not implemented")
+}
+func (UnimplementedCustomerServiceServer) UpdateBeneficiary(context.Context, *UpdateBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBeneficiary not implemented")
+}
+func (UnimplementedCustomerServiceServer) DeleteBeneficiary(context.Context, *DeleteBeneficiaryRequest) (*DeleteBeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBeneficiary not implemented")
+}
+func (UnimplementedCustomerServiceServer) ConfirmBeneficiary(context.Context, *ConfirmBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmBeneficiary not implemented")
+}
+func (UnimplementedCustomerServiceServer) ResolveBeneficiary(context.Context, *ResolveBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveBeneficiary not implemented")
+}
 func (UnimplementedCustomerServiceServer) mustEmbedUnimplementedCustomerServiceServer() {}
 func (UnimplementedCustomerServiceServer) testEmbeddedByValue()                         {}
 
@@ -239,6 +479,276 @@ func _CustomerService_ListCustomers_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CustomerService_GetCustomerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).GetCustomerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_GetCustomerHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).GetCustomerHistory(ctx, req.(*GetCustomerHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_UpdateAccountStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).UpdateAccountStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_UpdateAccountStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).UpdateAccountStatus(ctx, req.(*UpdateAccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).CloseAccount(ctx, req.(*CloseAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_DebitAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).DebitAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_DebitAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).DebitAccount(ctx, req.(*AdjustBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_CreditAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).CreditAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_CreditAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).CreditAccount(ctx, req.(*AdjustBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_CreateBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).CreateBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_CreateBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).CreateBeneficiary(ctx, req.(*CreateBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_GetBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).GetBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_GetBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).GetBeneficiary(ctx, req.(*GetBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ListBeneficiaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeneficiariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ListBeneficiaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_ListBeneficiaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ListBeneficiaries(ctx, req.(*ListBeneficiariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_UpdateBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).UpdateBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_UpdateBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).UpdateBeneficiary(ctx, req.(*UpdateBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_DeleteBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).DeleteBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_DeleteBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).DeleteBeneficiary(ctx, req.(*DeleteBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ConfirmBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ConfirmBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_ConfirmBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ConfirmBeneficiary(ctx, req.(*ConfirmBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerService_ResolveBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServiceServer).ResolveBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerService_ResolveBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServiceServer).ResolveBeneficiary(ctx, req.(*ResolveBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CustomerService_ServiceDesc is the grpc.ServiceDesc for CustomerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -266,6 +776,66 @@ var CustomerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCustomers",
 			Handler:    _CustomerService_ListCustomers_Handler,
 		},
+		{
+			MethodName: "GetCustomerHistory",
+			Handler:    _CustomerService_GetCustomerHistory_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _CustomerService_CreateAccount_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _CustomerService_GetAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _CustomerService_ListAccounts_Handler,
+		},
+		{
+			MethodName: "UpdateAccountStatus",
+			Handler:    _CustomerService_UpdateAccountStatus_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _CustomerService_CloseAccount_Handler,
+		},
+		{
+			MethodName: "DebitAccount",
+			Handler:    _CustomerService_DebitAccount_Handler,
+		},
+		{
+			MethodName: "CreditAccount",
+			Handler:    _CustomerService_CreditAccount_Handler,
+		},
+		{
+			MethodName: "CreateBeneficiary",
+			Handler:    _CustomerService_CreateBeneficiary_Handler,
+		},
+		{
+			MethodName: "GetBeneficiary",
+			Handler:    _CustomerService_GetBeneficiary_Handler,
+		},
+		{
+			MethodName: "ListBeneficiaries",
+			Handler:    _CustomerService_ListBeneficiaries_Handler,
+		},
+		{
+			MethodName: "UpdateBeneficiary",
+			Handler:    _CustomerService_UpdateBeneficiary_Handler,
+		},
+		{
+			MethodName: "DeleteBeneficiary",
+			Handler:    _CustomerService_DeleteBeneficiary_Handler,
+		},
+		{
+			MethodName: "ConfirmBeneficiary",
+			Handler:    _CustomerService_ConfirmBeneficiary_Handler,
+		},
+		{
+			MethodName: "ResolveBeneficiary",
+			Handler:    _CustomerService_ResolveBeneficiary_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "customer.proto",
@@ -0,0 +1,1002 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0--rc2
+// source: events.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Every message on Kafka is wrapped in an Envelope. The payload type is
+// determined by `type`; `version` is the schema version of that payload.
+// `aggregate_id` is also the message key, so all events of one aggregate
+// land on the same partition. `sequence` starts at 1 and grows by one with
+// every event of the aggregate; 0 means the producer does not track order.
+type Envelope struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	EventId     string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type        string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // "PAYMENT_CREATED", "CARD_ISSUED", ...
+	Version     int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	TraceId     string                 `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	Payload     *anypb.Any             `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	AggregateId string                 `protobuf:"bytes,7,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	Sequence    uint64                 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Registry ID of the payload schema the producer registered; 0 when the
+	// producer ran without a schema registry.
+	SchemaId      int32 `protobuf:"varint,9,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetPayload() *anypb.Any {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *Envelope) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Envelope) GetSchemaId() int32 {
+	if x != nil {
+		return x.SchemaId
+	}
+	return 0
+}
+
+// PAYMENT_CREATED, PAYMENT_CANCELLED, PAYMENT_COMPLETED, PAYMENT_FAILED
+type PaymentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     uint32                 `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CardId        uint32                 `protobuf:"varint,3,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	AccountId     uint32                 `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BeneficiaryId uint32                 `protobuf:"varint,5,opt,name=beneficiary_id,json=beneficiaryId,proto3" json:"beneficiary_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaymentType   string                 `protobuf:"bytes,7,opt,name=payment_type,json=paymentType,proto3" json:"payment_type,omitempty"` // "CARD" or "CASH"
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CancelledAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// PAYMENT_FAILED only: why the payment saga gave up.
+	FailureReason string `protobuf:"bytes,13,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentEvent) Reset() {
+	*x = PaymentEvent{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentEvent) ProtoMessage() {}
+
+func (x *PaymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentEvent.ProtoReflect.Descriptor instead.
+func (*PaymentEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentEvent) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetAccountId() uint32 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetBeneficiaryId() uint32 {
+	if x != nil {
+		return x.BeneficiaryId
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentEvent) GetPaymentType() string {
+	if x != nil {
+		return x.PaymentType
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+// CARD_ISSUED, CARD_UPDATED, CARD_STATUS_CHANGED, CARD_LIMIT_CHANGED, CARD_REMOVED
+// The PAN, CVV and expiry date never leave the card service.
+type CardEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CardId              uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CustomerId          uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaskedNumber        string                 `protobuf:"bytes,3,opt,name=masked_number,json=maskedNumber,proto3" json:"masked_number,omitempty"`
+	CardType            string                 `protobuf:"bytes,4,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`
+	IsActive            bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreditLimit         float64                `protobuf:"fixed64,6,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"`
+	PreviousCreditLimit float64                `protobuf:"fixed64,7,opt,name=previous_credit_limit,json=previousCreditLimit,proto3" json:"previous_credit_limit,omitempty"` // CARD_LIMIT_CHANGED only
+	ChangedFields       []string               `protobuf:"bytes,8,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`                       // CARD_UPDATED only
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CardEvent) Reset() {
+	*x = CardEvent{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardEvent) ProtoMessage() {}
+
+func (x *CardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardEvent.ProtoReflect.Descriptor instead.
+func (*CardEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CardEvent) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *CardEvent) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CardEvent) GetMaskedNumber() string {
+	if x != nil {
+		return x.MaskedNumber
+	}
+	return ""
+}
+
+func (x *CardEvent) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *CardEvent) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *CardEvent) GetCreditLimit() float64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *CardEvent) GetPreviousCreditLimit() float64 {
+	if x != nil {
+		return x.PreviousCreditLimit
+	}
+	return 0
+}
+
+func (x *CardEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// CUSTOMER_CREATED, CUSTOMER_UPDATED, CUSTOMER_DELETED
+// Carries no personal data; consumers that need contact details ask the
+// customer service, which applies its own access checks.
+type CustomerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // CUSTOMER_UPDATED: field names only, never values
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerEvent) Reset() {
+	*x = CustomerEvent{}
+	mi := &file_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerEvent) ProtoMessage() {}
+
+func (x *CustomerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerEvent.ProtoReflect.Descriptor instead.
+func (*CustomerEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CustomerEvent) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// CARD_STATE on the compacted cards.state topic, keyed by card id.
+// Full current state after every change; a tombstone (null value) follows
+// removal. Like CardEvent it never carries the PAN, CVV or expiry date.
+type CardState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaskedNumber  string                 `protobuf:"bytes,3,opt,name=masked_number,json=maskedNumber,proto3" json:"masked_number,omitempty"`
+	CardType      string                 `protobuf:"bytes,4,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`
+	CreditLimit   float64                `protobuf:"fixed64,5,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"`
+	Balance       float64                `protobuf:"fixed64,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	IsActive      bool                   `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CardState) Reset() {
+	*x = CardState{}
+	mi := &file_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardState) ProtoMessage() {}
+
+func (x *CardState) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardState.ProtoReflect.Descriptor instead.
+func (*CardState) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CardState) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *CardState) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CardState) GetMaskedNumber() string {
+	if x != nil {
+		return x.MaskedNumber
+	}
+	return ""
+}
+
+func (x *CardState) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *CardState) GetCreditLimit() float64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *CardState) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *CardState) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *CardState) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CardState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CUSTOMER_STATE on the compacted customers.state topic, keyed by customer id.
+// Current state after every change; like CustomerEvent it never carries the
+// customer's personal data, which stays in the customer service. Deletion and
+// erasure write a tombstone, which removes the record once the topic is
+// compacted.
+type CustomerState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerState) Reset() {
+	*x = CustomerState{}
+	mi := &file_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerState) ProtoMessage() {}
+
+func (x *CustomerState) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerState.ProtoReflect.Descriptor instead.
+func (*CustomerState) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CustomerState) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerState) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CustomerState) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CustomerState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CUSTOMER_ERASURE_REQUESTED
+type CustomerErasureRequested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErasureId     uint32                 `protobuf:"varint,1,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"`
+	CustomerId    uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerErasureRequested) Reset() {
+	*x = CustomerErasureRequested{}
+	mi := &file_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerErasureRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerErasureRequested) ProtoMessage() {}
+
+func (x *CustomerErasureRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerErasureRequested.ProtoReflect.Descriptor instead.
+func (*CustomerErasureRequested) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CustomerErasureRequested) GetErasureId() uint32 {
+	if x != nil {
+		return x.ErasureId
+	}
+	return 0
+}
+
+func (x *CustomerErasureRequested) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerErasureRequested) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+// CUSTOMER_ERASURE_COMPLETED
+type CustomerErasureCompleted struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ErasureId         uint32                 `protobuf:"varint,1,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"`
+	CustomerId        uint32                 `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Service           string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	AnonymizedRecords int64                  `protobuf:"varint,4,opt,name=anonymized_records,json=anonymizedRecords,proto3" json:"anonymized_records,omitempty"`
+	RetainedRecords   int64                  `protobuf:"varint,5,opt,name=retained_records,json=retainedRecords,proto3" json:"retained_records,omitempty"`
+	Error             string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CustomerErasureCompleted) Reset() {
+	*x = CustomerErasureCompleted{}
+	mi := &file_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerErasureCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerErasureCompleted) ProtoMessage() {}
+
+func (x *CustomerErasureCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerErasureCompleted.ProtoReflect.Descriptor instead.
+func (*CustomerErasureCompleted) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CustomerErasureCompleted) GetErasureId() uint32 {
+	if x != nil {
+		return x.ErasureId
+	}
+	return 0
+}
+
+func (x *CustomerErasureCompleted) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerErasureCompleted) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *CustomerErasureCompleted) GetAnonymizedRecords() int64 {
+	if x != nil {
+		return x.AnonymizedRecords
+	}
+	return 0
+}
+
+func (x *CustomerErasureCompleted) GetRetainedRecords() int64 {
+	if x != nil {
+		return x.RetainedRecords
+	}
+	return 0
+}
+
+func (x *CustomerErasureCompleted) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// STEP_UP_CODE_ISSUED
+type StepUpCodeIssued struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	BeneficiaryId uint32                 `protobuf:"varint,2,opt,name=beneficiary_id,json=beneficiaryId,proto3" json:"beneficiary_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// The code is never written in clear text. AES-256-GCM with the key
+	// SHA-256(STEP_UP_CODE_KEY) shared with the notification service; the
+	// 12-byte nonce is prepended and the decimal beneficiary_id is the
+	// additional authenticated data.
+	EncryptedCode []byte `protobuf:"bytes,5,opt,name=encrypted_code,json=encryptedCode,proto3" json:"encrypted_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepUpCodeIssued) Reset() {
+	*x = StepUpCodeIssued{}
+	mi := &file_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepUpCodeIssued) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepUpCodeIssued) ProtoMessage() {}
+
+func (x *StepUpCodeIssued) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepUpCodeIssued.ProtoReflect.Descriptor instead.
+func (*StepUpCodeIssued) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StepUpCodeIssued) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *StepUpCodeIssued) GetBeneficiaryId() uint32 {
+	if x != nil {
+		return x.BeneficiaryId
+	}
+	return 0
+}
+
+func (x *StepUpCodeIssued) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StepUpCodeIssued) GetEncryptedCode() []byte {
+	if x != nil {
+		return x.EncryptedCode
+	}
+	return nil
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\x06events\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x02\n" +
+	"\bEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x19\n" +
+	"\btrace_id\x18\x05 \x01(\tR\atraceId\x12.\n" +
+	"\apayload\x18\x06 \x01(\v2\x14.google.protobuf.AnyR\apayload\x12!\n" +
+	"\faggregate_id\x18\a \x01(\tR\vaggregateId\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x04R\bsequence\x12\x1b\n" +
+	"\tschema_id\x18\t \x01(\x05R\bschemaId\"\x82\x04\n" +
+	"\fPaymentEvent\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\rR\tpaymentId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12\x17\n" +
+	"\acard_id\x18\x03 \x01(\rR\x06cardId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x04 \x01(\rR\taccountId\x12%\n" +
+	"\x0ebeneficiary_id\x18\x05 \x01(\rR\rbeneficiaryId\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12!\n" +
+	"\fpayment_type\x18\a \x01(\tR\vpaymentType\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcancelled_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12=\n" +
+	"\fcompleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12%\n" +
+	"\x0efailure_reason\x18\r \x01(\tR\rfailureReason\"\xa2\x02\n" +
+	"\tCardEvent\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12#\n" +
+	"\rmasked_number\x18\x03 \x01(\tR\fmaskedNumber\x12\x1b\n" +
+	"\tcard_type\x18\x04 \x01(\tR\bcardType\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12!\n" +
+	"\fcredit_limit\x18\x06 \x01(\x01R\vcreditLimit\x122\n" +
+	"\x15previous_credit_limit\x18\a \x01(\x01R\x13previousCreditLimit\x12%\n" +
+	"\x0echanged_fields\x18\b \x03(\tR\rchangedFields\"W\n" +
+	"\rCustomerEvent\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"\xb8\x02\n" +
+	"\tCardState\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12#\n" +
+	"\rmasked_number\x18\x03 \x01(\tR\fmaskedNumber\x12\x1b\n" +
+	"\tcard_type\x18\x04 \x01(\tR\bcardType\x12!\n" +
+	"\fcredit_limit\x18\x05 \x01(\x01R\vcreditLimit\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x01R\abalance\x12\x1b\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x04R\bsequence\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\x01\n" +
+	"\rCustomerState\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x04R\bsequence\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x02\x10\aR\n" +
+	"first_nameR\tlast_nameR\x05emailR\x05phoneR\aaddress\"\x99\x01\n" +
+	"\x18CustomerErasureRequested\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x01 \x01(\rR\terasureId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12=\n" +
+	"\frequested_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\"\xe4\x01\n" +
+	"\x18CustomerErasureCompleted\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x01 \x01(\rR\terasureId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\rR\n" +
+	"customerId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12-\n" +
+	"\x12anonymized_records\x18\x04 \x01(\x03R\x11anonymizedRecords\x12)\n" +
+	"\x10retained_records\x18\x05 \x01(\x03R\x0fretainedRecords\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xc8\x01\n" +
+	"\x10StepUpCodeIssued\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12%\n" +
+	"\x0ebeneficiary_id\x18\x02 \x01(\rR\rbeneficiaryId\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12%\n" +
+	"\x0eencrypted_code\x18\x05 \x01(\fR\rencryptedCodeJ\x04\b\x03\x10\x04R\x04codeB\x17Z\x15govo/api/proto/eventsb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_events_proto_goTypes = []any{
+	(*Envelope)(nil),                 // 0: events.Envelope
+	(*PaymentEvent)(nil),             // 1: events.PaymentEvent
+	(*CardEvent)(nil),                // 2: events.CardEvent
+	(*CustomerEvent)(nil),            // 3: events.CustomerEvent
+	(*CardState)(nil),                // 4: events.CardState
+	(*CustomerState)(nil),            // 5: events.CustomerState
+	(*CustomerErasureRequested)(nil), // 6: events.CustomerErasureRequested
+	(*CustomerErasureCompleted)(nil), // 7: events.CustomerErasureCompleted
+	(*StepUpCodeIssued)(nil),         // 8: events.StepUpCodeIssued
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                // 10: google.protobuf.Any
+}
+var file_events_proto_depIdxs = []int32{
+	9,  // 0: events.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	10, // 1: events.Envelope.payload:type_name -> google.protobuf.Any
+	9,  // 2: events.PaymentEvent.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: events.PaymentEvent.cancelled_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: events.PaymentEvent.completed_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: events.CardState.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: events.CustomerState.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: events.CustomerState.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 8: events.CustomerErasureRequested.requested_at:type_name -> google.protobuf.Timestamp
+	9,  // 9: events.StepUpCodeIssued.expires_at:type_name -> google.protobuf.Timestamp
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
@@ -33,6 +33,8 @@ type Payment struct {
 	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AccountId     uint32                 `protobuf:"varint,10,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`             // Debited account for cash payments
+	BeneficiaryId uint32                 `protobuf:"varint,11,opt,name=beneficiary_id,json=beneficiaryId,proto3" json:"beneficiary_id,omitempty"` // Optional saved payee
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -130,6 +132,20 @@ func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Payment) GetAccountId() uint32 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Payment) GetBeneficiaryId() uint32 {
+	if x != nil {
+		return x.BeneficiaryId
+	}
+	return 0
+}
+
 // Create Payment
 type CreatePaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -138,6 +154,8 @@ type CreatePaymentRequest struct {
 	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	PaymentType   string                 `protobuf:"bytes,4,opt,name=payment_type,json=paymentType,proto3" json:"payment_type,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	AccountId     uint32                 `protobuf:"varint,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`             // Required for cash payments
+	BeneficiaryId uint32                 `protobuf:"varint,7,opt,name=beneficiary_id,json=beneficiaryId,proto3" json:"beneficiary_id,omitempty"` // Optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,6 +225,20 @@ func (x *CreatePaymentRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreatePaymentRequest) GetAccountId() uint32 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreatePaymentRequest) GetBeneficiaryId() uint32 {
+	if x != nil {
+		return x.BeneficiaryId
+	}
+	return 0
+}
+
 type CreatePaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
@@ -550,11 +582,156 @@ func (x *CancelPaymentResponse) GetSuccess() bool {
 	return false
 }
 
+// Watch Payments
+type WatchPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    uint32                 `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`   // Either customer_id or payment_id
+	PaymentId     uint32                 `protobuf:"varint,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`      // Starts with a snapshot and ends once the payment is final
+	ResumeToken   string                 `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // Optional
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPaymentsRequest) Reset() {
+	*x = WatchPaymentsRequest{}
+	mi := &file_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPaymentsRequest) ProtoMessage() {}
+
+func (x *WatchPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchPaymentsRequest) GetCustomerId() uint32 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *WatchPaymentsRequest) GetPaymentId() uint32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *WatchPaymentsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type PaymentUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "SNAPSHOT", "PAYMENT_CREATED", "PAYMENT_COMPLETED", ...
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	FailureReason string                 `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"` // PAYMENT_FAILED only
+	ResumeToken   string                 `protobuf:"bytes,6,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentUpdate) Reset() {
+	*x = PaymentUpdate{}
+	mi := &file_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentUpdate) ProtoMessage() {}
+
+func (x *PaymentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentUpdate.ProtoReflect.Descriptor instead.
+func (*PaymentUpdate) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PaymentUpdate) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *PaymentUpdate) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *PaymentUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PaymentUpdate) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *PaymentUpdate) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentUpdate) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 var File_payment_proto protoreflect.FileDescriptor
 
 const file_payment_proto_rawDesc = "" +
 	"\n" +
-	"\rpayment.proto\x12\apayment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x02\n" +
+	"\rpayment.proto\x12\apayment\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x03\n" +
 	"\aPayment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\rR\n" +
@@ -567,14 +744,21 @@ const file_payment_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xad\x01\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\n" +
+	" \x01(\rR\taccountId\x12%\n" +
+	"\x0ebeneficiary_id\x18\v \x01(\rR\rbeneficiaryId\"\xf3\x01\n" +
 	"\x14CreatePaymentRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\rR\n" +
 	"customerId\x12\x17\n" +
 	"\acard_id\x18\x02 \x01(\rR\x06cardId\x12\x16\n" +
 	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12!\n" +
 	"\fpayment_type\x18\x04 \x01(\tR\vpaymentType\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\"C\n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x06 \x01(\rR\taccountId\x12%\n" +
+	"\x0ebeneficiary_id\x18\a \x01(\rR\rbeneficiaryId\"C\n" +
 	"\x15CreatePaymentResponse\x12*\n" +
 	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\"2\n" +
 	"\x11GetPaymentRequest\x12\x1d\n" +
@@ -596,13 +780,29 @@ const file_payment_proto_rawDesc = "" +
 	"payment_id\x18\x01 \x01(\rR\tpaymentId\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"1\n" +
 	"\x15CancelPaymentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xc4\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"y\n" +
+	"\x14WatchPaymentsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\rR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\rR\tpaymentId\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\"\xfd\x01\n" +
+	"\rPaymentUpdate\x12*\n" +
+	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12%\n" +
+	"\x0efailure_reason\x18\x05 \x01(\tR\rfailureReason\x12!\n" +
+	"\fresume_token\x18\x06 \x01(\tR\vresumeToken2\x8e\x03\n" +
 	"\x0ePaymentService\x12N\n" +
 	"\rCreatePayment\x12\x1d.payment.CreatePaymentRequest\x1a\x1e.payment.CreatePaymentResponse\x12E\n" +
 	"\n" +
 	"GetPayment\x12\x1a.payment.GetPaymentRequest\x1a\x1b.payment.GetPaymentResponse\x12K\n" +
 	"\fListPayments\x12\x1c.payment.ListPaymentsRequest\x1a\x1d.payment.ListPaymentsResponse\x12N\n" +
-	"\rCancelPayment\x12\x1d.payment.CancelPaymentRequest\x1a\x1e.payment.CancelPaymentResponseB\x18Z\x16govo/api/proto/paymentb\x06proto3"
+	"\rCancelPayment\x12\x1d.payment.CancelPaymentRequest\x1a\x1e.payment.CancelPaymentResponse\x12H\n" +
+	"\rWatchPayments\x12\x1d.payment.WatchPaymentsRequest\x1a\x16.payment.PaymentUpdate0\x01B\x18Z\x16govo/api/proto/paymentb\x06proto3"
 
 var (
 	file_payment_proto_rawDescOnce sync.Once
@@ -616,7 +816,7 @@ func file_payment_proto_rawDescGZIP() []byte {
 	return file_payment_proto_rawDescData
 }
 
-var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_payment_proto_goTypes = []any{
 	(*Payment)(nil),               // 0: payment.Payment
 	(*CreatePaymentRequest)(nil),  // 1: payment.CreatePaymentRequest
@@ -627,29 +827,35 @@ var file_payment_proto_goTypes = []any{
 	(*ListPaymentsResponse)(nil),  // 6: payment.ListPaymentsResponse
 	(*CancelPaymentRequest)(nil),  // 7: payment.CancelPaymentRequest
 	(*CancelPaymentResponse)(nil), // 8: payment.CancelPaymentResponse
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*WatchPaymentsRequest)(nil),  // 9: payment.WatchPaymentsRequest
+	(*PaymentUpdate)(nil),         // 10: payment.PaymentUpdate
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
 }
 var file_payment_proto_depIdxs = []int32{
-	9,  // 0: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 1: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 0: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: payment.CreatePaymentResponse.payment:type_name -> payment.Payment
 	0,  // 3: payment.GetPaymentResponse.payment:type_name -> payment.Payment
-	9,  // 4: payment.ListPaymentsRequest.start_date:type_name -> google.protobuf.Timestamp
-	9,  // 5: payment.ListPaymentsRequest.end_date:type_name -> google.protobuf.Timestamp
+	11, // 4: payment.ListPaymentsRequest.start_date:type_name -> google.protobuf.Timestamp
+	11, // 5: payment.ListPaymentsRequest.end_date:type_name -> google.protobuf.Timestamp
 	0,  // 6: payment.ListPaymentsResponse.payments:type_name -> payment.Payment
-	1,  // 7: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
-	3,  // 8: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
-	5,  // 9: payment.PaymentService.ListPayments:input_type -> payment.ListPaymentsRequest
-	7,  // 10: payment.PaymentService.CancelPayment:input_type -> payment.CancelPaymentRequest
-	2,  // 11: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
-	4,  // 12: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
-	6,  // 13: payment.PaymentService.ListPayments:output_type -> payment.ListPaymentsResponse
-	8,  // 14: payment.PaymentService.CancelPayment:output_type -> payment.CancelPaymentResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	0,  // 7: payment.PaymentUpdate.payment:type_name -> payment.Payment
+	11, // 8: payment.PaymentUpdate.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
+	3,  // 10: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
+	5,  // 11: payment.PaymentService.ListPayments:input_type -> payment.ListPaymentsRequest
+	7,  // 12: payment.PaymentService.CancelPayment:input_type -> payment.CancelPaymentRequest
+	9,  // 13: payment.PaymentService.WatchPayments:input_type -> payment.WatchPaymentsRequest
+	2,  // 14: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
+	4,  // 15: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
+	6,  // 16: payment.PaymentService.ListPayments:output_type -> payment.ListPaymentsResponse
+	8,  // 17: payment.PaymentService.CancelPayment:output_type -> payment.CancelPaymentResponse
+	10, // 18: payment.PaymentService.WatchPayments:output_type -> payment.PaymentUpdate
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_payment_proto_init() }
@@ -663,7 +869,7 @@ func file_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
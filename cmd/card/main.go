@@ -2,21 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	cardpb "govo/api/proto/card"
 	"govo/internal/card/handler"
 	"govo/internal/card/model"
 	"govo/internal/card/repository"
 	"govo/internal/card/service"
+	"govo/internal/inbox"
+	"govo/internal/outbox"
+	"govo/internal/requestctx"
+	"govo/kafka"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -38,13 +47,15 @@ func (s *CardServer) GetCustomerCards(ctx context.Context, req *cardpb.GetCustom
 
 	for i, c := range cards {
 		response.Cards[i] = &cardpb.GetCardResponse{
-			Id:          uint32(c.ID),
-			CustomerId:  uint32(c.CustomerID),
-			CardNumber:  c.CardNumber,
-			CardType:    c.CardType,
-			ExpiryDate:  c.ExpiryDate,
-			CreditLimit: float32(c.CreditLimit),
-			Balance:     float32(c.Balance),
+			Id:           uint32(c.ID),
+			CustomerId:   uint32(c.CustomerID),
+			CardNumber:   c.CardNumber,
+			CardType:     c.CardType,
+			ExpiryDate:   c.ExpiryDate,
+			CreditLimit:  float32(c.CreditLimit),
+			Balance:      float32(c.Balance),
+			MaskedNumber: service.MaskCardNumber(c.CardNumber),
+			IsActive:     c.IsActive,
 		}
 	}
 
@@ -53,6 +64,7 @@ func (s *CardServer) GetCustomerCards(ctx context.Context, req *cardpb.GetCustom
 
 func (s *CardServer) AddCard(ctx context.Context, req *cardpb.AddCardRequest) (*cardpb.AddCardResponse, error) {
 	err := s.service.AddCard(
+		ctx,
 		uint(req.CustomerId),
 		req.CardNumber,
 		req.CardType,
@@ -71,7 +83,7 @@ func (s *CardServer) AddCard(ctx context.Context, req *cardpb.AddCardRequest) (*
 }
 
 func (s *CardServer) RemoveCard(ctx context.Context, req *cardpb.RemoveCardRequest) (*cardpb.RemoveCardResponse, error) {
-	err := s.service.RemoveCard(uint(req.CustomerId), req.CardNumber)
+	err := s.service.RemoveCard(ctx, uint(req.CustomerId), req.CardNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +93,105 @@ func (s *CardServer) RemoveCard(ctx context.Context, req *cardpb.RemoveCardReque
 	}, nil
 }
 
+func (s *CardServer) SetCardStatus(ctx context.Context, req *cardpb.SetCardStatusRequest) (*cardpb.SetCardStatusResponse, error) {
+	if err := s.service.SetCardStatus(ctx, uint(req.Id), req.IsActive); err != nil {
+		return nil, err
+	}
+
+	return &cardpb.SetCardStatusResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *CardServer) AuthorizePayment(ctx context.Context, req *cardpb.AuthorizePaymentRequest) (*cardpb.CardHoldResponse, error) {
+	hold, err := s.service.AuthorizePayment(uint(req.CardId), uint(req.CustomerId), uint(req.PaymentId), req.Amount)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	requestctx.Logf(ctx, "Payment %d authorized on card %d: %.2f", hold.PaymentID, hold.CardID, hold.Amount)
+	return toHoldPB(hold), nil
+}
+
+func (s *CardServer) CapturePayment(ctx context.Context, req *cardpb.CardHoldRequest) (*cardpb.CardHoldResponse, error) {
+	hold, err := s.service.CapturePayment(ctx, uint(req.PaymentId))
+	if err != nil {
+		return nil, holdError(err)
+	}
+	requestctx.Logf(ctx, "Payment %d captured on card %d", hold.PaymentID, hold.CardID)
+	return toHoldPB(hold), nil
+}
+
+func (s *CardServer) ReleasePayment(ctx context.Context, req *cardpb.CardHoldRequest) (*cardpb.CardHoldResponse, error) {
+	hold, err := s.service.ReleasePayment(ctx, uint(req.PaymentId))
+	if err != nil {
+		return nil, holdError(err)
+	}
+	requestctx.Logf(ctx, "Payment %d released on card %d", hold.PaymentID, hold.CardID)
+	return toHoldPB(hold), nil
+}
+
+func (s *CardServer) WatchCard(req *cardpb.WatchCardRequest, stream cardpb.CardService_WatchCardServer) error {
+	err := s.service.WatchCard(stream.Context(), uint(req.CardId), req.ResumeToken, func(update *service.CardUpdate) error {
+		state := update.State
+		return stream.Send(&cardpb.CardUpdate{
+			CardId:       state.CardId,
+			CustomerId:   state.CustomerId,
+			MaskedNumber: state.MaskedNumber,
+			CardType:     state.CardType,
+			CreditLimit:  state.CreditLimit,
+			Balance:      state.Balance,
+			IsActive:     state.IsActive,
+			Removed:      update.Removed,
+			Sequence:     state.Sequence,
+			UpdatedAt:    state.UpdatedAt,
+			ResumeToken:  update.ResumeToken,
+		})
+	})
+	return watchError(err)
+}
+
+// İstemci kapattıysa hata dönülmez
+func watchError(err error) error {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, kafka.ErrPositionExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, service.ErrWatchUnavailable), errors.Is(err, kafka.ErrFeedStopped), errors.Is(err, kafka.ErrNotConnected):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
+// Ödeme servisi bu kodlarla kalıcı hatayı geçici hatadan ayırır
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrCardNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, repository.ErrCardInactive),
+		errors.Is(err, repository.ErrInsufficientLimit),
+		errors.Is(err, repository.ErrHoldNotFound),
+		errors.Is(err, repository.ErrHoldReleased):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func toHoldPB(hold *model.CardHold) *cardpb.CardHoldResponse {
+	return &cardpb.CardHoldResponse{
+		PaymentId: uint32(hold.PaymentID),
+		CardId:    uint32(hold.CardID),
+		Amount:    hold.Amount,
+		Status:    hold.Status,
+	}
+}
+
 func main() {
 	// PostgreSQL bağlantısı
 	dsn := "host=postgres user=postgres password=postgres dbname=carddb port=5432 sslmode=disable"
@@ -90,22 +201,103 @@ func main() {
 	}
 
 	// Tabloları oluştur
-	if err := db.AutoMigrate(&model.Card{}); err != nil {
+	if err := db.AutoMigrate(&model.Card{}, &model.CardHold{}, &inbox.ProcessedEvent{}, &inbox.AggregateSequence{}, &outbox.Message{}); err != nil {
 		log.Fatalf("Tablo oluşturulamadı: %v", err)
 	}
 
+	// Aktör, müşteri ve rol sadece gateway'in doğruladığı JWT'den okunur
+	jwtSecret, err := requestctx.SecretFromEnv("JWT_SECRET")
+	if err != nil {
+		log.Fatalf("JWT anahtarı okunamadı: %v", err)
+	}
+	requestctx.UseTokenSecret(jwtSecret)
+
+	// Olay şemaları SCHEMA_REGISTRY_URL tanımlıysa açılışta kaydedilir ve tüketirken kontrol edilir
+	schemaRegistry, err := kafka.SchemaRegistryFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz şema kayıt defteri ayarları: %v", err)
+	}
+	if schemaRegistry != nil {
+		if err := schemaRegistry.RegisterAll(context.Background()); err != nil {
+			log.Fatalf("Olay şemaları kaydedilemedi: %v", err)
+		}
+		kafka.UseSchemaRegistry(schemaRegistry)
+	}
+
+	// Kafka client
+	producerConfig, err := kafka.ProducerConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka producer ayarları: %v", err)
+	}
+	// Kafka'ya ulaşılamazsa servis degraded açılır, client arka planda bağlanır ve olaylar outbox'ta bekler
+	kafkaClient := kafka.NewClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
+	defer kafkaClient.Close()
+	// Eksik topic'leri bağlantı kurulunca oluştur; tanımdan farklı olanlar loglanır, servis yine de açılır
+	topicConfig, err := kafka.LoadTopicConfig(os.Getenv("KAFKA_TOPICS_CONFIG"))
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka topic ayarları: %v", err)
+	}
+	kafkaClient.OnConnect(func() {
+		if err := kafka.EnsureTopics([]string{"kafka:9092"}, topicConfig); err != nil {
+			log.Printf("Kafka topic'leri doğrulanamadı: %v", err)
+		}
+	})
+	kafkaSubscriber := kafka.NewSubscriber([]string{"kafka:9092"})
+	defer kafkaSubscriber.Close()
+
 	// Dependency injection
 	cardRepo := repository.NewCardRepository(db)
-	cardService := service.NewCardService(cardRepo)
+	// Kart izleme cards.state topic'inden beslenir
+	stateFeed := kafka.NewFeed([]string{"kafka:9092"}, kafka.CardStateTopic)
+	cardService := service.NewCardService(cardRepo).WithStateFeed(stateFeed)
 	cardServer := &CardServer{service: cardService}
 	cardHandler := handler.NewCardHandler(cardService)
 
+	// /ready consumer'lar bu eşikleri aştığında hazır değil döner
+	healthThresholds, err := kafka.HealthThresholdsFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz consumer sağlık eşikleri: %v", err)
+	}
+
+	// Müşteri silme taleplerini dinle
+	customerConsumer := kafka.NewConsumer(kafkaSubscriber, "card-erasure", kafka.CustomersTopic).WithRetry(kafkaClient, kafka.DefaultRetryTiers...).WithHealth(healthThresholds)
+	kafka.HandleEvent(customerConsumer, kafka.EventCustomerErasureRequested, cardService.HandleErasureRequested)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go customerConsumer.Start(ctx)
+	expvar.Publish("kafka_consumers", expvar.Func(func() interface{} { return kafka.ConsumerStatuses(customerConsumer) }))
+	// Feed Kafka'ya ulaşılana kadar arka planda bağlanmayı dener, durumu /ready'de görünür
+	go stateFeed.Start(ctx)
+
+	// İşlenmiş olay kayıtlarını temizle
+	go inbox.StartCleanup(ctx, db, inbox.DefaultRetention, time.Hour)
+
+	// Outbox'taki olayları Kafka'ya gönder; async modda gönderim sonucu callback ile işlenir
+	var relayPublisher kafka.Publisher = kafkaClient
+	producers := []kafka.ConnectionReporter{kafkaClient}
+	if producerConfig.Async {
+		asyncClient := kafka.NewAsyncClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
+		defer asyncClient.Close()
+		expvar.Publish("kafka_producer", expvar.Func(func() interface{} { return asyncClient.Stats() }))
+		relayPublisher = asyncClient
+		producers = append(producers, asyncClient)
+	}
+	go outbox.NewRelay(db, relayPublisher).Start(ctx)
+
 	// HTTP router
 	router := mux.NewRouter()
+	router.Use(requestctx.HTTPMiddleware)
 	router.HandleFunc("/api/cards", cardHandler.CreateCard).Methods("POST")
 	router.HandleFunc("/api/cards", cardHandler.GetCard).Methods("GET")
 	router.HandleFunc("/api/cards/list", cardHandler.ListCards).Methods("GET")
 	router.HandleFunc("/api/cards", cardHandler.DeleteCard).Methods("DELETE")
+	router.HandleFunc("/api/cards/status", cardHandler.UpdateCardStatus).Methods("PUT")
+	router.HandleFunc("/api/cards", cardHandler.UpdateCard).Methods("PUT")
+	router.HandleFunc("/api/cards/limit", cardHandler.UpdateCreditLimit).Methods("PUT")
+	router.Handle("/debug/vars", expvar.Handler())
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }).Methods("GET")
+	router.Handle("/ready", kafka.ReadinessHandler(customerConsumer).WithProducers(producers...).WithFeeds(stateFeed)).Methods("GET")
+	router.Handle("/consumers", kafka.ConsumersHandler(customerConsumer)).Methods("GET")
 
 	// HTTP server
 	go func() {
@@ -121,7 +313,7 @@ func main() {
 		log.Fatalf("Port dinlenemedi: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(requestctx.UnaryServerInterceptor()), grpc.StreamInterceptor(requestctx.StreamServerInterceptor()))
 	cardpb.RegisterCardServiceServer(grpcServer, cardServer)
 
 	// Graceful shutdown
@@ -137,5 +329,6 @@ func main() {
 
 	<-sigChan
 	log.Println("Shutting down...")
+	cancel()
 	grpcServer.GracefulStop()
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	cardpb "govo/api/proto/card"
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/customer/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Müşteri servisindeki kart okuma modelini kart servisinden yeniden oluşturur.
+// Kullanım: cardsync [-customer 42]
+func main() {
+	dsn := flag.String("dsn", "host=postgres user=postgres password=postgres dbname=customerdb port=5432 sslmode=disable", "customer database DSN")
+	cardAddr := flag.String("card-addr", "card-service:50054", "card service gRPC address")
+	customerID := flag.Uint("customer", 0, "rebuild only this customer (0 = all customers)")
+	flag.Parse()
+
+	db, err := gorm.Open(postgres.Open(*dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Veritabanına bağlanılamadı: %v", err)
+	}
+
+	if err := db.AutoMigrate(&model.CustomerCard{}); err != nil {
+		log.Fatalf("Tablo oluşturulamadı: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*cardAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Kart servisine bağlanılamadı: %v", err)
+	}
+	defer conn.Close()
+	cardClient := cardpb.NewCardServiceClient(conn)
+
+	customerRepo := repository.NewCustomerRepository(db)
+	cardSyncService := service.NewCardSyncService(repository.NewCustomerCardRepository(db))
+
+	ids := []uint{*customerID}
+	if *customerID == 0 {
+		ids, err = customerRepo.ListIDs()
+		if err != nil {
+			log.Fatalf("Müşteriler listelenemedi: %v", err)
+		}
+	}
+
+	var total, failed int
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		n, err := cardSyncService.RebuildCustomer(ctx, cardClient, id)
+		cancel()
+		if err != nil {
+			log.Printf("%v", err)
+			failed++
+			continue
+		}
+		total += n
+	}
+
+	log.Printf("%d müşteri için %d kart senkronize edildi, %d hata", len(ids)-failed, total, failed)
+	if failed > 0 {
+		log.Fatalf("Yeniden oluşturma %d müşteri için başarısız oldu", failed)
+	}
+}
@@ -2,22 +2,43 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"sort"
+	"time"
 
+	cardpb "govo/api/proto/card"
 	"govo/api/proto/customer"
+	paymentpb "govo/api/proto/payment"
+	"govo/internal/customer/handler"
 	"govo/internal/customer/model"
 	"govo/internal/customer/repository"
 	"govo/internal/customer/service"
+	"govo/internal/inbox"
+	"govo/internal/outbox"
+	"govo/internal/requestctx"
+	"govo/kafka"
 
+	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type CustomerServer struct {
 	customer.UnimplementedCustomerServiceServer
-	service *service.CustomerService
+	service            *service.CustomerService
+	accountService     *service.AccountService
+	beneficiaryService *service.BeneficiaryService
 }
 
 func (s *CustomerServer) CreateCustomer(ctx context.Context, req *customer.CreateCustomerRequest) (*customer.CreateCustomerResponse, error) {
@@ -30,7 +51,7 @@ func (s *CustomerServer) CreateCustomer(ctx context.Context, req *customer.Creat
 		Balance:   float64(req.Balance),
 	}
 
-	if err := s.service.CreateCustomer(c); err != nil {
+	if err := s.service.CreateCustomer(ctx, c); err != nil {
 		return nil, err
 	}
 
@@ -75,7 +96,7 @@ func (s *CustomerServer) UpdateCustomer(ctx context.Context, req *customer.Updat
 		Balance:   float64(req.Balance),
 	}
 
-	if err := s.service.UpdateCustomer(c); err != nil {
+	if err := s.service.UpdateCustomer(ctx, c); err != nil {
 		return nil, err
 	}
 
@@ -92,7 +113,7 @@ func (s *CustomerServer) UpdateCustomer(ctx context.Context, req *customer.Updat
 }
 
 func (s *CustomerServer) DeleteCustomer(ctx context.Context, req *customer.DeleteCustomerRequest) (*customer.DeleteCustomerResponse, error) {
-	if err := s.service.DeleteCustomer(uint(req.Id)); err != nil {
+	if err := s.service.DeleteCustomer(ctx, uint(req.Id)); err != nil {
 		return nil, err
 	}
 
@@ -127,6 +148,298 @@ func (s *CustomerServer) ListCustomers(ctx context.Context, req *customer.ListCu
 	return response, nil
 }
 
+func (s *CustomerServer) GetCustomerHistory(ctx context.Context, req *customer.GetCustomerHistoryRequest) (*customer.GetCustomerHistoryResponse, error) {
+	history, err := s.service.GetCustomerHistory(ctx, uint(req.CustomerId), int(req.Page), int(req.PageSize))
+	if errors.Is(err, service.ErrCustomerMismatch) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &customer.GetCustomerHistoryResponse{
+		Entries:  make([]*customer.AuditEntry, len(history.Entries)),
+		Total:    history.Total,
+		Page:     int32(history.Page),
+		PageSize: int32(history.PageSize),
+	}
+
+	for i, e := range history.Entries {
+		var changes map[string]model.FieldChange
+		json.Unmarshal(e.Changes, &changes)
+
+		entry := &customer.AuditEntry{
+			Id:         uint32(e.ID),
+			CustomerId: uint32(e.CustomerID),
+			Action:     e.Action,
+			Actor:      e.Actor,
+			Source:     e.Source,
+			RequestId:  e.RequestID,
+			BeforeJson: string(e.Before),
+			AfterJson:  string(e.After),
+			CreatedAt:  timestamppb.New(e.CreatedAt),
+		}
+		for field, change := range changes {
+			from, _ := json.Marshal(change.From)
+			to, _ := json.Marshal(change.To)
+			entry.Changes = append(entry.Changes, &customer.FieldChange{
+				Field:    field,
+				FromJson: string(from),
+				ToJson:   string(to),
+			})
+		}
+		sort.Slice(entry.Changes, func(a, b int) bool { return entry.Changes[a].Field < entry.Changes[b].Field })
+		response.Entries[i] = entry
+	}
+
+	return response, nil
+}
+
+func (s *CustomerServer) CreateAccount(ctx context.Context, req *customer.CreateAccountRequest) (*customer.AccountResponse, error) {
+	jointHolderIDs := make([]uint, len(req.JointHolderIds))
+	for i, id := range req.JointHolderIds {
+		jointHolderIDs[i] = uint(id)
+	}
+
+	account, err := s.accountService.CreateAccount(uint(req.CustomerId), req.AccountType, req.Currency, jointHolderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customer.AccountResponse{Account: toAccountPB(account)}, nil
+}
+
+func (s *CustomerServer) GetAccount(ctx context.Context, req *customer.GetAccountRequest) (*customer.AccountResponse, error) {
+	account, err := s.accountService.GetAccount(uint(req.Id))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &customer.AccountResponse{Account: toAccountPB(account)}, nil
+}
+
+func (s *CustomerServer) ListAccounts(ctx context.Context, req *customer.ListAccountsRequest) (*customer.ListAccountsResponse, error) {
+	accounts, err := s.accountService.ListAccounts(uint(req.CustomerId))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &customer.ListAccountsResponse{
+		Accounts: make([]*customer.Account, len(accounts)),
+	}
+	for i := range accounts {
+		response.Accounts[i] = toAccountPB(&accounts[i])
+	}
+
+	return response, nil
+}
+
+func (s *CustomerServer) UpdateAccountStatus(ctx context.Context, req *customer.UpdateAccountStatusRequest) (*customer.AccountResponse, error) {
+	if err := s.accountService.UpdateAccountStatus(uint(req.Id), req.Status); err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountService.GetAccount(uint(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &customer.AccountResponse{Account: toAccountPB(account)}, nil
+}
+
+func (s *CustomerServer) CloseAccount(ctx context.Context, req *customer.CloseAccountRequest) (*customer.CloseAccountResponse, error) {
+	if err := s.accountService.CloseAccount(uint(req.Id)); err != nil {
+		return nil, err
+	}
+
+	return &customer.CloseAccountResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *CustomerServer) DebitAccount(ctx context.Context, req *customer.AdjustBalanceRequest) (*customer.AccountResponse, error) {
+	account, err := s.accountService.Debit(uint(req.AccountId), req.Amount, adjustBalanceKey(req))
+	if err != nil {
+		return nil, balanceError(err)
+	}
+	requestctx.Logf(ctx, "Account %d debited %.2f (event %s)", req.AccountId, req.Amount, req.EventId)
+
+	return &customer.AccountResponse{Account: toAccountPB(account)}, nil
+}
+
+func (s *CustomerServer) CreditAccount(ctx context.Context, req *customer.AdjustBalanceRequest) (*customer.AccountResponse, error) {
+	var account *model.Account
+	var err error
+	if req.ReversesEventId != "" {
+		account, err = s.accountService.Reverse(uint(req.AccountId), req.Amount, adjustBalanceKey(req), inbox.Key{
+			Consumer: req.Consumer,
+			EventID:  req.ReversesEventId,
+		})
+	} else {
+		account, err = s.accountService.Credit(uint(req.AccountId), req.Amount, adjustBalanceKey(req))
+	}
+	if err != nil {
+		return nil, balanceError(err)
+	}
+	requestctx.Logf(ctx, "Account %d credited %.2f (event %s)", req.AccountId, req.Amount, req.EventId)
+
+	return &customer.AccountResponse{Account: toAccountPB(account)}, nil
+}
+
+func adjustBalanceKey(req *customer.AdjustBalanceRequest) inbox.Key {
+	return inbox.Key{
+		Consumer:    req.Consumer,
+		EventID:     req.EventId,
+		AggregateID: req.AggregateId,
+		Sequence:    req.Sequence,
+	}
+}
+
+func balanceError(err error) error {
+	if errors.Is(err, repository.ErrInsufficientFunds) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, inbox.ErrOutOfOrder) {
+		// Ödeme servisi olayı tekrar denemeye gönderir
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
+
+func toAccountPB(a *model.Account) *customer.Account {
+	holderIDs := make([]uint32, len(a.Holders))
+	for i, h := range a.Holders {
+		holderIDs[i] = uint32(h.CustomerID)
+	}
+
+	return &customer.Account{
+		Id:            uint32(a.ID),
+		CustomerId:    uint32(a.CustomerID),
+		AccountType:   a.AccountType,
+		AccountNumber: a.AccountNumber,
+		Iban:          a.IBAN,
+		Currency:      a.Currency,
+		Balance:       a.Balance,
+		Status:        a.Status,
+		HolderIds:     holderIDs,
+	}
+}
+
+func (s *CustomerServer) CreateBeneficiary(ctx context.Context, req *customer.CreateBeneficiaryRequest) (*customer.BeneficiaryResponse, error) {
+	b := &model.Beneficiary{
+		CustomerID:       uint(req.CustomerId),
+		Nickname:         req.Nickname,
+		IBAN:             req.Iban,
+		TargetCustomerID: uint(req.TargetCustomerId),
+		DefaultReference: req.DefaultReference,
+		RequireStepUp:    req.RequireStepUp,
+	}
+
+	if err := s.beneficiaryService.CreateBeneficiary(ctx, b); err != nil {
+		return nil, beneficiaryStatus(err, codes.InvalidArgument)
+	}
+
+	return &customer.BeneficiaryResponse{Beneficiary: toBeneficiaryPB(b)}, nil
+}
+
+func (s *CustomerServer) GetBeneficiary(ctx context.Context, req *customer.GetBeneficiaryRequest) (*customer.BeneficiaryResponse, error) {
+	b, err := s.beneficiaryService.GetBeneficiary(ctx, uint(req.Id))
+	if err != nil {
+		return nil, beneficiaryStatus(err, codes.Internal)
+	}
+
+	return &customer.BeneficiaryResponse{Beneficiary: toBeneficiaryPB(b)}, nil
+}
+
+func (s *CustomerServer) ListBeneficiaries(ctx context.Context, req *customer.ListBeneficiariesRequest) (*customer.ListBeneficiariesResponse, error) {
+	beneficiaries, err := s.beneficiaryService.ListBeneficiaries(ctx, uint(req.CustomerId))
+	if err != nil {
+		return nil, beneficiaryStatus(err, codes.Internal)
+	}
+
+	response := &customer.ListBeneficiariesResponse{
+		Beneficiaries: make([]*customer.Beneficiary, len(beneficiaries)),
+	}
+	for i := range beneficiaries {
+		response.Beneficiaries[i] = toBeneficiaryPB(&beneficiaries[i])
+	}
+
+	return response, nil
+}
+
+func (s *CustomerServer) UpdateBeneficiary(ctx context.Context, req *customer.UpdateBeneficiaryRequest) (*customer.BeneficiaryResponse, error) {
+	b, err := s.beneficiaryService.UpdateBeneficiary(ctx, uint(req.Id), req.Nickname, req.DefaultReference)
+	if err != nil {
+		return nil, beneficiaryStatus(err, codes.InvalidArgument)
+	}
+
+	return &customer.BeneficiaryResponse{Beneficiary: toBeneficiaryPB(b)}, nil
+}
+
+func (s *CustomerServer) DeleteBeneficiary(ctx context.Context, req *customer.DeleteBeneficiaryRequest) (*customer.DeleteBeneficiaryResponse, error) {
+	if err := s.beneficiaryService.DeleteBeneficiary(ctx, uint(req.Id)); err != nil {
+		return nil, beneficiaryStatus(err, codes.Internal)
+	}
+
+	return &customer.DeleteBeneficiaryResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *CustomerServer) ConfirmBeneficiary(ctx context.Context, req *customer.ConfirmBeneficiaryRequest) (*customer.BeneficiaryResponse, error) {
+	b, err := s.beneficiaryService.ConfirmBeneficiary(ctx, uint(req.Id), req.Code)
+	if err != nil {
+		return nil, beneficiaryStatus(err, codes.Internal)
+	}
+
+	return &customer.BeneficiaryResponse{Beneficiary: toBeneficiaryPB(b)}, nil
+}
+
+func (s *CustomerServer) ResolveBeneficiary(ctx context.Context, req *customer.ResolveBeneficiaryRequest) (*customer.BeneficiaryResponse, error) {
+	b, err := s.beneficiaryService.ResolveForPayment(uint(req.Id), uint(req.CustomerId))
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &customer.BeneficiaryResponse{Beneficiary: toBeneficiaryPB(b)}, nil
+}
+
+func beneficiaryStatus(err error, fallback codes.Code) error {
+	code := fallback
+	switch {
+	case errors.Is(err, service.ErrBeneficiaryNotFound):
+		code = codes.NotFound
+	case errors.Is(err, service.ErrCustomerMismatch), errors.Is(err, service.ErrInvalidStepUpCode):
+		code = codes.PermissionDenied
+	case errors.Is(err, service.ErrBeneficiaryLocked):
+		code = codes.FailedPrecondition
+	case errors.Is(err, service.ErrResendTooSoon):
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
+
+func toBeneficiaryPB(b *model.Beneficiary) *customer.Beneficiary {
+	return &customer.Beneficiary{
+		Id:               uint32(b.ID),
+		CustomerId:       uint32(b.CustomerID),
+		Nickname:         b.Nickname,
+		Iban:             b.IBAN,
+		TargetCustomerId: uint32(b.TargetCustomerID),
+		DefaultReference: b.DefaultReference,
+		RequireStepUp:    b.RequireStepUp,
+		Status:           b.Status(time.Now()),
+		AvailableAt:      timestamppb.New(b.AvailableAt),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
 func main() {
 	// PostgreSQL bağlantısı
 	dsn := "host=postgres user=postgres password=postgres dbname=customerdb port=5432 sslmode=disable"
@@ -136,14 +449,179 @@ func main() {
 	}
 
 	// Tabloları oluştur
-	if err := db.AutoMigrate(&model.Customer{}); err != nil {
+	if err := db.AutoMigrate(
+		&model.Customer{},
+		&model.CustomerCard{},
+		&model.ErasureRequest{},
+		&model.ErasureStep{},
+		&model.Account{},
+		&model.AccountHolder{},
+		&model.Beneficiary{},
+		&model.CustomerAudit{},
+		&inbox.ProcessedEvent{}, &inbox.AggregateSequence{},
+		&outbox.Message{},
+	); err != nil {
 		log.Fatalf("Tablo oluşturulamadı: %v", err)
 	}
 
+	// Hesap numaraları için sequence
+	if err := db.Exec("CREATE SEQUENCE IF NOT EXISTS account_number_seq").Error; err != nil {
+		log.Fatalf("Sequence oluşturulamadı: %v", err)
+	}
+
+	// Aktör, müşteri ve rol sadece gateway'in doğruladığı JWT'den okunur
+	jwtSecret, err := requestctx.SecretFromEnv("JWT_SECRET")
+	if err != nil {
+		log.Fatalf("JWT anahtarı okunamadı: %v", err)
+	}
+	requestctx.UseTokenSecret(jwtSecret)
+
+	// Olay şemaları SCHEMA_REGISTRY_URL tanımlıysa açılışta kaydedilir ve tüketirken kontrol edilir
+	schemaRegistry, err := kafka.SchemaRegistryFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz şema kayıt defteri ayarları: %v", err)
+	}
+	if schemaRegistry != nil {
+		if err := schemaRegistry.RegisterAll(context.Background()); err != nil {
+			log.Fatalf("Olay şemaları kaydedilemedi: %v", err)
+		}
+		kafka.UseSchemaRegistry(schemaRegistry)
+	}
+
+	// Kafka client
+	producerConfig, err := kafka.ProducerConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka producer ayarları: %v", err)
+	}
+	// Kafka'ya ulaşılamazsa servis degraded açılır, client arka planda bağlanır ve olaylar outbox'ta bekler
+	kafkaClient := kafka.NewClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
+	defer kafkaClient.Close()
+	// Eksik topic'leri bağlantı kurulunca oluştur; tanımdan farklı olanlar loglanır, servis yine de açılır
+	topicConfig, err := kafka.LoadTopicConfig(os.Getenv("KAFKA_TOPICS_CONFIG"))
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka topic ayarları: %v", err)
+	}
+	kafkaClient.OnConnect(func() {
+		if err := kafka.EnsureTopics([]string{"kafka:9092"}, topicConfig); err != nil {
+			log.Printf("Kafka topic'leri doğrulanamadı: %v", err)
+		}
+	})
+	kafkaSubscriber := kafka.NewSubscriber([]string{"kafka:9092"})
+	defer kafkaSubscriber.Close()
+
+	// Kart ve ödeme servisleri için gRPC bağlantıları
+	cardConn, err := grpc.NewClient("card-service:50054", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(requestctx.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Kart servisine bağlanılamadı: %v", err)
+	}
+	defer cardConn.Close()
+
+	paymentConn, err := grpc.NewClient("payment-service:50053", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(requestctx.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Ödeme servisine bağlanılamadı: %v", err)
+	}
+	defer paymentConn.Close()
+
 	// Dependency injection
 	customerRepo := repository.NewCustomerRepository(db)
-	customerService := service.NewCustomerService(customerRepo)
-	customerServer := &CustomerServer{service: customerService}
+	customerCardRepo := repository.NewCustomerCardRepository(db)
+	customerService := service.NewCustomerService(customerRepo, repository.NewCustomerAuditRepository(db))
+	ibanConfig := service.IBANConfig{
+		CountryCode: getEnv("IBAN_COUNTRY_CODE", "TR"),
+		BankCode:    getEnv("IBAN_BANK_CODE", "00100"),
+	}
+	if err := ibanConfig.Validate(); err != nil {
+		log.Fatalf("Geçersiz IBAN ayarları: %v", err)
+	}
+	accountService := service.NewAccountService(repository.NewAccountRepository(db), customerRepo, ibanConfig)
+	coolingOff, err := time.ParseDuration(getEnv("BENEFICIARY_COOLING_OFF", "24h"))
+	if err != nil {
+		log.Fatalf("Geçersiz BENEFICIARY_COOLING_OFF değeri: %v", err)
+	}
+	stepUpCodeKey, err := requestctx.SecretFromEnv("STEP_UP_CODE_KEY")
+	if err != nil {
+		log.Fatalf("Onay kodu anahtarı okunamadı: %v", err)
+	}
+	beneficiaryService := service.NewBeneficiaryService(
+		repository.NewBeneficiaryRepository(db),
+		customerRepo,
+		kafkaClient,
+		coolingOff,
+		stepUpCodeKey,
+	)
+	customerServer := &CustomerServer{
+		service:            customerService,
+		accountService:     accountService,
+		beneficiaryService: beneficiaryService,
+	}
+	cardSyncService := service.NewCardSyncService(customerCardRepo)
+	gdprService := service.NewGDPRService(
+		customerRepo,
+		customerCardRepo,
+		repository.NewErasureRepository(db),
+		cardpb.NewCardServiceClient(cardConn),
+		paymentpb.NewPaymentServiceClient(paymentConn),
+	)
+
+	// /ready consumer'lar bu eşikleri aştığında hazır değil döner
+	healthThresholds, err := kafka.HealthThresholdsFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz consumer sağlık eşikleri: %v", err)
+	}
+
+	// Kart olaylarını dinleyerek okuma modelini güncel tut
+	cardConsumer := kafka.NewConsumer(kafkaSubscriber, "customer-card-sync", kafka.CardsTopic).WithRetry(kafkaClient, kafka.DefaultRetryTiers...).WithHealth(healthThresholds)
+	kafka.HandleEvent(cardConsumer, kafka.EventCardIssued, cardSyncService.HandleCardChanged)
+	kafka.HandleEvent(cardConsumer, kafka.EventCardUpdated, cardSyncService.HandleCardChanged)
+	kafka.HandleEvent(cardConsumer, kafka.EventCardStatusChanged, cardSyncService.HandleCardChanged)
+	kafka.HandleEvent(cardConsumer, kafka.EventCardLimitChanged, cardSyncService.HandleCardChanged)
+	kafka.HandleEvent(cardConsumer, kafka.EventCardRemoved, cardSyncService.HandleCardRemoved)
+
+	// Diğer servislerden gelen silme raporlarını dinle
+	erasureConsumer := kafka.NewConsumer(kafkaSubscriber, "customer-erasure", kafka.CustomersTopic).WithRetry(kafkaClient, kafka.DefaultRetryTiers...).WithHealth(healthThresholds)
+	kafka.HandleEvent(erasureConsumer, kafka.EventCustomerErasureCompleted, gdprService.HandleErasureCompleted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cardConsumer.Start(ctx)
+	go erasureConsumer.Start(ctx)
+	consumers := []*kafka.Consumer{cardConsumer, erasureConsumer}
+	expvar.Publish("kafka_consumers", expvar.Func(func() interface{} { return kafka.ConsumerStatuses(consumers...) }))
+
+	// İşlenmiş olay kayıtlarını temizle, bakiye işlemlerinin kayıtları da burada tutulur
+	go inbox.StartCleanup(ctx, db, inbox.DefaultRetention, time.Hour)
+
+	// Outbox'taki olayları Kafka'ya gönder; async modda gönderim sonucu callback ile işlenir
+	var relayPublisher kafka.Publisher = kafkaClient
+	producers := []kafka.ConnectionReporter{kafkaClient}
+	if producerConfig.Async {
+		asyncClient := kafka.NewAsyncClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
+		defer asyncClient.Close()
+		expvar.Publish("kafka_producer", expvar.Func(func() interface{} { return asyncClient.Stats() }))
+		relayPublisher = asyncClient
+		producers = append(producers, asyncClient)
+	}
+	go outbox.NewRelay(db, relayPublisher).Start(ctx)
+
+	// HTTP router
+	router := gin.Default()
+	router.Use(requestctx.GinMiddleware())
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/ready", gin.WrapH(kafka.ReadinessHandler(consumers...).WithProducers(producers...)))
+	router.GET("/consumers", gin.WrapH(kafka.ConsumersHandler(consumers...)))
+	handler.NewCustomerHandler(customerService).RegisterRoutes(router)
+	handler.NewGDPRHandler(gdprService).RegisterRoutes(router)
+	handler.NewAccountHandler(accountService).RegisterRoutes(router)
+	handler.NewBeneficiaryHandler(beneficiaryService).RegisterRoutes(router)
+
+	// HTTP server
+	go func() {
+		log.Println("HTTP server 8082 portunda başlatılıyor...")
+		if err := router.Run(":8082"); err != nil {
+			log.Fatalf("HTTP server başlatılamadı: %v", err)
+		}
+	}()
 
 	// gRPC server'ı başlat
 	lis, err := net.Listen("tcp", ":50052")
@@ -151,7 +629,7 @@ func main() {
 		log.Fatalf("Port dinlenemedi: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(requestctx.UnaryServerInterceptor()), grpc.StreamInterceptor(requestctx.StreamServerInterceptor()))
 	customer.RegisterCustomerServiceServer(grpcServer, customerServer)
 
 	log.Println("Customer servisi 50052 portunda başlatılıyor...")
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"govo/internal/schema"
+	"govo/kafka"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Olay şemalarını son yayınlanan sürümlerle karşılaştırır.
+// -registry verilirse ayrıca her olay tipi kayıt defterindeki subject'in kuralıyla kontrol edilir.
+// Kullanım: eventcompat [-lock api/proto/events/schema.lock.json] [-update] [-registry http://localhost:8086]
+func main() {
+	lockPath := flag.String("lock", "api/proto/events/schema.lock.json", "schema lock file")
+	update := flag.Bool("update", false, "write the current schemas to the lock file after a successful check")
+	registryURL := flag.String("registry", "", "schema registry to check the current schemas against")
+	flag.Parse()
+
+	current, err := currentSchemas()
+	if err != nil {
+		log.Fatalf("Şemalar okunamadı: %v", err)
+	}
+
+	if *registryURL != "" {
+		problems, err := checkRegistry(*registryURL)
+		if err != nil {
+			log.Fatalf("Kayıt defteri kontrol edilemedi: %v", err)
+		}
+		if len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, p)
+			}
+			os.Exit(1)
+		}
+	}
+
+	previous, err := readLock(*lockPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("Lock dosyası okunamadı: %v", err)
+	}
+
+	if previous != nil {
+		if problems := checkCompatibility(previous, current); len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, p)
+			}
+			os.Exit(1)
+		}
+	}
+
+	if *update {
+		if err := writeLock(*lockPath, current); err != nil {
+			log.Fatalf("Lock dosyası yazılamadı: %v", err)
+		}
+		log.Printf("%s güncellendi", *lockPath)
+		return
+	}
+	log.Println("Olay şemaları uyumlu")
+}
+
+type schemaLock struct {
+	Events   map[string]eventLock      `json:"events"`
+	Messages map[string]schema.Message `json:"messages"`
+}
+
+type eventLock struct {
+	Version int32  `json:"version"`
+	Payload string `json:"payload"`
+}
+
+func currentSchemas() (*schemaLock, error) {
+	lock := &schemaLock{
+		Events:   make(map[string]eventLock),
+		Messages: make(map[string]schema.Message),
+	}
+
+	for _, s := range kafka.Schemas() {
+		lock.Events[s.Type] = eventLock{Version: s.Version, Payload: string(s.Payload)}
+
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(s.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("payload %s for %s: %v", s.Payload, s.Type, err)
+		}
+		schema.AddMessage(lock.Messages, desc.(protoreflect.MessageDescriptor))
+	}
+	return lock, nil
+}
+
+func checkCompatibility(previous, current *schemaLock) []string {
+	var problems []string
+
+	changed := make(map[string]bool)
+	for name, old := range previous.Messages {
+		msg, ok := current.Messages[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("message %s was removed", name))
+			continue
+		}
+		p, diff := schema.CompareMessage(name, old, msg)
+		problems = append(problems, p...)
+		changed[name] = diff
+	}
+
+	for eventType, old := range previous.Events {
+		event, ok := current.Events[eventType]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("event %s was removed", eventType))
+			continue
+		}
+		if event.Payload != old.Payload {
+			problems = append(problems, fmt.Sprintf("event %s payload changed from %s to %s", eventType, old.Payload, event.Payload))
+			continue
+		}
+		if event.Version < old.Version {
+			problems = append(problems, fmt.Sprintf("event %s version went back from %d to %d", eventType, old.Version, event.Version))
+		}
+		if changed[event.Payload] && event.Version == old.Version {
+			problems = append(problems, fmt.Sprintf("event %s payload %s changed, bump its version above %d", eventType, event.Payload, old.Version))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// Her olay tipinin güncel şemasını subject'in son sürümüyle kayıt defterinin kuralına göre karşılaştırır
+func checkRegistry(registryURL string) ([]string, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	var problems []string
+	for _, s := range kafka.Schemas() {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(s.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("payload %s for %s: %v", s.Payload, s.Type, err)
+		}
+		body, _ := json.Marshal(map[string]string{
+			"schema":     schema.Describe(desc.(protoreflect.MessageDescriptor)).String(),
+			"schemaType": schema.TypeProtobuf,
+		})
+
+		resp, err := client.Post(strings.TrimRight(registryURL, "/")+"/compatibility/subjects/"+url.PathEscape(s.Type)+"/versions/latest",
+			"application/vnd.schemaregistry.v1+json", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			IsCompatible bool     `json:"is_compatible"`
+			Messages     []string `json:"messages"`
+			Message      string   `json:"message"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", s.Type, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: registry returned %d: %s", s.Type, resp.StatusCode, result.Message)
+		}
+		for _, m := range result.Messages {
+			problems = append(problems, fmt.Sprintf("registry: event %s: %s", s.Type, m))
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+func readLock(path string) (*schemaLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &schemaLock{}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return lock, nil
+}
+
+func writeLock(path string, lock *schemaLock) error {
+	for name, msg := range lock.Messages {
+		sort.Slice(msg.Fields, func(i, j int) bool { return msg.Fields[i].Number < msg.Fields[j].Number })
+		lock.Messages[name] = msg
+	}
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"govo/kafka"
+)
+
+func runConsumers(brokers []string, subcommand string, args []string) error {
+	fs := flag.NewFlagSet("consumers "+subcommand, flag.ExitOnError)
+	services := fs.String("services", getEnv("GOVO_SERVICES", "http://localhost:8080,http://localhost:8081,http://localhost:8082"), "comma separated service URLs serving /consumers")
+	group := fs.String("group", "", "read committed offsets of this consumer group from Kafka instead of the services")
+	fs.Parse(args)
+
+	if subcommand != "status" {
+		return fmt.Errorf("unknown consumers subcommand %q", subcommand)
+	}
+
+	// Servis kapalıyken bile commit edilen offset'lerden lag görülebilir
+	if *group != "" {
+		partitions, err := kafka.GroupLag(brokers, *group)
+		if err != nil {
+			return err
+		}
+		printConsumerStatuses([]kafka.ConsumerStatus{{Group: *group, Partitions: partitions, Healthy: true}}, false)
+		return nil
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	var statuses []kafka.ConsumerStatus
+	var failed []string
+	for _, service := range strings.Split(*services, ",") {
+		result, err := fetchConsumerStatuses(client, strings.TrimRight(service, "/"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", service, err)
+			failed = append(failed, service)
+			continue
+		}
+		statuses = append(statuses, result...)
+	}
+	printConsumerStatuses(statuses, true)
+
+	unhealthy := 0
+	for _, s := range statuses {
+		if !s.Healthy {
+			unhealthy++
+		}
+	}
+	if len(failed) > 0 || unhealthy > 0 {
+		return fmt.Errorf("%d unhealthy consumer(s), %d unreachable service(s)", unhealthy, len(failed))
+	}
+	return nil
+}
+
+func fetchConsumerStatuses(client *http.Client, service string) ([]kafka.ConsumerStatus, error) {
+	resp, err := client.Get(service + "/consumers")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var statuses []kafka.ConsumerStatus
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return nil, fmt.Errorf("invalid response: %v", err)
+	}
+	return statuses, nil
+}
+
+func printConsumerStatuses(statuses []kafka.ConsumerStatus, live bool) {
+	if len(statuses) == 0 {
+		fmt.Println("no consumers")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tTOPIC\tPARTITION\tLAST OFFSET\tLAST PROCESSED\tHIGH WATERMARK\tLAG")
+	for _, s := range statuses {
+		for _, p := range s.Partitions {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%d\n", s.Group, p.Topic, p.Partition,
+				formatOffset(p.LastOffset), formatAge(p.LastProcessedAt), formatOffset(p.HighWatermark), p.Lag)
+		}
+	}
+	w.Flush()
+
+	if !live {
+		return
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tLAG\tPROCESSED\tFAILED\tERROR RATE\tSTATUS")
+	for _, s := range statuses {
+		status := "healthy"
+		switch {
+		case !s.Healthy:
+			status = "unhealthy: " + strings.Join(s.Problems, "; ")
+		case s.Degraded:
+			status = "degraded: " + strings.Join(s.Problems, "; ")
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f%%\t%s\n", s.Group, s.Lag, s.Processed, s.Failed, s.ErrorRate*100, status)
+	}
+	w.Flush()
+}
+
+func formatOffset(offset int64) string {
+	if offset < 0 {
+		return "-"
+	}
+	return fmt.Sprint(offset)
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return time.Since(t).Truncate(time.Second).String() + " ago"
+}
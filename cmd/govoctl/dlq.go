@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"govo/kafka"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func runDLQ(brokers []string, subcommand string, args []string) error {
+	fs := flag.NewFlagSet("dlq "+subcommand, flag.ExitOnError)
+	topic := fs.String("topic", "", "DLQ topic, e.g. payments.dlq")
+	limit := fs.Int("limit", 50, "maximum number of messages to list (0 = all)")
+	partition := fs.Int("partition", -1, "message partition")
+	offset := fs.Int64("offset", -1, "message offset")
+	all := fs.Bool("all", false, "redrive every message in the topic")
+	actor := fs.String("actor", "govoctl:"+getEnv("USER", "unknown"), "actor recorded on redriven messages")
+	fs.Parse(args)
+
+	if *topic == "" {
+		return errors.New("-topic is required")
+	}
+
+	dlq, err := kafka.NewDLQ(brokers)
+	if err != nil {
+		return err
+	}
+	defer dlq.Close()
+	dlq.WithActor(*actor)
+
+	switch subcommand {
+	case "list":
+		messages, err := dlq.List(*topic, *limit)
+		if err != nil {
+			return err
+		}
+		printDLQList(messages)
+		return nil
+
+	case "show":
+		if *partition < 0 || *offset < 0 {
+			return errors.New("-partition and -offset are required")
+		}
+		msg, err := dlq.Get(*topic, int32(*partition), *offset)
+		if err != nil {
+			return err
+		}
+		printDLQMessage(msg)
+		return nil
+
+	case "redrive":
+		var messages []*kafka.DLQMessage
+		switch {
+		case *all:
+			messages, err = dlq.List(*topic, 0)
+		case *partition >= 0 && *offset >= 0:
+			var msg *kafka.DLQMessage
+			msg, err = dlq.Get(*topic, int32(*partition), *offset)
+			messages = append(messages, msg)
+		default:
+			return errors.New("either -all or -partition and -offset are required")
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if err := dlq.Redrive(msg); err != nil {
+				return fmt.Errorf("failed to redrive %d/%d: %v", msg.Partition, msg.Offset, err)
+			}
+			fmt.Printf("redriven %s/%d/%d -> %s\n", msg.Topic, msg.Partition, msg.Offset, msg.OriginalTopic())
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown dlq subcommand %q", subcommand)
+	}
+}
+
+func printDLQList(messages []*kafka.DLQMessage) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PARTITION\tOFFSET\tTYPE\tEVENT ID\tGROUP\tATTEMPTS\tFAILED AT\tERROR")
+	for _, m := range messages {
+		eventType, eventID := "-", "-"
+		if m.Envelope != nil {
+			eventType, eventID = m.Envelope.Type, m.Envelope.EventId
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			m.Partition, m.Offset, eventType, eventID, m.TargetGroup(), m.Attempts(), m.FailedAt(), truncate(m.Error(), 80))
+	}
+	w.Flush()
+}
+
+func printDLQMessage(m *kafka.DLQMessage) {
+	fmt.Printf("Topic:      %s\n", m.Topic)
+	fmt.Printf("Partition:  %d\n", m.Partition)
+	fmt.Printf("Offset:     %d\n", m.Offset)
+	fmt.Printf("Timestamp:  %s\n", m.Timestamp.Format(time.RFC3339))
+	fmt.Printf("Key:        %s\n", m.Key)
+
+	fmt.Println("Headers:")
+	keys := make([]string, 0, len(m.Headers))
+	for k := range m.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %s: %s\n", k, m.Headers[k])
+	}
+
+	fmt.Println("Envelope:")
+	if m.Envelope == nil {
+		fmt.Printf("  %s\n", m.Value)
+		return
+	}
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m.Envelope)
+	if err != nil {
+		fmt.Printf("  <failed to format envelope: %v>\n", err)
+		return
+	}
+	fmt.Println(string(out))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Kafka yönetim aracı.
+// Kullanım: govoctl [-brokers kafka:9092] <komut> <alt komut> [flag'ler]
+func main() {
+	brokers := flag.String("brokers", getEnv("KAFKA_BROKERS", "localhost:9092"), "comma separated Kafka brokers")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	brokerList := strings.Split(*brokers, ",")
+
+	var err error
+	switch args[0] {
+	case "dlq":
+		err = runDLQ(brokerList, args[1], args[2:])
+	case "topics":
+		err = runTopics(brokerList, args[1], args[2:])
+	case "consumers":
+		err = runConsumers(brokerList, args[1], args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "govoctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage: govoctl [-brokers host:port,...] <command> <subcommand> [flags]
+
+Commands:
+  dlq list     -topic payments.dlq [-limit 50]
+  dlq show     -topic payments.dlq -partition 0 -offset 12
+  dlq redrive  -topic payments.dlq (-partition 0 -offset 12 | -all) [-actor govoctl:$USER]
+  topics diff  [-config topics.json] [-allow-partition-increase]
+  topics apply [-config topics.json] [-dry-run] [-allow-partition-increase]
+  consumers status [-services http://localhost:8080,...] [-group payment-erasure]`)
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"govo/kafka"
+)
+
+func runTopics(brokers []string, subcommand string, args []string) error {
+	fs := flag.NewFlagSet("topics "+subcommand, flag.ExitOnError)
+	configPath := fs.String("config", os.Getenv("KAFKA_TOPICS_CONFIG"), "topic config file (default: built-in kafka/topics.json)")
+	dryRun := fs.Bool("dry-run", false, "only print the changes")
+	allowPartitions := fs.Bool("allow-partition-increase", false, "add partitions to topics with fewer than configured (changes key -> partition mapping)")
+	fs.Parse(args)
+
+	cfg, err := kafka.LoadTopicConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	admin, err := kafka.NewTopicAdmin(brokers)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	admin.AllowPartitionIncrease = *allowPartitions
+
+	changes, err := admin.Plan(cfg)
+	if err != nil {
+		return err
+	}
+
+	switch subcommand {
+	case "diff":
+		printTopicChanges(changes)
+		if len(changes) > 0 {
+			return fmt.Errorf("%d difference(s) from the topic config", len(changes))
+		}
+		return nil
+
+	case "apply":
+		printTopicChanges(changes)
+		if *dryRun {
+			return nil
+		}
+		if err := admin.Apply(changes); err != nil {
+			return err
+		}
+
+		drift := 0
+		for _, c := range changes {
+			if c.Action == kafka.TopicDrift {
+				drift++
+			}
+		}
+		fmt.Printf("applied %d change(s)\n", len(changes)-drift)
+		if drift > 0 {
+			return fmt.Errorf("%d difference(s) need manual action", drift)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown topics subcommand %q", subcommand)
+	}
+}
+
+func printTopicChanges(changes []kafka.TopicChange) {
+	if len(changes) == 0 {
+		fmt.Println("topics match the config")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tTOPIC\tFIELD\tCURRENT\tDESIRED")
+	for _, c := range changes {
+		field, have := c.Field, c.Have
+		if c.Action == kafka.TopicCreate {
+			field, have = "partitions", "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Action, c.Topic, field, have, c.Want)
+	}
+	w.Flush()
+}
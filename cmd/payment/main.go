@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"log"
 	"net"
 	"net/http"
@@ -10,15 +12,28 @@ import (
 	"syscall"
 	"time"
 
+	cardpb "govo/api/proto/card"
+	customerpb "govo/api/proto/customer"
 	paymentpb "govo/api/proto/payment"
+	"govo/internal/inbox"
+	"govo/internal/outbox"
 	"govo/internal/payment/handler"
 	"govo/internal/payment/model"
 	"govo/internal/payment/repository"
 	"govo/internal/payment/service"
+	"govo/internal/requestctx"
+	"govo/internal/saga"
+	webhookhandler "govo/internal/webhook/handler"
+	webhookmodel "govo/internal/webhook/model"
+	webhookrepository "govo/internal/webhook/repository"
+	webhookservice "govo/internal/webhook/service"
 	"govo/kafka"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -34,6 +49,8 @@ func (s *PaymentServer) CreatePayment(ctx context.Context, req *paymentpb.Create
 		ctx,
 		uint(req.CustomerId),
 		uint(req.CardId),
+		uint(req.AccountId),
+		uint(req.BeneficiaryId),
 		req.Amount,
 		req.PaymentType,
 		req.Description,
@@ -44,15 +61,17 @@ func (s *PaymentServer) CreatePayment(ctx context.Context, req *paymentpb.Create
 
 	return &paymentpb.CreatePaymentResponse{
 		Payment: &paymentpb.Payment{
-			Id:          uint32(payment.ID),
-			CustomerId:  uint32(payment.CustomerID),
-			CardId:      uint32(payment.CardID),
-			Amount:      payment.Amount,
-			PaymentType: payment.PaymentType,
-			Status:      payment.Status,
-			Description: payment.Description,
-			CreatedAt:   timestamppb.New(payment.CreatedAt),
-			UpdatedAt:   timestamppb.New(payment.UpdatedAt),
+			Id:            uint32(payment.ID),
+			CustomerId:    uint32(payment.CustomerID),
+			CardId:        uint32(payment.CardID),
+			AccountId:     uint32(payment.AccountID),
+			BeneficiaryId: uint32(payment.BeneficiaryID),
+			Amount:        payment.Amount,
+			PaymentType:   payment.PaymentType,
+			Status:        payment.Status,
+			Description:   payment.Description,
+			CreatedAt:     timestamppb.New(payment.CreatedAt),
+			UpdatedAt:     timestamppb.New(payment.UpdatedAt),
 		},
 	}, nil
 }
@@ -65,15 +84,17 @@ func (s *PaymentServer) GetPayment(ctx context.Context, req *paymentpb.GetPaymen
 
 	return &paymentpb.GetPaymentResponse{
 		Payment: &paymentpb.Payment{
-			Id:          uint32(payment.ID),
-			CustomerId:  uint32(payment.CustomerID),
-			CardId:      uint32(payment.CardID),
-			Amount:      payment.Amount,
-			PaymentType: payment.PaymentType,
-			Status:      payment.Status,
-			Description: payment.Description,
-			CreatedAt:   timestamppb.New(payment.CreatedAt),
-			UpdatedAt:   timestamppb.New(payment.UpdatedAt),
+			Id:            uint32(payment.ID),
+			CustomerId:    uint32(payment.CustomerID),
+			CardId:        uint32(payment.CardID),
+			AccountId:     uint32(payment.AccountID),
+			BeneficiaryId: uint32(payment.BeneficiaryID),
+			Amount:        payment.Amount,
+			PaymentType:   payment.PaymentType,
+			Status:        payment.Status,
+			Description:   payment.Description,
+			CreatedAt:     timestamppb.New(payment.CreatedAt),
+			UpdatedAt:     timestamppb.New(payment.UpdatedAt),
 		},
 	}, nil
 }
@@ -100,15 +121,17 @@ func (s *PaymentServer) ListPayments(ctx context.Context, req *paymentpb.ListPay
 
 	for i, p := range payments {
 		response.Payments[i] = &paymentpb.Payment{
-			Id:          uint32(p.ID),
-			CustomerId:  uint32(p.CustomerID),
-			CardId:      uint32(p.CardID),
-			Amount:      p.Amount,
-			PaymentType: p.PaymentType,
-			Status:      p.Status,
-			Description: p.Description,
-			CreatedAt:   timestamppb.New(p.CreatedAt),
-			UpdatedAt:   timestamppb.New(p.UpdatedAt),
+			Id:            uint32(p.ID),
+			CustomerId:    uint32(p.CustomerID),
+			CardId:        uint32(p.CardID),
+			AccountId:     uint32(p.AccountID),
+			BeneficiaryId: uint32(p.BeneficiaryID),
+			Amount:        p.Amount,
+			PaymentType:   p.PaymentType,
+			Status:        p.Status,
+			Description:   p.Description,
+			CreatedAt:     timestamppb.New(p.CreatedAt),
+			UpdatedAt:     timestamppb.New(p.UpdatedAt),
 		}
 	}
 
@@ -126,6 +149,47 @@ func (s *PaymentServer) CancelPayment(ctx context.Context, req *paymentpb.Cancel
 	}, nil
 }
 
+func (s *PaymentServer) WatchPayments(req *paymentpb.WatchPaymentsRequest, stream paymentpb.PaymentService_WatchPaymentsServer) error {
+	err := s.service.WatchPayments(stream.Context(), uint(req.CustomerId), uint(req.PaymentId), req.ResumeToken, func(update *service.PaymentUpdate) error {
+		p := update.Payment
+		return stream.Send(&paymentpb.PaymentUpdate{
+			Payment: &paymentpb.Payment{
+				Id:            uint32(p.ID),
+				CustomerId:    uint32(p.CustomerID),
+				CardId:        uint32(p.CardID),
+				AccountId:     uint32(p.AccountID),
+				BeneficiaryId: uint32(p.BeneficiaryID),
+				Amount:        p.Amount,
+				PaymentType:   p.PaymentType,
+				Status:        p.Status,
+				Description:   p.Description,
+				CreatedAt:     timestamppb.New(p.CreatedAt),
+				UpdatedAt:     timestamppb.New(p.UpdatedAt),
+			},
+			EventType:     update.EventType,
+			Sequence:      p.Sequence,
+			OccurredAt:    timestamppb.New(update.OccurredAt),
+			FailureReason: update.FailureReason,
+			ResumeToken:   update.ResumeToken,
+		})
+	})
+
+	// İstemci kapattıysa hata dönülmez
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, kafka.ErrPositionExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, service.ErrWatchUnavailable), errors.Is(err, kafka.ErrFeedStopped), errors.Is(err, kafka.ErrNotConnected):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
 func main() {
 	// PostgreSQL bağlantısı
 	dsn := "host=postgres user=postgres password=postgres dbname=paymentdb port=5432 sslmode=disable"
@@ -135,34 +199,160 @@ func main() {
 	}
 
 	// Tabloları oluştur
-	if err := db.AutoMigrate(&model.Payment{}); err != nil {
+	if err := db.AutoMigrate(&model.Payment{}, &model.PaymentEvent{}, &model.PaymentSnapshot{}, &inbox.ProcessedEvent{}, &inbox.AggregateSequence{}, &outbox.Message{}, &saga.Instance{},
+		&webhookmodel.Endpoint{}, &webhookmodel.Delivery{}, &webhookmodel.DeliveryAttempt{}); err != nil {
 		log.Fatalf("Tablo oluşturulamadı: %v", err)
 	}
 
+	// Aktör, müşteri ve rol sadece gateway'in doğruladığı JWT'den okunur
+	jwtSecret, err := requestctx.SecretFromEnv("JWT_SECRET")
+	if err != nil {
+		log.Fatalf("JWT anahtarı okunamadı: %v", err)
+	}
+	requestctx.UseTokenSecret(jwtSecret)
+
+	// Olay şemaları SCHEMA_REGISTRY_URL tanımlıysa açılışta kaydedilir ve tüketirken kontrol edilir
+	schemaRegistry, err := kafka.SchemaRegistryFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz şema kayıt defteri ayarları: %v", err)
+	}
+	if schemaRegistry != nil {
+		if err := schemaRegistry.RegisterAll(context.Background()); err != nil {
+			log.Fatalf("Olay şemaları kaydedilemedi: %v", err)
+		}
+		kafka.UseSchemaRegistry(schemaRegistry)
+	}
+
 	// Kafka client
-	kafkaClient := kafka.NewClient([]string{"kafka:9092"})
+	producerConfig, err := kafka.ProducerConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka producer ayarları: %v", err)
+	}
+	// Kafka'ya ulaşılamazsa servis degraded açılır, client arka planda bağlanır ve olaylar outbox'ta bekler
+	kafkaClient := kafka.NewClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
 	defer kafkaClient.Close()
+	// Eksik topic'leri bağlantı kurulunca oluştur; tanımdan farklı olanlar loglanır, servis yine de açılır
+	topicConfig, err := kafka.LoadTopicConfig(os.Getenv("KAFKA_TOPICS_CONFIG"))
+	if err != nil {
+		log.Fatalf("Geçersiz Kafka topic ayarları: %v", err)
+	}
+	kafkaClient.OnConnect(func() {
+		if err := kafka.EnsureTopics([]string{"kafka:9092"}, topicConfig); err != nil {
+			log.Printf("Kafka topic'leri doğrulanamadı: %v", err)
+		}
+	})
+	kafkaSubscriber := kafka.NewSubscriber([]string{"kafka:9092"})
+	defer kafkaSubscriber.Close()
 
-	// Kafka consumer
-	consumer := kafka.NewConsumer([]string{"kafka:9092"})
-	defer consumer.Close()
+	// Hesap işlemleri için müşteri servisi
+	customerConn, err := grpc.NewClient("customer-service:50052", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(requestctx.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Müşteri servisine bağlanılamadı: %v", err)
+	}
+	defer customerConn.Close()
+	customerClient := service.NewCustomerClient(customerpb.NewCustomerServiceClient(customerConn))
 
-	// Consumer'ı başlat
-	ctx, cancel := context.WithCancel(context.Background())
-	go consumer.Start(ctx)
+	// Kart ödemelerinde limit ayırma ve bakiye için kart servisi
+	cardConn, err := grpc.NewClient("card-service:50054", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithUnaryInterceptor(requestctx.UnaryClientInterceptor()))
+	if err != nil {
+		log.Fatalf("Kart servisine bağlanılamadı: %v", err)
+	}
+	defer cardConn.Close()
+	cardClient := service.NewCardClient(cardpb.NewCardServiceClient(cardConn))
 
 	// Dependency injection
 	paymentRepo := repository.NewPaymentRepository(db)
-	paymentService := service.NewPaymentService(paymentRepo, kafkaClient)
+	// Olay deposundan önceki ödemeler o anki durumlarıyla aktarılır
+	if imported, err := paymentRepo.ImportLegacy(context.Background()); err != nil {
+		log.Fatalf("Eski ödemeler olay deposuna aktarılamadı: %v", err)
+	} else if imported > 0 {
+		log.Printf("%d ödeme olay deposuna aktarıldı", imported)
+	}
+	// WatchPayments payments topic'inden beslenir
+	paymentFeed := kafka.NewFeed([]string{"kafka:9092"}, kafka.PaymentsTopic)
+	// Web arayüzündeki canlı akış kart bakiyelerini cards.state topic'inden alır
+	cardStateFeed := kafka.NewFeed([]string{"kafka:9092"}, kafka.CardStateTopic)
+	paymentService := service.NewPaymentService(service.NewRepositoryStore(paymentRepo), customerClient).WithUpdates(paymentFeed).WithCardStates(cardStateFeed)
 	paymentServer := &PaymentServer{service: paymentService}
 	paymentHandler := handler.NewPaymentHandler(paymentService)
+	// Birden fazla replikada token'lar her instance'ta doğrulanabilmeli, anahtar ortak olmalı
+	streamSecret, err := requestctx.SecretFromEnv("PAYMENT_STREAM_SECRET")
+	if err != nil {
+		log.Fatalf("Stream anahtarı okunamadı: %v", err)
+	}
+	streamHandler := handler.NewStreamHandler(paymentService, handler.NewStreamTokens(streamSecret, time.Hour))
+
+	// Ödemelerin bakiye hareketlerini yürüten saga'lar
+	orchestrator := saga.NewOrchestrator(db)
+	for _, def := range service.NewPaymentSagas(customerClient, cardClient) {
+		orchestrator.Register(def)
+	}
+
+	// /ready consumer'lar bu eşikleri aştığında hazır değil döner
+	healthThresholds, err := kafka.HealthThresholdsFromEnv()
+	if err != nil {
+		log.Fatalf("Geçersiz consumer sağlık eşikleri: %v", err)
+	}
+
+	// Müşteri silme taleplerini dinle
+	customerConsumer := kafka.NewConsumer(kafkaSubscriber, "payment-erasure", kafka.CustomersTopic).WithRetry(kafkaClient, kafka.DefaultRetryTiers...).WithHealth(healthThresholds)
+	kafka.HandleEvent(customerConsumer, kafka.EventCustomerErasureRequested, paymentService.HandleErasureRequested)
+
+	// Ödeme ve kart olaylarını kayıtlı webhook endpoint'lerine ilet
+	webhookService := webhookservice.NewWebhookService(webhookrepository.NewWebhookRepository(db))
+	webhookHandler := webhookhandler.NewWebhookHandler(webhookService)
+	webhookConsumer := kafka.NewConsumer(kafkaSubscriber, "payment-webhooks", kafka.PaymentsTopic, kafka.CardsTopic).WithRetry(kafkaClient, kafka.DefaultRetryTiers...).WithHealth(healthThresholds)
+	for _, eventType := range webhookservice.DeliverableEvents {
+		webhookConsumer.Handle(eventType, webhookService.HandleEvent)
+	}
+
+	// Consumer'ları ve saga'ları başlat; yarıda kalan saga'lar kaldığı adımdan devam eder
+	ctx, cancel := context.WithCancel(context.Background())
+	go customerConsumer.Start(ctx)
+	go orchestrator.Start(ctx)
+	go webhookConsumer.Start(ctx)
+	consumers := []*kafka.Consumer{customerConsumer, webhookConsumer}
+	expvar.Publish("kafka_consumers", expvar.Func(func() interface{} { return kafka.ConsumerStatuses(consumers...) }))
+	go webhookService.Start(ctx)
+	// Feed'ler Kafka'ya ulaşılana kadar arka planda bağlanmayı dener, durumları /ready'de görünür
+	go paymentFeed.Start(ctx)
+	go cardStateFeed.Start(ctx)
+
+	// İşlenmiş olay kayıtlarını temizle
+	go inbox.StartCleanup(ctx, db, inbox.DefaultRetention, time.Hour)
+
+	// Outbox'taki olayları Kafka'ya gönder; async modda gönderim sonucu callback ile işlenir
+	var relayPublisher kafka.Publisher = kafkaClient
+	producers := []kafka.ConnectionReporter{kafkaClient}
+	if producerConfig.Async {
+		asyncClient := kafka.NewAsyncClient([]string{"kafka:9092"}, kafka.WithProducerConfig(producerConfig))
+		defer asyncClient.Close()
+		expvar.Publish("kafka_producer", expvar.Func(func() interface{} { return asyncClient.Stats() }))
+		relayPublisher = asyncClient
+		producers = append(producers, asyncClient)
+	}
+	go outbox.NewRelay(db, relayPublisher).Start(ctx)
 
 	// HTTP router
 	router := mux.NewRouter()
+	router.Use(requestctx.HTTPMiddleware)
 	router.HandleFunc("/api/payments", paymentHandler.CreatePayment).Methods("POST")
 	router.HandleFunc("/api/payments", paymentHandler.GetPayment).Methods("GET")
+	router.HandleFunc("/api/payments/history", paymentHandler.GetPaymentHistory).Methods("GET")
 	router.HandleFunc("/api/payments/list", paymentHandler.ListPayments).Methods("GET")
 	router.HandleFunc("/api/payments/cancel", paymentHandler.CancelPayment).Methods("POST")
+	router.HandleFunc("/api/payments/stream/token", streamHandler.IssueToken).Methods("POST")
+	router.HandleFunc("/api/payments/stream", streamHandler.Stream).Methods("GET")
+	router.HandleFunc("/api/webhooks", webhookHandler.RegisterEndpoint).Methods("POST")
+	router.HandleFunc("/api/webhooks", webhookHandler.ListEndpoints).Methods("GET")
+	router.HandleFunc("/api/webhooks", webhookHandler.DeleteEndpoint).Methods("DELETE")
+	router.HandleFunc("/api/webhooks/test", webhookHandler.SendTest).Methods("POST")
+	router.HandleFunc("/api/webhooks/deliveries", webhookHandler.GetDeliveries).Methods("GET")
+	router.HandleFunc("/api/webhooks/deliveries/redeliver", webhookHandler.Redeliver).Methods("POST")
+	router.Handle("/debug/vars", expvar.Handler())
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }).Methods("GET")
+	router.Handle("/ready", kafka.ReadinessHandler(consumers...).WithProducers(producers...).WithFeeds(paymentFeed, cardStateFeed)).Methods("GET")
+	router.Handle("/consumers", kafka.ConsumersHandler(consumers...)).Methods("GET")
 
 	// HTTP server
 	go func() {
@@ -178,7 +368,7 @@ func main() {
 		log.Fatalf("Port dinlenemedi: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(requestctx.UnaryServerInterceptor()), grpc.StreamInterceptor(requestctx.StreamServerInterceptor()))
 	paymentpb.RegisterPaymentServiceServer(grpcServer, paymentServer)
 
 	// Graceful shutdown
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/customer/service"
+	"govo/kafka"
+
+	"gorm.io/gorm"
+)
+
+// Yeniden oluşturulabilen okuma modelleri. snapshot, dry-run'da gösterilecek farkı
+// hesaplamak için modelin satırlarını anahtar -> değer olarak döner.
+type handlerSet struct {
+	topic    string
+	register func(r *kafka.Router, tx *gorm.DB)
+	reset    func(tx *gorm.DB) error
+	snapshot func(tx *gorm.DB) (map[string]string, error)
+}
+
+var handlerSets = map[string]handlerSet{
+	// Müşteri servisindeki kart listesi, cards topic'inden beslenir
+	"customer-cards": {
+		topic: kafka.CardsTopic,
+		register: func(r *kafka.Router, tx *gorm.DB) {
+			cardSync := service.NewCardSyncService(repository.NewCustomerCardRepository(tx))
+			kafka.HandleEvent(r, kafka.EventCardIssued, cardSync.HandleCardChanged)
+			kafka.HandleEvent(r, kafka.EventCardUpdated, cardSync.HandleCardChanged)
+			kafka.HandleEvent(r, kafka.EventCardStatusChanged, cardSync.HandleCardChanged)
+			kafka.HandleEvent(r, kafka.EventCardLimitChanged, cardSync.HandleCardChanged)
+			kafka.HandleEvent(r, kafka.EventCardRemoved, cardSync.HandleCardRemoved)
+		},
+		reset: func(tx *gorm.DB) error {
+			return tx.Where("1 = 1").Delete(&model.CustomerCard{}).Error
+		},
+		snapshot: func(tx *gorm.DB) (map[string]string, error) {
+			var cards []model.CustomerCard
+			if err := tx.Find(&cards).Error; err != nil {
+				return nil, err
+			}
+			rows := make(map[string]string, len(cards))
+			for _, c := range cards {
+				rows[fmt.Sprintf("customer_cards card_id=%d", c.CardID)] = fmt.Sprintf("customer_id=%d masked_number=%q card_type=%s is_active=%t",
+					c.CustomerID, c.MaskedNumber, c.CardType, c.IsActive)
+			}
+			return rows, nil
+		},
+	},
+
+	// Silme taleplerinin servis bazlı adımları, customers topic'indeki raporlardan beslenir
+	"erasure-steps": {
+		topic: kafka.CustomersTopic,
+		register: func(r *kafka.Router, tx *gorm.DB) {
+			gdpr := service.NewGDPRService(nil, nil, repository.NewErasureRepository(tx), nil, nil)
+			kafka.HandleEvent(r, kafka.EventCustomerErasureCompleted, gdpr.HandleErasureCompleted)
+		},
+		snapshot: func(tx *gorm.DB) (map[string]string, error) {
+			var steps []model.ErasureStep
+			if err := tx.Find(&steps).Error; err != nil {
+				return nil, err
+			}
+			var requests []model.ErasureRequest
+			if err := tx.Omit("Steps").Find(&requests).Error; err != nil {
+				return nil, err
+			}
+			rows := make(map[string]string, len(steps)+len(requests))
+			for _, s := range steps {
+				rows[fmt.Sprintf("erasure_steps erasure_id=%d service=%s", s.ErasureID, s.Service)] = fmt.Sprintf("status=%s anonymized=%d retained=%d detail=%q",
+					s.Status, s.AnonymizedRecords, s.RetainedRecords, s.Detail)
+			}
+			for _, r := range requests {
+				rows[fmt.Sprintf("erasure_requests id=%d", r.ID)] = fmt.Sprintf("status=%s", r.Status)
+			}
+			return rows, nil
+		},
+	},
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"govo/kafka"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Bir topic'i (veya dışa aktarılmış JSONL dosyasını) seçilen handler'lardan geçirerek
+// okuma modellerini yeniden oluşturur. Tüm replay tek transaction'dır; -dry-run
+// değişiklikleri yazdırıp geri alır.
+//
+// Kullanım:
+//
+//	replay -handlers customer-cards [-from 2025-01-01T00:00:00Z | -offset 120] [-reset] [-dry-run]
+//	replay -handlers customer-cards -file cards.jsonl -dry-run
+//	replay -topic cards -export cards.jsonl
+func main() {
+	brokers := flag.String("brokers", getEnv("KAFKA_BROKERS", "localhost:9092"), "comma separated Kafka brokers")
+	dsn := flag.String("dsn", "host=postgres user=postgres password=postgres dbname=customerdb port=5432 sslmode=disable", "read model database DSN")
+	handlers := flag.String("handlers", "", "comma separated handler sets: "+strings.Join(handlerSetNames(), ", "))
+	topic := flag.String("topic", "", "topic to read (defaults to the handler sets' topics)")
+	from := flag.String("from", "", "replay events published at or after this RFC3339 time")
+	offset := flag.Int64("offset", -2, "start offset when -from is not set (-2 = oldest)")
+	partition := flag.Int("partition", -1, "read only this partition (-1 = all)")
+	file := flag.String("file", "", "read events from a JSONL export instead of Kafka")
+	export := flag.String("export", "", "write the events to this JSONL file instead of replaying them")
+	reset := flag.Bool("reset", false, "clear the read models before replaying")
+	dryRun := flag.Bool("dry-run", false, "print the changes without committing them")
+	flag.Parse()
+
+	sets, err := selectHandlerSets(*handlers)
+	if err != nil && *export == "" {
+		log.Fatalf("%v", err)
+	}
+
+	opts := kafka.DefaultReadOptions()
+	opts.Offset = *offset
+	opts.Partition = int32(*partition)
+	if *from != "" {
+		if opts.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			log.Fatalf("Geçersiz -from değeri: %v", err)
+		}
+	}
+
+	topics := topicsFor(sets)
+	if *topic != "" {
+		topics = []string{*topic}
+	}
+
+	messages, err := loadMessages(strings.Split(*brokers, ","), topics, *file, opts)
+	if err != nil {
+		log.Fatalf("Olaylar okunamadı: %v", err)
+	}
+	log.Printf("%d olay okundu", len(messages))
+
+	if *export != "" {
+		f, err := os.Create(*export)
+		if err != nil {
+			log.Fatalf("Dosya oluşturulamadı: %v", err)
+		}
+		defer f.Close()
+		if err := kafka.WriteJSONL(f, messages); err != nil {
+			log.Fatalf("Olaylar yazılamadı: %v", err)
+		}
+		log.Printf("%d olay %s dosyasına yazıldı", len(messages), *export)
+		return
+	}
+
+	db, err := gorm.Open(postgres.Open(*dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Veritabanına bağlanılamadı: %v", err)
+	}
+
+	if err := replay(db, sets, messages, *reset, *dryRun); err != nil {
+		log.Fatalf("Replay başarısız: %v", err)
+	}
+}
+
+var errDryRun = errors.New("dry run")
+
+func replay(db *gorm.DB, sets map[string]handlerSet, messages []*kafka.Message, reset, dryRun bool) error {
+	var applied, skipped int
+	var before, after map[string]string
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		router := kafka.NewRouter()
+		for _, set := range sets {
+			set.register(router, tx)
+		}
+
+		var err error
+		if before, err = snapshot(tx, sets); err != nil {
+			return err
+		}
+
+		if reset {
+			for name, set := range sets {
+				if set.reset == nil {
+					return fmt.Errorf("handler set %s cannot be reset", name)
+				}
+				if err := set.reset(tx); err != nil {
+					return fmt.Errorf("failed to reset %s: %v", name, err)
+				}
+			}
+		}
+
+		// Replay'de Group boş bırakılır, işlenmiş olay kayıtlarına bakılmaz
+		ctx := context.Background()
+		for _, m := range messages {
+			if !router.Handles(m.EventType()) {
+				skipped++
+				continue
+			}
+			if err := router.Dispatch(ctx, m); err != nil {
+				return fmt.Errorf("%s/%d/%d: %v", m.Topic, m.Partition, m.Offset, err)
+			}
+			applied++
+		}
+
+		if after, err = snapshot(tx, sets); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return err
+	}
+
+	changes := printDiff(before, after, dryRun)
+	if dryRun {
+		log.Printf("Dry run: %d olay uygulanacak, %d olay atlanacak, %d satır değişecek", applied, skipped, changes)
+	} else {
+		log.Printf("%d olay uygulandı, %d olay atlandı, %d satır değişti", applied, skipped, changes)
+	}
+	return nil
+}
+
+func snapshot(tx *gorm.DB, sets map[string]handlerSet) (map[string]string, error) {
+	rows := make(map[string]string)
+	for name, set := range sets {
+		part, err := set.snapshot(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", name, err)
+		}
+		for k, v := range part {
+			rows[k] = v
+		}
+	}
+	return rows, nil
+}
+
+// Değişen satır sayısını döner, dry-run'da satırları da yazdırır
+func printDiff(before, after map[string]string, verbose bool) int {
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	changes := 0
+	for _, k := range sorted {
+		old, hadOld := before[k]
+		cur, hasCur := after[k]
+		var line string
+		switch {
+		case !hadOld:
+			line = fmt.Sprintf("+ %s %s", k, cur)
+		case !hasCur:
+			line = fmt.Sprintf("- %s %s", k, old)
+		case old != cur:
+			line = fmt.Sprintf("~ %s %s -> %s", k, old, cur)
+		default:
+			continue
+		}
+		changes++
+		if verbose {
+			fmt.Println(line)
+		}
+	}
+	return changes
+}
+
+func loadMessages(brokers, topics []string, file string, opts kafka.ReadOptions) ([]*kafka.Message, error) {
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return kafka.ReadJSONL(f)
+	}
+
+	if len(topics) == 0 {
+		return nil, errors.New("-topic or -handlers is required")
+	}
+	var messages []*kafka.Message
+	for _, topic := range topics {
+		part, err := kafka.ReadTopic(brokers, topic, opts)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, part...)
+	}
+	kafka.SortMessages(messages)
+	return messages, nil
+}
+
+func selectHandlerSets(names string) (map[string]handlerSet, error) {
+	if names == "" {
+		return nil, fmt.Errorf("-handlers is required (%s)", strings.Join(handlerSetNames(), ", "))
+	}
+	sets := make(map[string]handlerSet)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		set, ok := handlerSets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown handler set %q (%s)", name, strings.Join(handlerSetNames(), ", "))
+		}
+		sets[name] = set
+	}
+	return sets, nil
+}
+
+func topicsFor(sets map[string]handlerSet) []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for _, set := range sets {
+		if !seen[set.topic] {
+			seen[set.topic] = true
+			topics = append(topics, set.topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func handlerSetNames() []string {
+	names := make([]string, 0, len(handlerSets))
+	for name := range handlerSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
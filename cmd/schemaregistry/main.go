@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"govo/internal/schema"
+	"govo/internal/schemaregistry"
+
+	"github.com/gorilla/mux"
+)
+
+// Yerel geliştirme ve testler için Schema Registry. Subject'ler olay tipleridir (ör. PAYMENT_CREATED);
+// servisler SCHEMA_REGISTRY_URL tanımlıysa yayınladıkları olayların şemasını buraya kaydeder.
+//
+// Kullanım:
+//
+//	schemaregistry -addr :8086 -data /data/schemas.json -compatibility BACKWARD
+//	curl localhost:8086/subjects
+//	curl -X PUT localhost:8086/config/PAYMENT_CREATED -d '{"compatibility":"FULL"}'
+func main() {
+	addr := flag.String("addr", ":8086", "listen address")
+	data := flag.String("data", os.Getenv("SCHEMA_REGISTRY_DATA"), "file to keep the schemas in (empty = memory only)")
+	compatibility := flag.String("compatibility", string(schema.Backward), "default compatibility for new subjects")
+	flag.Parse()
+
+	mode, err := schema.ParseCompatibility(*compatibility)
+	if err != nil {
+		log.Fatalf("Geçersiz uyumluluk kuralı: %v", err)
+	}
+	store, err := schemaregistry.NewStore(*data, mode)
+	if err != nil {
+		log.Fatalf("Şemalar okunamadı: %v", err)
+	}
+
+	router := mux.NewRouter()
+	schemaregistry.NewHandler(store).Routes(router)
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+
+	log.Printf("Schema registry %s adresinde dinliyor (%s)...", *addr, mode)
+	if err := http.ListenAndServe(*addr, router); err != nil {
+		log.Fatalf("HTTP server başlatılamadı: %v", err)
+	}
+}
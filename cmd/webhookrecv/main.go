@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"govo/internal/webhook"
+)
+
+// Webhook'ları yerelde denemek için alıcı. İmzayı doğrular ve olayları yazdırır.
+// -status ile farklı HTTP kodu dönerek tekrar deneme davranışı gözlemlenebilir.
+//
+// Kullanım:
+//
+//	webhookrecv -addr :9090 -secret whsec_...
+//	curl -X POST localhost:8080/api/webhooks -d '{"owner_type":"PARTNER","owner_id":"local","url":"http://host.docker.internal:9090/","event_types":["*"]}'
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	secret := flag.String("secret", os.Getenv("WEBHOOK_SECRET"), "endpoint secret returned at registration (empty = skip verification)")
+	tolerance := flag.Duration("tolerance", webhook.DefaultTolerance, "accepted clock skew for the timestamp header")
+	status := flag.Int("status", http.StatusOK, "status code to respond with")
+	flag.Parse()
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		verified := "not checked"
+		if *secret != "" {
+			err := webhook.Verify(*secret, r.Header.Get(webhook.HeaderSignature), r.Header.Get(webhook.HeaderTimestamp), body, *tolerance)
+			if err != nil {
+				log.Printf("Rejected delivery %s: %v", r.Header.Get(webhook.HeaderDeliveryID), err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			verified = "ok"
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			pretty.Write(body)
+		}
+		fmt.Printf("%s %s event=%s delivery=%s signature=%s\n%s\n\n",
+			time.Now().Format(time.RFC3339),
+			r.Header.Get(webhook.HeaderEventType),
+			r.Header.Get(webhook.HeaderEventID),
+			r.Header.Get(webhook.HeaderDeliveryID),
+			verified,
+			pretty.String(),
+		)
+		w.WriteHeader(*status)
+	})
+
+	log.Printf("Webhook alıcısı %s adresinde dinliyor...", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		log.Fatalf("HTTP server başlatılamadı: %v", err)
+	}
+}
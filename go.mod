@@ -5,10 +5,11 @@ go 1.23
 toolchain go1.24.2
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/IBM/sarama v1.45.1
 	github.com/gin-gonic/gin v1.10.1
 	github.com/gorilla/mux v1.8.1
-	github.com/segmentio/kafka-go v0.4.47
+	github.com/jackc/pgx/v5 v5.4.3
 	google.golang.org/grpc v1.72.2
 	google.golang.org/protobuf v1.36.5
 	gorm.io/driver/postgres v1.5.6
@@ -36,7 +37,6 @@ require (
 	github.com/hashicorp/go-uuid v1.0.3 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
-	github.com/jackc/pgx/v5 v5.4.3 // indirect
 	github.com/jcmturner/aescts/v2 v2.0.0 // indirect
 	github.com/jcmturner/dnsutils/v2 v2.0.0 // indirect
 	github.com/jcmturner/gofork v1.7.6 // indirect
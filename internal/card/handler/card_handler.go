@@ -26,6 +26,22 @@ type CreateCardRequest struct {
 	Balance     float64 `json:"balance"`
 }
 
+type UpdateCardStatusRequest struct {
+	CardID   uint `json:"card_id"`
+	IsActive bool `json:"is_active"`
+}
+
+type UpdateCardRequest struct {
+	CardID     uint   `json:"card_id"`
+	CardType   string `json:"card_type"`
+	ExpiryDate string `json:"expiry_date"`
+}
+
+type UpdateCreditLimitRequest struct {
+	CardID      uint    `json:"card_id"`
+	CreditLimit float64 `json:"credit_limit"`
+}
+
 type CardResponse struct {
 	ID          uint    `json:"id"`
 	CustomerID  uint    `json:"customer_id"`
@@ -44,6 +60,7 @@ func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := h.service.AddCard(
+		r.Context(),
 		req.CustomerID,
 		req.CardNumber,
 		req.CardType,
@@ -148,7 +165,7 @@ func (h *CardHandler) DeleteCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.RemoveCard(uint(id), cardNumber)
+	err = h.service.RemoveCard(r.Context(), uint(id), cardNumber)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -157,3 +174,66 @@ func (h *CardHandler) DeleteCard(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 }
+
+func (h *CardHandler) UpdateCardStatus(w http.ResponseWriter, r *http.Request) {
+	var req UpdateCardStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.CardID == 0 {
+		http.Error(w, "Card ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetCardStatus(r.Context(), req.CardID, req.IsActive); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func (h *CardHandler) UpdateCard(w http.ResponseWriter, r *http.Request) {
+	var req UpdateCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.CardID == 0 {
+		http.Error(w, "Card ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateCard(r.Context(), req.CardID, req.CardType, req.ExpiryDate); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func (h *CardHandler) UpdateCreditLimit(w http.ResponseWriter, r *http.Request) {
+	var req UpdateCreditLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.CardID == 0 {
+		http.Error(w, "Card ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetCreditLimit(r.Context(), req.CardID, req.CreditLimit); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -20,4 +20,7 @@ type Card struct {
 	CreditLimit float64 `gorm:"not null" json:"credit_limit"`
 	Balance     float64 `gorm:"not null" json:"balance"`
 	IsActive    bool    `gorm:"not null;default:true" json:"is_active"`
+
+	// Incremented on every change and carried in the card events as the aggregate sequence
+	Sequence uint64 `gorm:"not null;default:0" json:"sequence"`
 }
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	HoldStatusHeld     = "HELD"
+	HoldStatusCaptured = "CAPTURED"
+	HoldStatusReleased = "RELEASED"
+)
+
+// CardHold reserves part of a card's available limit for a payment until it
+// is captured into the balance or released. There is at most one hold per
+// payment; releasing a payment that was never authorized still records a
+// RELEASED hold so a late authorization for it is refused.
+type CardHold struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PaymentID uint    `gorm:"not null;uniqueIndex" json:"payment_id"`
+	CardID    uint    `gorm:"not null;index" json:"card_id"`
+	Amount    float64 `gorm:"not null" json:"amount"`
+	Status    string  `gorm:"size:20;not null" json:"status"`
+}
@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"context"
+
 	"govo/internal/card/model"
+	"govo/internal/inbox"
+	"govo/internal/outbox"
+	"govo/kafka"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CardRepository struct {
@@ -14,6 +20,18 @@ func NewCardRepository(db *gorm.DB) *CardRepository {
 	return &CardRepository{db: db}
 }
 
+// fn içindeki repository ve outbox aynı transaction'ı kullanır, olaylar commit ile birlikte kalıcı olur
+func (r *CardRepository) Transaction(ctx context.Context, fn func(tx *CardRepository, events kafka.Publisher) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&CardRepository{db: tx}, outbox.NewWriter(tx))
+	})
+}
+
+// Transaction dışında outbox'a yazar
+func (r *CardRepository) Outbox() kafka.Publisher {
+	return outbox.NewWriter(r.db)
+}
+
 func (r *CardRepository) Create(card *model.Card) error {
 	return r.db.Create(card).Error
 }
@@ -36,6 +54,29 @@ func (r *CardRepository) GetByCustomerID(customerID uint) ([]*model.Card, error)
 	return cards, nil
 }
 
+func (r *CardRepository) GetByCustomerAndNumber(customerID uint, cardNumber string) (*model.Card, error) {
+	var card model.Card
+	err := r.db.Where("customer_id = ? AND card_number = ?", customerID, cardNumber).First(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Transaction içinde kartı commit'e kadar kilitler; bakiye ve sıra numarası bu sırada değişmez
+func (r *CardRepository) GetForUpdate(id uint) (*model.Card, error) {
+	var card model.Card
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&card, id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Sadece verilen kolonları yazar; tüm satırı yazmak eşzamanlı bakiye değişikliklerini ezer
+func (r *CardRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&model.Card{}).Where("id = ?", id).Updates(fields).Error
+}
+
 func (r *CardRepository) Delete(id uint) error {
 	return r.db.Delete(&model.Card{}, id).Error
 }
@@ -56,3 +97,28 @@ func (r *CardRepository) AddCard(card *model.Card) error {
 func (r *CardRepository) RemoveCard(customerID uint, cardNumber string) error {
 	return r.db.Where("customer_id = ? AND card_number = ?", customerID, cardNumber).Delete(&model.Card{}).Error
 }
+
+// PAN ve CVV silinir, bakiye ve limit gibi finansal alanlar saklanır
+func (r *CardRepository) AnonymizeByCustomerID(customerID uint, key inbox.Key) (int64, error) {
+	var count int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&model.Card{}).
+			Where("customer_id = ?", customerID).
+			Updates(map[string]interface{}{
+				"card_number": gorm.Expr("'ERASED' || lpad(id::text, 10, '0')"),
+				"cvv":         "",
+				"is_active":   false,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		count = result.RowsAffected
+
+		return tx.Where("customer_id = ?", customerID).Delete(&model.Card{}).Error
+	})
+	return count, err
+}
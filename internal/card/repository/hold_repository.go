@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"govo/internal/card/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCardInactive      = errors.New("card is not active")
+	ErrCardNotOwned      = errors.New("card does not belong to customer")
+	ErrInsufficientLimit = errors.New("insufficient credit limit")
+	ErrHoldNotFound      = errors.New("payment is not authorized")
+	ErrHoldReleased      = errors.New("payment was released")
+)
+
+// Kart limitinden ödeme için yer ayırır. Aynı ödeme için tekrar çağrılırsa mevcut hold döner.
+// Kullanılabilir limit: kredi limiti - bakiye (borç) - bekleyen hold'lar.
+func (r *CardRepository) Authorize(cardID, customerID, paymentID uint, amount float64) (*model.CardHold, error) {
+	var hold model.CardHold
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var card model.Card
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&card, cardID).Error; err != nil {
+			return err
+		}
+
+		found, err := findHold(tx, paymentID, &hold)
+		if err != nil {
+			return err
+		}
+		if found {
+			if hold.Status == model.HoldStatusReleased {
+				return ErrHoldReleased
+			}
+			return nil
+		}
+
+		if card.CustomerID != customerID {
+			return ErrCardNotOwned
+		}
+		if !card.IsActive {
+			return ErrCardInactive
+		}
+
+		var held float64
+		if err := tx.Model(&model.CardHold{}).
+			Where("card_id = ? AND status = ?", cardID, model.HoldStatusHeld).
+			Select("COALESCE(SUM(amount), 0)").
+			Scan(&held).Error; err != nil {
+			return err
+		}
+		if card.Balance+held+amount > card.CreditLimit {
+			return ErrInsufficientLimit
+		}
+
+		hold = model.CardHold{
+			PaymentID: paymentID,
+			CardID:    cardID,
+			Amount:    amount,
+			Status:    model.HoldStatusHeld,
+		}
+		return tx.Create(&hold).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// Hold'daki tutarı kart bakiyesine işler
+func (r *CardRepository) Capture(paymentID uint) (*model.CardHold, error) {
+	var hold model.CardHold
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		found, err := findHold(tx, paymentID, &hold)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrHoldNotFound
+		}
+
+		switch hold.Status {
+		case model.HoldStatusCaptured:
+			return nil
+		case model.HoldStatusReleased:
+			return ErrHoldReleased
+		}
+
+		if err := tx.Model(&model.Card{}).Where("id = ?", hold.CardID).
+			Update("balance", gorm.Expr("balance + ?", hold.Amount)).Error; err != nil {
+			return fmt.Errorf("failed to update card balance: %v", err)
+		}
+		hold.Status = model.HoldStatusCaptured
+		return tx.Save(&hold).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// Hold'u serbest bırakır, bakiyeye işlendiyse tutar geri alınır.
+// Hiç yetkilendirilmemiş ödeme için RELEASED kayıt bırakılır ki geç gelen Authorize reddedilsin.
+func (r *CardRepository) Release(paymentID uint) (*model.CardHold, error) {
+	var hold model.CardHold
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		found, err := findHold(tx, paymentID, &hold)
+		if err != nil {
+			return err
+		}
+		if !found {
+			hold = model.CardHold{PaymentID: paymentID, Status: model.HoldStatusReleased}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&hold)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				// Aynı anda Authorize çalıştı, çağıran tekrar denemeli
+				return fmt.Errorf("payment %d was authorized concurrently", paymentID)
+			}
+			return nil
+		}
+
+		switch hold.Status {
+		case model.HoldStatusReleased:
+			return nil
+		case model.HoldStatusCaptured:
+			if err := tx.Model(&model.Card{}).Where("id = ?", hold.CardID).
+				Update("balance", gorm.Expr("balance - ?", hold.Amount)).Error; err != nil {
+				return fmt.Errorf("failed to refund card balance: %v", err)
+			}
+		}
+		hold.Status = model.HoldStatusReleased
+		return tx.Save(&hold).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func findHold(tx *gorm.DB, paymentID uint, hold *model.CardHold) (bool, error) {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("payment_id = ?", paymentID).Take(hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
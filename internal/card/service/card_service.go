@@ -1,12 +1,25 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"govo/api/proto/events"
 	"govo/internal/card/model"
 	"govo/internal/card/repository"
+	"govo/internal/inbox"
+	"govo/kafka"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
+// Olaylar repository üzerinden outbox'a yazılır, Kafka'ya outbox.Relay gönderir
 type CardService struct {
-	repo *repository.CardRepository
+	repo      *repository.CardRepository
+	stateFeed *kafka.Feed
 }
 
 func NewCardService(repo *repository.CardRepository) *CardService {
@@ -17,7 +30,7 @@ func (s *CardService) GetCustomerCards(customerID uint) ([]*model.Card, error) {
 	return s.repo.GetCustomerCards(customerID)
 }
 
-func (s *CardService) AddCard(customerID uint, cardNumber string, cardType string, expiryDate string, cvv string, creditLimit float64, balance float64) error {
+func (s *CardService) AddCard(ctx context.Context, customerID uint, cardNumber string, cardType string, expiryDate string, cvv string, creditLimit float64, balance float64) error {
 	card := &model.Card{
 		CustomerID:  customerID,
 		CardNumber:  cardNumber,
@@ -27,12 +40,64 @@ func (s *CardService) AddCard(customerID uint, cardNumber string, cardType strin
 		CreditLimit: creditLimit,
 		Balance:     balance,
 		IsActive:    true,
+		Sequence:    1,
 	}
-	return s.repo.Create(card)
+	return s.apply(ctx, card, kafka.EventCardIssued, nil, func(tx *repository.CardRepository) error {
+		return tx.Create(card)
+	})
+}
+
+func (s *CardService) RemoveCard(ctx context.Context, customerID uint, cardNumber string) error {
+	card, err := s.repo.GetByCustomerAndNumber(customerID, cardNumber)
+	if err != nil {
+		return fmt.Errorf("card not found: %v", err)
+	}
+	return s.remove(ctx, card.ID)
+}
+
+func (s *CardService) SetCardStatus(ctx context.Context, id uint, isActive bool) error {
+	return s.update(ctx, id, kafka.EventCardStatusChanged, func(card *model.Card) (map[string]interface{}, func(*events.CardEvent)) {
+		// Durum değişmediyse olay yayınlama
+		if card.IsActive == isActive {
+			return nil, nil
+		}
+		return map[string]interface{}{"is_active": isActive}, nil
+	})
 }
 
-func (s *CardService) RemoveCard(customerID uint, cardNumber string) error {
-	return s.repo.RemoveCard(customerID, cardNumber)
+// Boş bırakılan alanlar değiştirilmez
+func (s *CardService) UpdateCard(ctx context.Context, id uint, cardType, expiryDate string) error {
+	return s.update(ctx, id, kafka.EventCardUpdated, func(card *model.Card) (map[string]interface{}, func(*events.CardEvent)) {
+		fields := make(map[string]interface{})
+		var changed []string
+		if cardType != "" && cardType != card.CardType {
+			fields["card_type"] = cardType
+			changed = append(changed, "card_type")
+		}
+		if expiryDate != "" && expiryDate != card.ExpiryDate {
+			fields["expiry_date"] = expiryDate
+			changed = append(changed, "expiry_date")
+		}
+		return fields, func(e *events.CardEvent) {
+			e.ChangedFields = changed
+		}
+	})
+}
+
+func (s *CardService) SetCreditLimit(ctx context.Context, id uint, limit float64) error {
+	if limit < 0 {
+		return errors.New("credit limit must not be negative")
+	}
+
+	return s.update(ctx, id, kafka.EventCardLimitChanged, func(card *model.Card) (map[string]interface{}, func(*events.CardEvent)) {
+		if card.CreditLimit == limit {
+			return nil, nil
+		}
+		previous := card.CreditLimit
+		return map[string]interface{}{"credit_limit": limit}, func(e *events.CardEvent) {
+			e.PreviousCreditLimit = previous
+		}
+	})
 }
 
 func (s *CardService) GetCardByID(id uint) (*model.Card, error) {
@@ -43,6 +108,215 @@ func (s *CardService) GetCardsByCustomerID(customerID uint) ([]*model.Card, erro
 	return s.repo.GetByCustomerID(customerID)
 }
 
-func (s *CardService) DeleteCard(id uint) error {
-	return s.repo.Delete(id)
+func (s *CardService) DeleteCard(ctx context.Context, id uint) error {
+	return s.remove(ctx, id)
+}
+
+// Silinen kartın sıra numarası da kaydedilir, kart geri yüklenirse sıra devam eder
+func (s *CardService) remove(ctx context.Context, id uint) error {
+	return s.repo.Transaction(ctx, func(tx *repository.CardRepository, outbox kafka.Publisher) error {
+		card, err := tx.GetForUpdate(id)
+		if err != nil {
+			return fmt.Errorf("card not found: %v", err)
+		}
+		card.Sequence++
+		if err := tx.UpdateFields(card.ID, map[string]interface{}{"sequence": card.Sequence}); err != nil {
+			return err
+		}
+		if err := tx.Delete(card.ID); err != nil {
+			return err
+		}
+		return publishChange(ctx, outbox, card, kafka.EventCardRemoved, nil)
+	})
+}
+
+// Müşteri silme talebinde kartlar anonimleştirilir ve sonuç müşteri servisine raporlanır
+func (s *CardService) HandleErasureRequested(ctx context.Context, event *events.CustomerErasureRequested) error {
+	if event.ErasureId == 0 || event.CustomerId == 0 {
+		return fmt.Errorf("invalid erasure requested event")
+	}
+
+	report := &events.CustomerErasureCompleted{
+		ErasureId:  event.ErasureId,
+		CustomerId: event.CustomerId,
+		Service:    "card",
+	}
+	key := kafka.WithKey(strconv.Itoa(int(event.CustomerId)))
+
+	// Rapor anonimleştirmeyle aynı transaction'da outbox'a yazılır
+	err := s.repo.Transaction(ctx, func(tx *repository.CardRepository, outbox kafka.Publisher) error {
+		cards, err := tx.GetByCustomerID(uint(event.CustomerId))
+		if err != nil {
+			return err
+		}
+		count, err := tx.AnonymizeByCustomerID(uint(event.CustomerId), inbox.KeyFromContext(ctx))
+		if err != nil {
+			return err
+		}
+		// Silinen kartların durum kayıtları compaction ile topic'ten kalkar
+		for _, card := range cards {
+			if err := outbox.Send(ctx, kafka.NewTombstone(kafka.CardStateTopic, strconv.Itoa(int(card.ID)))); err != nil {
+				return err
+			}
+		}
+		// Kart kayıtları finansal geçmiş için anonim olarak saklanır
+		report.AnonymizedRecords = count
+		report.RetainedRecords = count
+		return outbox.Publish(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureCompleted, report, key)
+	})
+	if errors.Is(err, inbox.ErrDuplicate) {
+		return nil
+	}
+	if err != nil {
+		// Hata raporu transaction geri alındıktan sonra ayrıca yazılır
+		report.AnonymizedRecords = 0
+		report.RetainedRecords = 0
+		report.Error = err.Error()
+		if err := s.repo.Outbox().Publish(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureCompleted, report, key); err != nil {
+			return fmt.Errorf("failed to send erasure report: %v", err)
+		}
+	}
+	return nil
+}
+
+// Ödeme servisinin saga'sı çağırır; üç işlem de ödeme ID'siyle tekilleşir
+func (s *CardService) AuthorizePayment(cardID, customerID, paymentID uint, amount float64) (*model.CardHold, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	if paymentID == 0 {
+		return nil, errors.New("payment ID is required")
+	}
+	return s.repo.Authorize(cardID, customerID, paymentID, amount)
+}
+
+func (s *CardService) CapturePayment(ctx context.Context, paymentID uint) (*model.CardHold, error) {
+	return s.settle(ctx, func(tx *repository.CardRepository) (*model.CardHold, error) {
+		return tx.Capture(paymentID)
+	})
+}
+
+func (s *CardService) ReleasePayment(ctx context.Context, paymentID uint) (*model.CardHold, error) {
+	return s.settle(ctx, func(tx *repository.CardRepository) (*model.CardHold, error) {
+		return tx.Release(paymentID)
+	})
+}
+
+// Bakiye değiştiği için kartın güncel durumu hold ile aynı transaction'da yayınlanır
+func (s *CardService) settle(ctx context.Context, fn func(tx *repository.CardRepository) (*model.CardHold, error)) (*model.CardHold, error) {
+	var hold *model.CardHold
+	err := s.repo.Transaction(ctx, func(tx *repository.CardRepository, outbox kafka.Publisher) error {
+		var err error
+		if hold, err = fn(tx); err != nil {
+			return err
+		}
+		// Hiç yetkilendirilmemiş ödeme ya da silinmiş kart
+		if hold.CardID == 0 {
+			return nil
+		}
+		card, err := tx.GetByID(hold.CardID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return publishState(ctx, outbox, card)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// Kart değişikliği ve olayı aynı transaction'da yazılır, olay outbox üzerinden Kafka'ya gider
+func (s *CardService) apply(ctx context.Context, card *model.Card, eventType string, fill func(*events.CardEvent), change func(tx *repository.CardRepository) error) error {
+	return s.repo.Transaction(ctx, func(tx *repository.CardRepository, outbox kafka.Publisher) error {
+		if err := change(tx); err != nil {
+			return err
+		}
+		return publishChange(ctx, outbox, card, eventType, fill)
+	})
+}
+
+// Kart transaction içinde kilitlenerek okunur, böylece capture/release'in bakiye değişikliği ezilmez ve
+// eşzamanlı iki güncelleme aynı sıra numarasını yayınlamaz. mutate değişen kolonları döner; boşsa
+// hiçbir şey yazılmaz.
+func (s *CardService) update(ctx context.Context, id uint, eventType string, mutate func(card *model.Card) (map[string]interface{}, func(*events.CardEvent))) error {
+	return s.repo.Transaction(ctx, func(tx *repository.CardRepository, outbox kafka.Publisher) error {
+		card, err := tx.GetForUpdate(id)
+		if err != nil {
+			return fmt.Errorf("card not found: %v", err)
+		}
+
+		fields, fill := mutate(card)
+		if len(fields) == 0 {
+			return nil
+		}
+		fields["sequence"] = card.Sequence + 1
+		if err := tx.UpdateFields(card.ID, fields); err != nil {
+			return fmt.Errorf("failed to update card: %v", err)
+		}
+
+		// Olaylar yazılan satırdan üretilir
+		if card, err = tx.GetByID(id); err != nil {
+			return err
+		}
+		return publishChange(ctx, outbox, card, eventType, fill)
+	})
+}
+
+func publishChange(ctx context.Context, outbox kafka.Publisher, card *model.Card, eventType string, fill func(*events.CardEvent)) error {
+	event := cardEvent(card)
+	if fill != nil {
+		fill(event)
+	}
+	if err := outbox.Publish(ctx, kafka.CardsTopic, eventType, event,
+		kafka.WithKey(strconv.Itoa(int(card.ID))),
+		kafka.WithSequence(card.Sequence)); err != nil {
+		return err
+	}
+
+	if eventType == kafka.EventCardRemoved {
+		return outbox.Send(ctx, kafka.NewTombstone(kafka.CardStateTopic, strconv.Itoa(int(card.ID))))
+	}
+	return publishState(ctx, outbox, card)
+}
+
+// Kartın tüm güncel durumu compacted cards.state topic'ine kart ID'siyle yazılır
+func publishState(ctx context.Context, outbox kafka.Publisher, card *model.Card) error {
+	return outbox.Publish(ctx, kafka.CardStateTopic, kafka.EventCardState, cardState(card), kafka.WithKey(strconv.Itoa(int(card.ID))))
+}
+
+func cardState(card *model.Card) *events.CardState {
+	return &events.CardState{
+		CardId:       uint32(card.ID),
+		CustomerId:   uint32(card.CustomerID),
+		MaskedNumber: MaskCardNumber(card.CardNumber),
+		CardType:     card.CardType,
+		CreditLimit:  card.CreditLimit,
+		Balance:      card.Balance,
+		IsActive:     card.IsActive,
+		Sequence:     card.Sequence,
+		UpdatedAt:    timestamppb.New(card.UpdatedAt),
+	}
+}
+
+// Olaylar kart numarasını sadece maskelenmiş olarak taşır, PAN servis dışına çıkmaz
+func cardEvent(card *model.Card) *events.CardEvent {
+	return &events.CardEvent{
+		CardId:       uint32(card.ID),
+		CustomerId:   uint32(card.CustomerID),
+		MaskedNumber: MaskCardNumber(card.CardNumber),
+		CardType:     card.CardType,
+		IsActive:     card.IsActive,
+		CreditLimit:  card.CreditLimit,
+	}
+}
+
+func MaskCardNumber(cardNumber string) string {
+	if len(cardNumber) < 4 {
+		return "****"
+	}
+	return "**** **** **** " + cardNumber[len(cardNumber)-4:]
 }
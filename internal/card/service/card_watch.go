@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"govo/api/proto/events"
+	"govo/kafka"
+)
+
+var (
+	ErrWatchUnavailable   = errors.New("card updates are not available")
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+)
+
+// Kart durumu değişikliği; Removed ise kart silinmiştir ve State sadece kart ID'sini taşır
+type CardUpdate struct {
+	State       *events.CardState
+	Removed     bool
+	ResumeToken string
+}
+
+// Kart izleme compacted cards.state topic'ini okuyan Feed üzerinden çalışır
+func (s *CardService) WithStateFeed(feed *kafka.Feed) *CardService {
+	s.stateFeed = feed
+	return s
+}
+
+// Token yoksa önce kartın veritabanındaki durumu, sonra değişiklikler gönderilir.
+// Aynı durum birden fazla gelebilir; istemci sequence ve updated_at ile ayırt eder.
+func (s *CardService) WatchCard(ctx context.Context, cardID uint, resumeToken string, send func(*CardUpdate) error) error {
+	if s.stateFeed == nil {
+		return ErrWatchUnavailable
+	}
+
+	var from kafka.Position
+	var last *events.CardState
+	if resumeToken != "" {
+		var err error
+		if from, err = kafka.ParsePosition(s.stateFeed.Topic(), resumeToken); err != nil {
+			return ErrInvalidResumeToken
+		}
+	} else {
+		// Konum veritabanı okumasından önce alınır, aradaki değişiklikler kaçmaz
+		var err error
+		if from, err = s.stateFeed.Position(ctx); err != nil {
+			return err
+		}
+		card, err := s.repo.GetByID(cardID)
+		if err != nil {
+			return err
+		}
+		last = cardState(card)
+		if err := send(&CardUpdate{State: last, ResumeToken: from.Token(s.stateFeed.Topic())}); err != nil {
+			return err
+		}
+	}
+
+	updates, err := s.stateFeed.Subscribe(ctx, from)
+	if err != nil {
+		return err
+	}
+	key := strconv.Itoa(int(cardID))
+	for ev := range updates {
+		if ev.Err != nil {
+			return ev.Err
+		}
+		if string(ev.Record.Key) != key {
+			continue
+		}
+		token := ev.Position.Token(s.stateFeed.Topic())
+
+		if ev.Record.IsTombstone() {
+			return send(&CardUpdate{State: &events.CardState{CardId: uint32(cardID)}, Removed: true, ResumeToken: token})
+		}
+
+		envelope, err := ev.Record.Envelope()
+		if err != nil || envelope == nil {
+			return fmt.Errorf("invalid card state record: %v", err)
+		}
+		state := &events.CardState{}
+		if err := envelope.GetPayload().UnmarshalTo(state); err != nil {
+			return fmt.Errorf("failed to decode card state: %v", err)
+		}
+		// Snapshot'tan önce yazılmış, geç gelen durum
+		if last != nil && (state.Sequence < last.Sequence || state.UpdatedAt.AsTime().Before(last.UpdatedAt.AsTime())) {
+			continue
+		}
+		last = state
+		if err := send(&CardUpdate{State: state, ResumeToken: token}); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"govo/internal/customer/model"
+	"govo/internal/customer/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BeneficiaryHandler struct {
+	service *service.BeneficiaryService
+}
+
+func NewBeneficiaryHandler(service *service.BeneficiaryService) *BeneficiaryHandler {
+	return &BeneficiaryHandler{service: service}
+}
+
+type CreateBeneficiaryRequest struct {
+	Nickname         string `json:"nickname" binding:"required"`
+	IBAN             string `json:"iban"`
+	TargetCustomerID uint   `json:"target_customer_id"`
+	DefaultReference string `json:"default_reference"`
+	RequireStepUp    bool   `json:"require_step_up"`
+}
+
+// Gönderilmeyen alanlar değişmez
+type UpdateBeneficiaryRequest struct {
+	Nickname         *string `json:"nickname"`
+	DefaultReference *string `json:"default_reference"`
+}
+
+type ConfirmBeneficiaryRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type BeneficiaryResponse struct {
+	model.Beneficiary
+	Status string `json:"status"`
+}
+
+func (h *BeneficiaryHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/api/customers/:id/beneficiaries", h.CreateBeneficiary)
+	router.GET("/api/customers/:id/beneficiaries", h.ListBeneficiaries)
+
+	beneficiaries := router.Group("/api/beneficiaries")
+	{
+		beneficiaries.GET("/:id", h.GetBeneficiary)
+		beneficiaries.PUT("/:id", h.UpdateBeneficiary)
+		beneficiaries.DELETE("/:id", h.DeleteBeneficiary)
+		beneficiaries.POST("/:id/confirm", h.ConfirmBeneficiary)
+		beneficiaries.POST("/:id/resend-code", h.ResendCode)
+	}
+}
+
+func (h *BeneficiaryHandler) CreateBeneficiary(c *gin.Context) {
+	customerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req CreateBeneficiaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	beneficiary := &model.Beneficiary{
+		CustomerID:       uint(customerID),
+		Nickname:         req.Nickname,
+		IBAN:             req.IBAN,
+		TargetCustomerID: req.TargetCustomerID,
+		DefaultReference: req.DefaultReference,
+		RequireStepUp:    req.RequireStepUp,
+	}
+	if err := h.service.CreateBeneficiary(c.Request.Context(), beneficiary); err != nil {
+		writeBeneficiaryError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBeneficiaryResponse(beneficiary))
+}
+
+func (h *BeneficiaryHandler) ListBeneficiaries(c *gin.Context) {
+	customerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	beneficiaries, err := h.service.ListBeneficiaries(c.Request.Context(), uint(customerID))
+	if err != nil {
+		writeBeneficiaryError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]BeneficiaryResponse, len(beneficiaries))
+	for i := range beneficiaries {
+		response[i] = toBeneficiaryResponse(&beneficiaries[i])
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *BeneficiaryHandler) GetBeneficiary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	beneficiary, err := h.service.GetBeneficiary(c.Request.Context(), uint(id))
+	if err != nil {
+		writeBeneficiaryError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBeneficiaryResponse(beneficiary))
+}
+
+func (h *BeneficiaryHandler) UpdateBeneficiary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req UpdateBeneficiaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	beneficiary, err := h.service.UpdateBeneficiary(c.Request.Context(), uint(id), req.Nickname, req.DefaultReference)
+	if err != nil {
+		writeBeneficiaryError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBeneficiaryResponse(beneficiary))
+}
+
+func (h *BeneficiaryHandler) DeleteBeneficiary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.DeleteBeneficiary(c.Request.Context(), uint(id)); err != nil {
+		writeBeneficiaryError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BeneficiaryHandler) ConfirmBeneficiary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var req ConfirmBeneficiaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	beneficiary, err := h.service.ConfirmBeneficiary(c.Request.Context(), uint(id), req.Code)
+	if err != nil {
+		writeBeneficiaryError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBeneficiaryResponse(beneficiary))
+}
+
+func (h *BeneficiaryHandler) ResendCode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	if err := h.service.ResendStepUpCode(c.Request.Context(), uint(id)); err != nil {
+		writeBeneficiaryError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// Başka müşterinin kaydı bulunamadı olarak döner
+func writeBeneficiaryError(c *gin.Context, err error, fallback int) {
+	code := fallback
+	switch {
+	case errors.Is(err, service.ErrBeneficiaryNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, service.ErrCustomerMismatch), errors.Is(err, service.ErrInvalidStepUpCode):
+		code = http.StatusForbidden
+	case errors.Is(err, service.ErrBeneficiaryLocked):
+		code = http.StatusLocked
+	case errors.Is(err, service.ErrResendTooSoon):
+		code = http.StatusTooManyRequests
+	}
+	c.JSON(code, gin.H{"error": err.Error()})
+}
+
+func toBeneficiaryResponse(b *model.Beneficiary) BeneficiaryResponse {
+	return BeneficiaryResponse{
+		Beneficiary: *b,
+		Status:      b.Status(time.Now()),
+	}
+}
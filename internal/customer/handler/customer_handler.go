@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -26,6 +27,7 @@ func (h *CustomerHandler) RegisterRoutes(router *gin.Engine) {
 		customers.GET("/:id", h.GetCustomer)
 		customers.PUT("/:id", h.UpdateCustomer)
 		customers.DELETE("/:id", h.DeleteCustomer)
+		customers.GET("/:id/history", h.GetCustomerHistory)
 	}
 }
 
@@ -36,7 +38,7 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.CreateCustomer(&customer); err != nil {
+	if err := h.service.CreateCustomer(c.Request.Context(), &customer); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -74,7 +76,7 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 	}
 
 	customer.ID = uint(id)
-	if err := h.service.UpdateCustomer(&customer); err != nil {
+	if err := h.service.UpdateCustomer(c.Request.Context(), &customer); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -89,7 +91,7 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteCustomer(uint(id)); err != nil {
+	if err := h.service.DeleteCustomer(c.Request.Context(), uint(id)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -106,3 +108,26 @@ func (h *CustomerHandler) ListCustomers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, customers)
 }
+
+func (h *CustomerHandler) GetCustomerHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	history, err := h.service.GetCustomerHistory(c.Request.Context(), uint(id), page, pageSize)
+	if errors.Is(err, service.ErrCustomerMismatch) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"govo/internal/customer/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GDPRHandler struct {
+	service *service.GDPRService
+}
+
+func NewGDPRHandler(service *service.GDPRService) *GDPRHandler {
+	return &GDPRHandler{service: service}
+}
+
+func (h *GDPRHandler) RegisterRoutes(router *gin.Engine) {
+	customers := router.Group("/api/customers")
+	{
+		customers.GET("/:id/export", h.ExportCustomerData)
+		customers.POST("/:id/erasure", h.RequestErasure)
+		customers.GET("/:id/erasure", h.GetErasureReport)
+	}
+}
+
+func (h *GDPRHandler) ExportCustomerData(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	bundle, err := h.service.Export(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=customer-%d.json", id))
+		c.JSON(http.StatusOK, bundle)
+	case "zip":
+		var buf bytes.Buffer
+		if err := h.service.WriteZip(&buf, bundle); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=customer-%d.zip", id))
+		c.Data(http.StatusOK, "application/zip", buf.Bytes())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be json or zip"})
+	}
+}
+
+func (h *GDPRHandler) RequestErasure(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	request, err := h.service.RequestErasure(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, request)
+}
+
+func (h *GDPRHandler) GetErasureReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	requests, err := h.service.GetErasureReport(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
@@ -0,0 +1,234 @@
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Ülke kodlarına göre toplam IBAN uzunlukları
+var lengths = map[string]int{
+	"AT": 20, "BE": 16, "CH": 21, "CY": 28, "CZ": 24, "DE": 22, "DK": 18,
+	"EE": 20, "ES": 24, "FI": 18, "FR": 27, "GB": 22, "GR": 27, "HR": 21,
+	"HU": 28, "IE": 22, "IT": 27, "LT": 20, "LU": 20, "LV": 21, "MT": 31,
+	"NL": 18, "NO": 15, "PL": 28, "PT": 25, "RO": 24, "SE": 24, "SI": 19,
+	"SK": 24, "TR": 26,
+}
+
+var (
+	ErrInvalidLength    = errors.New("invalid IBAN length")
+	ErrInvalidCharacter = errors.New("invalid character in IBAN")
+	ErrInvalidChecksum  = errors.New("invalid IBAN check digits")
+	ErrUnknownCountry   = errors.New("unsupported IBAN country")
+)
+
+// IBAN üretilebilen ülkelerin BBAN yapısı. Banka kodu bankaya (ve varsa şubeye) ait sabit kısımdır,
+// hesap numarası verilen uzunluğa soldan sıfırla doldurulur; ulusal kontrol basamakları sona eklenir.
+type layout struct {
+	// Banka kodunun biçimi: n rakam, a büyük harf, c alfanümerik
+	bank string
+	// Banka kodu ile hesap numarası arasındaki sabit alan (TR'de rezerv alan)
+	reserved string
+	account  int
+	// Ulusal kontrol basamakları, hesap numarasından sonra
+	check func(bank, account string) string
+}
+
+var layouts = map[string]layout{
+	"AT": {bank: "nnnnn", account: 11},
+	"BE": {bank: "nnn", account: 7, check: belgianCheck},
+	"CH": {bank: "nnnnn", account: 12},
+	"DE": {bank: "nnnnnnnn", account: 10},
+	"FR": {bank: "nnnnnnnnnn", account: 11, check: ribKey},
+	"GB": {bank: "aaaannnnnn", account: 8},
+	"IE": {bank: "aaaannnnnn", account: 8},
+	"LU": {bank: "nnn", account: 13},
+	"NL": {bank: "aaaa", account: 10},
+	"TR": {bank: "nnnnn", reserved: "0", account: 16},
+}
+
+// Ülkede IBAN üretilebiliyorsa banka kodunu doğrular; servis açılırken kontrol edilir
+func CheckBankCode(countryCode, bankCode string) error {
+	countryCode = strings.ToUpper(countryCode)
+	l, ok := layouts[countryCode]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCountry, countryCode)
+	}
+	if len(bankCode) != len(l.bank) {
+		return fmt.Errorf("%w: %s bank code must be %d characters", ErrInvalidLength, countryCode, len(l.bank))
+	}
+	for i, r := range bankCode {
+		var ok bool
+		switch l.bank[i] {
+		case 'n':
+			ok = r >= '0' && r <= '9'
+		case 'a':
+			ok = r >= 'A' && r <= 'Z'
+		default:
+			ok = isAlphanumeric(string(r))
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s bank code must match %s", ErrInvalidCharacter, countryCode, l.bank)
+		}
+	}
+	return nil
+}
+
+// Sıra numarasını ülkenin hesap numarası uzunluğuna göre biçimlendirir
+func AccountNumber(countryCode string, n int64) (string, error) {
+	countryCode = strings.ToUpper(countryCode)
+	l, ok := layouts[countryCode]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCountry, countryCode)
+	}
+	number := fmt.Sprintf("%0*d", l.account, n)
+	if n < 0 || len(number) > l.account {
+		return "", fmt.Errorf("%w: account number %d does not fit %d digits", ErrInvalidLength, n, l.account)
+	}
+	return number, nil
+}
+
+// Banka kodu ve hesap numarasından geçerli kontrol basamaklarıyla IBAN üretir.
+// Hesap numarası ülkenin hesap numarası uzunluğuna kadar soldan sıfırla doldurulur.
+func Generate(countryCode, bankCode, accountNumber string) (string, error) {
+	countryCode = strings.ToUpper(countryCode)
+	bankCode = strings.ToUpper(bankCode)
+	accountNumber = strings.ToUpper(accountNumber)
+	if err := CheckBankCode(countryCode, bankCode); err != nil {
+		return "", err
+	}
+	l := layouts[countryCode]
+
+	if len(accountNumber) > l.account {
+		return "", fmt.Errorf("%w: account number exceeds %d characters", ErrInvalidLength, l.account)
+	}
+	if !isAlphanumeric(accountNumber) {
+		return "", ErrInvalidCharacter
+	}
+	accountNumber = strings.Repeat("0", l.account-len(accountNumber)) + accountNumber
+
+	bban := bankCode + l.reserved + accountNumber
+	if l.check != nil {
+		bban += l.check(bankCode, accountNumber)
+	}
+	if len(bban) != lengths[countryCode]-4 {
+		return "", fmt.Errorf("%w: BBAN for %s must be %d characters", ErrInvalidLength, countryCode, lengths[countryCode]-4)
+	}
+
+	check, err := checkDigits(countryCode, bban)
+	if err != nil {
+		return "", err
+	}
+	return countryCode + check + bban, nil
+}
+
+// Belçika: banka kodu ve hesap numarasının mod 97'si, 0 ise 97
+func belgianCheck(bank, account string) string {
+	n, _ := new(big.Int).SetString(bank+account, 10)
+	check := new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	if check == 0 {
+		check = 97
+	}
+	return fmt.Sprintf("%02d", check)
+}
+
+// Fransa: RIB anahtarı; hesap numarasındaki harfler A,J=1 B,K,S=2 ... I,R=9 olarak sayılır
+func ribKey(bank, account string) string {
+	var digits strings.Builder
+	for _, r := range account {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteByte("12345678912345678923456789"[r-'A'])
+			continue
+		}
+		digits.WriteRune(r)
+	}
+	b, _ := new(big.Int).SetString(bank[:5], 10)
+	g, _ := new(big.Int).SetString(bank[5:], 10)
+	c, _ := new(big.Int).SetString(digits.String(), 10)
+
+	sum := new(big.Int).Mul(b, big.NewInt(89))
+	sum.Add(sum, new(big.Int).Mul(g, big.NewInt(15)))
+	sum.Add(sum, new(big.Int).Mul(c, big.NewInt(3)))
+	return fmt.Sprintf("%02d", 97-new(big.Int).Mod(sum, big.NewInt(97)).Int64())
+}
+
+func Validate(value string) error {
+	iban := Normalize(value)
+	if len(iban) < 15 || len(iban) > 34 {
+		return ErrInvalidLength
+	}
+	if !isAlphanumeric(iban) {
+		return ErrInvalidCharacter
+	}
+
+	countryCode := iban[:2]
+	if length, ok := lengths[countryCode]; ok && len(iban) != length {
+		return ErrInvalidLength
+	}
+
+	remainder, err := mod97(iban[4:] + iban[:4])
+	if err != nil {
+		return err
+	}
+	if remainder != 1 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Boşlukları kaldırır ve büyük harfe çevirir
+func Normalize(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+}
+
+// IBAN'ı okunabilirlik için dörderli gruplara ayırır
+func Format(value string) string {
+	iban := Normalize(value)
+	var b strings.Builder
+	for i, r := range iban {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func checkDigits(countryCode, bban string) (string, error) {
+	remainder, err := mod97(bban + countryCode + "00")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%02d", 98-remainder), nil
+}
+
+// Harfler A=10 ... Z=35 olacak şekilde sayıya çevrilir ve mod 97 alınır
+func mod97(value string) (int64, error) {
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		default:
+			return 0, ErrInvalidCharacter
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return 0, ErrInvalidCharacter
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64(), nil
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if !(r >= '0' && r <= '9') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
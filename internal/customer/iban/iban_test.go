@@ -0,0 +1,120 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+)
+
+// Örnekler IBAN registry ve ulusal bankacılık kurumlarının yayınladığı IBAN'lardır
+func TestGeneratePublishedSamples(t *testing.T) {
+	tests := []struct {
+		country string
+		bank    string
+		account string
+		want    string
+	}{
+		{"AT", "19043", "00234573201", "AT611904300234573201"},
+		{"BE", "539", "0075470", "BE68539007547034"},
+		{"CH", "00762", "011623852957", "CH9300762011623852957"},
+		{"DE", "37040044", "0532013000", "DE89370400440532013000"},
+		{"FR", "2004101005", "0500013M026", "FR1420041010050500013M02606"},
+		{"GB", "NWBK601613", "31926819", "GB29NWBK60161331926819"},
+		{"IE", "AIBK931152", "12345678", "IE29AIBK93115212345678"},
+		{"LU", "001", "9400644750000", "LU280019400644750000"},
+		{"NL", "ABNA", "0417164300", "NL91ABNA0417164300"},
+		{"TR", "00061", "0519786457841326", "TR330006100519786457841326"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country, func(t *testing.T) {
+			got, err := Generate(tt.country, tt.bank, tt.account)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Generate = %s, want %s", got, tt.want)
+			}
+			if err := Validate(got); err != nil {
+				t.Errorf("Validate(%s): %v", got, err)
+			}
+		})
+	}
+}
+
+// Sıra numarasından üretilen IBAN her ülkede doğru uzunlukta ve geçerli olmalı
+func TestGenerateFromSequence(t *testing.T) {
+	for country := range layouts {
+		bank := map[byte]string{'n': "1", 'a': "B", 'c': "C"}
+		var code string
+		for i := 0; i < len(layouts[country].bank); i++ {
+			code += bank[layouts[country].bank[i]]
+		}
+		if err := CheckBankCode(country, code); err != nil {
+			t.Fatalf("%s: CheckBankCode(%s): %v", country, code, err)
+		}
+
+		number, err := AccountNumber(country, 42)
+		if err != nil {
+			t.Fatalf("%s: AccountNumber: %v", country, err)
+		}
+		got, err := Generate(country, code, number)
+		if err != nil {
+			t.Fatalf("%s: Generate: %v", country, err)
+		}
+		if len(got) != lengths[country] {
+			t.Errorf("%s: length %d, want %d", country, len(got), lengths[country])
+		}
+		if err := Validate(got); err != nil {
+			t.Errorf("%s: Validate(%s): %v", country, got, err)
+		}
+	}
+}
+
+func TestCheckBankCode(t *testing.T) {
+	tests := []struct {
+		country string
+		bank    string
+		want    error
+	}{
+		{"TR", "00100", nil},
+		{"TR", "0010", ErrInvalidLength},
+		{"DE", "00100", ErrInvalidLength},
+		{"GB", "NWBK60161", ErrInvalidLength},
+		{"GB", "1234601613", ErrInvalidCharacter},
+		{"NL", "AB1A", ErrInvalidCharacter},
+		{"ES", "21000418", ErrUnknownCountry},
+		{"XX", "00100", ErrUnknownCountry},
+	}
+	for _, tt := range tests {
+		err := CheckBankCode(tt.country, tt.bank)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("CheckBankCode(%s, %s) = %v, want %v", tt.country, tt.bank, err, tt.want)
+		}
+	}
+}
+
+func TestAccountNumberOverflow(t *testing.T) {
+	if _, err := AccountNumber("BE", 10_000_000); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("AccountNumber overflow = %v, want ErrInvalidLength", err)
+	}
+	if got, err := AccountNumber("DE", 7); err != nil || got != "0000000007" {
+		t.Errorf("AccountNumber(DE, 7) = %s, %v", got, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		iban string
+		want error
+	}{
+		{"DE89 3704 0044 0532 0130 00", nil},
+		{"gb29nwbk60161331926819", nil},
+		{"DE88370400440532013000", ErrInvalidChecksum},
+		{"DE8937040044053201300", ErrInvalidLength},
+		{"DE89-3704-0044-0532-0130-00", ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.iban); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%s) = %v, want %v", tt.iban, err, tt.want)
+		}
+	}
+}
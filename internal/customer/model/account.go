@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AccountTypeChecking = "CHECKING"
+	AccountTypeSavings  = "SAVINGS"
+	AccountTypeJoint    = "JOINT"
+
+	AccountStatusActive = "ACTIVE"
+	AccountStatusFrozen = "FROZEN"
+	AccountStatusClosed = "CLOSED"
+
+	HolderRoleOwner = "OWNER"
+	HolderRoleJoint = "JOINT"
+)
+
+type Account struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	CustomerID    uint            `gorm:"not null;index" json:"customer_id"`    // Owner of the account
+	AccountType   string          `gorm:"size:10;not null" json:"account_type"` // "CHECKING", "SAVINGS", "JOINT"
+	AccountNumber string          `gorm:"size:16;not null;uniqueIndex" json:"account_number"`
+	IBAN          string          `gorm:"column:iban;size:34;not null;uniqueIndex" json:"iban"`
+	Currency      string          `gorm:"size:3;not null;default:TRY" json:"currency"`
+	Balance       float64         `gorm:"type:decimal(15,2);not null;default:0" json:"balance"`
+	Status        string          `gorm:"size:10;not null" json:"status"` // "ACTIVE", "FROZEN", "CLOSED"
+	Holders       []AccountHolder `gorm:"foreignKey:AccountID" json:"holders"`
+}
+
+// Joint accounts can have holders other than the owner
+type AccountHolder struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	AccountID  uint   `gorm:"not null;uniqueIndex:idx_account_holder" json:"account_id"`
+	CustomerID uint   `gorm:"not null;uniqueIndex:idx_account_holder;index" json:"customer_id"`
+	Role       string `gorm:"size:10;not null" json:"role"` // "OWNER", "JOINT"
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	BeneficiaryStatusPendingConfirmation = "PENDING_CONFIRMATION"
+	BeneficiaryStatusCoolingOff          = "COOLING_OFF"
+	BeneficiaryStatusActive              = "ACTIVE"
+	BeneficiaryStatusLocked              = "LOCKED"
+)
+
+// Saved payee of a customer, either an external IBAN or another customer of the bank
+type Beneficiary struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	CustomerID       uint   `gorm:"not null;index" json:"customer_id"`
+	Nickname         string `gorm:"size:100;not null" json:"nickname"`
+	IBAN             string `gorm:"column:iban;size:34" json:"iban,omitempty"`
+	TargetCustomerID uint   `json:"target_customer_id,omitempty"` // Internal payee, alternative to IBAN
+	DefaultReference string `gorm:"size:140" json:"default_reference"`
+
+	RequireStepUp       bool       `gorm:"not null;default:false" json:"require_step_up"`
+	StepUpCodeHash      string     `gorm:"size:64" json:"-"`
+	StepUpCodeExpiresAt *time.Time `json:"-"`
+	StepUpCodeSentAt    *time.Time `json:"-"`
+	StepUpResends       int        `gorm:"not null;default:0" json:"-"`
+	StepUpAttempts      int        `gorm:"not null;default:0" json:"-"` // Wrong codes entered so far
+	LockedAt            *time.Time `json:"locked_at,omitempty"`         // Too many wrong codes, must be added again
+	ConfirmedAt         *time.Time `json:"confirmed_at,omitempty"`
+	AvailableAt         time.Time  `gorm:"not null" json:"available_at"` // End of the cooling-off period
+}
+
+func (b *Beneficiary) Status(now time.Time) string {
+	if b.LockedAt != nil {
+		return BeneficiaryStatusLocked
+	}
+	if b.RequireStepUp && b.ConfirmedAt == nil {
+		return BeneficiaryStatusPendingConfirmation
+	}
+	if now.Before(b.AvailableAt) {
+		return BeneficiaryStatusCoolingOff
+	}
+	return BeneficiaryStatusActive
+}
@@ -18,6 +18,9 @@ type Customer struct {
 	Phone     string `gorm:"size:20" json:"phone"`
 	Address   string `gorm:"size:255" json:"address"`
 
-	Balance float64  `gorm:"type:decimal(10,2);default:0" json:"balance"`
-	Cards   []string `gorm:"type:text[]" json:"cards"` // Array of card numbers
+	Balance float64  `gorm:"type:decimal(10,2);default:0" json:"balance"` // Deprecated: balances are kept per Account
+	Cards   []string `gorm:"-" json:"cards"`                              // Masked numbers of active cards, filled from the customer_cards read model
+
+	// Incremented on every change and carried in the customer events as the aggregate sequence
+	Sequence uint64 `gorm:"not null;default:0" json:"sequence"`
 }
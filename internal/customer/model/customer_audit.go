@@ -0,0 +1,33 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+	AuditActionErase  = "ERASE"
+)
+
+// Append-only history of changes made to a customer
+type CustomerAudit struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	CustomerID uint            `gorm:"not null;index" json:"customer_id"`
+	Action     string          `gorm:"size:10;not null" json:"action"` // "CREATE", "UPDATE", "DELETE", "ERASE"
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before,omitempty"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after,omitempty"`
+	Changes    json.RawMessage `gorm:"type:jsonb" json:"changes,omitempty"` // Field name -> {"from", "to"}
+	Actor      string          `gorm:"size:100" json:"actor"`
+	Source     string          `gorm:"size:10" json:"source"` // "grpc", "rest", "admin"
+	RequestID  string          `gorm:"size:64;index" json:"request_id"`
+}
+
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Kart servisindeki kartların müşteri tarafındaki okuma modeli, PAN tutulmaz
+type CustomerCard struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CustomerID   uint   `gorm:"not null;index" json:"customer_id"`
+	CardID       uint   `gorm:"not null;uniqueIndex" json:"card_id"`
+	MaskedNumber string `gorm:"size:19;not null" json:"masked_number"`
+	CardType     string `gorm:"size:20" json:"card_type"`
+	IsActive     bool   `gorm:"not null;default:true" json:"is_active"`
+}
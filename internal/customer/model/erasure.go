@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	ErasureStatusInProgress = "IN_PROGRESS"
+	ErasureStatusCompleted  = "COMPLETED"
+	ErasureStatusFailed     = "FAILED"
+)
+
+// Müşteri verisinin silinmesi talebi, her servis kendi adımını raporlar
+type ErasureRequest struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CustomerID  uint          `gorm:"not null;index" json:"customer_id"`
+	Status      string        `gorm:"size:20;not null" json:"status"` // "IN_PROGRESS", "COMPLETED", "FAILED"
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Steps       []ErasureStep `gorm:"foreignKey:ErasureID" json:"steps"`
+}
+
+type ErasureStep struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ErasureID         uint       `gorm:"not null;uniqueIndex:idx_erasure_service" json:"erasure_id"`
+	Service           string     `gorm:"size:20;not null;uniqueIndex:idx_erasure_service" json:"service"` // "customer", "card", "payment"
+	Status            string     `gorm:"size:20;not null" json:"status"`
+	AnonymizedRecords int64      `json:"anonymized_records"`
+	RetainedRecords   int64      `json:"retained_records"` // Yasal saklama yükümlülüğü nedeniyle tutulan finansal kayıtlar
+	Detail            string     `json:"detail"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+}
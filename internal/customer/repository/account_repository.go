@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"govo/internal/customer/model"
+	"govo/internal/inbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// Hesap numaraları veritabanı sequence'ından alınır, ülkeye göre biçimlendirme servistedir
+func (r *AccountRepository) NextAccountNumber() (int64, error) {
+	var next int64
+	if err := r.db.Raw("SELECT nextval('account_number_seq')").Scan(&next).Error; err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+func (r *AccountRepository) Create(account *model.Account) error {
+	return r.db.Create(account).Error
+}
+
+func (r *AccountRepository) GetByID(id uint) (*model.Account, error) {
+	var account model.Account
+	if err := r.db.Preload("Holders").First(&account, id).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *AccountRepository) GetByIBAN(iban string) (*model.Account, error) {
+	var account model.Account
+	if err := r.db.Preload("Holders").Where("iban = ?", iban).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Müşterinin sahibi olduğu veya ortak olarak yetkili olduğu hesaplar
+func (r *AccountRepository) ListByCustomerID(customerID uint) ([]model.Account, error) {
+	var accounts []model.Account
+	err := r.db.Preload("Holders").
+		Where("id IN (?)", r.db.Model(&model.AccountHolder{}).Select("account_id").Where("customer_id = ?", customerID)).
+		Order("id").
+		Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *AccountRepository) UpdateStatus(id uint, status string) error {
+	result := r.db.Model(&model.Account{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Bakiyeyi satır kilidi altında günceller, eksi bakiyeye izin verilmez
+// Aynı olay için ikinci çağrı bakiyeyi değiştirmez, inbox.ErrDuplicate döner
+func (r *AccountRepository) AdjustBalance(id uint, delta float64, key inbox.Key) (*model.Account, error) {
+	var account model.Account
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, id).Error; err != nil {
+			return err
+		}
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+		if account.Status != model.AccountStatusActive {
+			return fmt.Errorf("account %d is %s", id, account.Status)
+		}
+		if account.Balance+delta < 0 {
+			return ErrInsufficientFunds
+		}
+
+		account.Balance += delta
+		return tx.Model(&account).Update("balance", account.Balance).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// original ile yapılan işlemi geri alır. original hiç uygulanmadıysa şimdi işlenmiş sayılır
+// (geç gelen tekrarı uygulanmaz) ve bakiye değişmez. Inbox kayıtları temizlenene kadar güvenlidir.
+func (r *AccountRepository) ReverseBalance(id uint, delta float64, key, original inbox.Key) (*model.Account, bool, error) {
+	var account model.Account
+	reversed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, id).Error; err != nil {
+			return err
+		}
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+
+		err := inbox.Claim(tx, original)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, inbox.ErrDuplicate) {
+			return err
+		}
+
+		reversed = true
+		account.Balance += delta
+		return tx.Model(&account).Update("balance", account.Balance).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &account, reversed, nil
+}
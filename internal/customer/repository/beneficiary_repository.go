@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"govo/internal/customer/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BeneficiaryRepository struct {
+	db *gorm.DB
+}
+
+func NewBeneficiaryRepository(db *gorm.DB) *BeneficiaryRepository {
+	return &BeneficiaryRepository{db: db}
+}
+
+func (r *BeneficiaryRepository) Create(beneficiary *model.Beneficiary) error {
+	return r.db.Create(beneficiary).Error
+}
+
+func (r *BeneficiaryRepository) GetByID(id uint) (*model.Beneficiary, error) {
+	var beneficiary model.Beneficiary
+	if err := r.db.First(&beneficiary, id).Error; err != nil {
+		return nil, err
+	}
+	return &beneficiary, nil
+}
+
+// owner 0 değilse kayıt o müşteriye ait olmalı, değilse bulunamadı döner
+func (r *BeneficiaryRepository) GetOwned(id, owner uint) (*model.Beneficiary, error) {
+	var beneficiary model.Beneficiary
+	if err := ownedBy(r.db, owner).First(&beneficiary, id).Error; err != nil {
+		return nil, err
+	}
+	return &beneficiary, nil
+}
+
+func (r *BeneficiaryRepository) ListByCustomerID(customerID uint) ([]model.Beneficiary, error) {
+	var beneficiaries []model.Beneficiary
+	err := r.db.Where("customer_id = ?", customerID).Order("nickname").Find(&beneficiaries).Error
+	return beneficiaries, err
+}
+
+// Kayıt commit'e kadar kilitlenir; fn yazılacak kolonları döner. fn hata dönse de kolonlar yazılır,
+// böylece yanlış kod denemesi hatayla birlikte sayılır.
+func (r *BeneficiaryRepository) Modify(id, owner uint, fn func(b *model.Beneficiary) (map[string]interface{}, error)) (*model.Beneficiary, error) {
+	var beneficiary model.Beneficiary
+	var result error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := ownedBy(tx, owner).Clauses(clause.Locking{Strength: "UPDATE"}).First(&beneficiary, id).Error; err != nil {
+			return err
+		}
+
+		var fields map[string]interface{}
+		fields, result = fn(&beneficiary)
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := tx.Model(&model.Beneficiary{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+			return err
+		}
+		return tx.First(&beneficiary, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &beneficiary, result
+}
+
+func (r *BeneficiaryRepository) Delete(id, owner uint) error {
+	result := ownedBy(r.db, owner).Delete(&model.Beneficiary{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func ownedBy(db *gorm.DB, owner uint) *gorm.DB {
+	if owner == 0 {
+		return db
+	}
+	return db.Where("customer_id = ?", owner)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"govo/internal/customer/model"
+	"govo/internal/requestctx"
+
+	"gorm.io/gorm"
+)
+
+type CustomerAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerAuditRepository(db *gorm.DB) *CustomerAuditRepository {
+	return &CustomerAuditRepository{db: db}
+}
+
+func (r *CustomerAuditRepository) ListByCustomerID(customerID uint, page, pageSize int) ([]model.CustomerAudit, int64, error) {
+	var total int64
+	query := r.db.Model(&model.CustomerAudit{}).Where("customer_id = ?", customerID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []model.CustomerAudit
+	err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// Denetlenen müşteri alanları, okuma modeli ve zaman damgaları dahil edilmez
+type customerSnapshot struct {
+	FirstName string  `json:"first_name"`
+	LastName  string  `json:"last_name"`
+	Email     string  `json:"email"`
+	Phone     string  `json:"phone"`
+	Address   string  `json:"address"`
+	Balance   float64 `json:"balance"`
+}
+
+func snapshotOf(c *model.Customer) map[string]interface{} {
+	if c == nil {
+		return nil
+	}
+
+	data, _ := json.Marshal(customerSnapshot{
+		FirstName: c.FirstName,
+		LastName:  c.LastName,
+		Email:     c.Email,
+		Phone:     c.Phone,
+		Address:   c.Address,
+		Balance:   c.Balance,
+	})
+	var snapshot map[string]interface{}
+	json.Unmarshal(data, &snapshot)
+	return snapshot
+}
+
+func diffSnapshots(before, after map[string]interface{}) map[string]model.FieldChange {
+	changes := make(map[string]model.FieldChange)
+	for field, to := range after {
+		from := before[field]
+		if !reflect.DeepEqual(from, to) {
+			changes[field] = model.FieldChange{From: from, To: to}
+		}
+	}
+	for field, from := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = model.FieldChange{From: from, To: nil}
+		}
+	}
+	return changes
+}
+
+// Denetim kaydı değişiklikle aynı transaction içinde yazılır
+func writeAudit(ctx context.Context, tx *gorm.DB, customerID uint, action string, before, after *model.Customer) error {
+	md := requestctx.FromContext(ctx)
+	beforeSnapshot := snapshotOf(before)
+	afterSnapshot := snapshotOf(after)
+
+	entry := &model.CustomerAudit{
+		CustomerID: customerID,
+		Action:     action,
+		Actor:      md.Actor,
+		Source:     md.Source,
+		RequestID:  md.RequestID,
+	}
+
+	var err error
+	if beforeSnapshot != nil {
+		if entry.Before, err = json.Marshal(beforeSnapshot); err != nil {
+			return err
+		}
+	}
+	if afterSnapshot != nil {
+		if entry.After, err = json.Marshal(afterSnapshot); err != nil {
+			return err
+		}
+	}
+	if entry.Changes, err = json.Marshal(diffSnapshots(beforeSnapshot, afterSnapshot)); err != nil {
+		return err
+	}
+
+	return tx.Create(entry).Error
+}
+
+// Silme talebinde geçmiş kayıtlardaki kişisel veriler temizlenir, sadece değişen alan adları kalır
+func scrubAudits(tx *gorm.DB, customerID uint) error {
+	var entries []model.CustomerAudit
+	if err := tx.Where("customer_id = ?", customerID).Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		var changes map[string]model.FieldChange
+		json.Unmarshal(entry.Changes, &changes)
+
+		fields := make(map[string]model.FieldChange, len(changes))
+		for field := range changes {
+			fields[field] = model.FieldChange{}
+		}
+		scrubbed, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.CustomerAudit{}).Where("id = ?", entry.ID).Updates(map[string]interface{}{
+			"before":  nil,
+			"after":   nil,
+			"changes": scrubbed,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
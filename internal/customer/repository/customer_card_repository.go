@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"govo/internal/customer/model"
+	"govo/internal/inbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerCardRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerCardRepository(db *gorm.DB) *CustomerCardRepository {
+	return &CustomerCardRepository{db: db}
+}
+
+func (r *CustomerCardRepository) Upsert(card *model.CustomerCard, key inbox.Key) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "card_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"customer_id", "masked_number", "card_type", "is_active", "updated_at"}),
+		}).Create(card).Error
+	})
+}
+
+func (r *CustomerCardRepository) DeleteByCardID(cardID uint, key inbox.Key) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+		return tx.Where("card_id = ?", cardID).Delete(&model.CustomerCard{}).Error
+	})
+}
+
+func (r *CustomerCardRepository) GetByCustomerID(customerID uint) ([]model.CustomerCard, error) {
+	var cards []model.CustomerCard
+	err := r.db.Where("customer_id = ?", customerID).Order("card_id").Find(&cards).Error
+	return cards, err
+}
+
+// Müşterinin okuma modelini verilen kart listesiyle tek transaction içinde değiştirir
+func (r *CustomerCardRepository) ReplaceForCustomer(customerID uint, cards []model.CustomerCard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("customer_id = ?", customerID).Delete(&model.CustomerCard{}).Error; err != nil {
+			return err
+		}
+		if len(cards) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "card_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"customer_id", "masked_number", "card_type", "is_active", "updated_at"}),
+		}).Create(&cards).Error
+	})
+}
@@ -1,9 +1,19 @@
 package repository
 
 import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"govo/api/proto/events"
 	"govo/internal/customer/model"
+	"govo/internal/outbox"
+	"govo/kafka"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CustomerRepository struct {
@@ -14,8 +24,17 @@ func NewCustomerRepository(db *gorm.DB) *CustomerRepository {
 	return &CustomerRepository{db: db}
 }
 
-func (r *CustomerRepository) Create(customer *model.Customer) error {
-	return r.db.Create(customer).Error
+func (r *CustomerRepository) Create(ctx context.Context, customer *model.Customer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		customer.Sequence = 1
+		if err := tx.Create(customer).Error; err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, tx, customer.ID, model.AuditActionCreate, nil, customer); err != nil {
+			return err
+		}
+		return writeEvent(ctx, tx, kafka.EventCustomerCreated, customer, nil)
+	})
 }
 
 func (r *CustomerRepository) GetByID(id uint) (*model.Customer, error) {
@@ -24,19 +43,151 @@ func (r *CustomerRepository) GetByID(id uint) (*model.Customer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := r.loadCards([]*model.Customer{&customer}); err != nil {
+		return nil, err
+	}
 	return &customer, nil
 }
 
-func (r *CustomerRepository) Update(customer *model.Customer) error {
-	return r.db.Save(customer).Error
+func (r *CustomerRepository) Update(ctx context.Context, customer *model.Customer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before model.Customer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, customer.ID).Error; err != nil {
+			return err
+		}
+
+		// Sadece değişen kişisel alanlar yazılır; Balance gibi diğer sütunlara dokunulmaz
+		after := before
+		after.FirstName = customer.FirstName
+		after.LastName = customer.LastName
+		after.Email = customer.Email
+		after.Phone = customer.Phone
+		after.Address = customer.Address
+		after.Sequence = before.Sequence + 1
+		after.UpdatedAt = time.Now()
+
+		changed := changedFields(&before, &after)
+		columns := append([]string{"sequence", "updated_at"}, changed...)
+		if err := tx.Model(&after).Select(columns).Updates(&after).Error; err != nil {
+			return err
+		}
+		*customer = after
+		if err := writeAudit(ctx, tx, customer.ID, model.AuditActionUpdate, &before, customer); err != nil {
+			return err
+		}
+		return writeEvent(ctx, tx, kafka.EventCustomerUpdated, customer, changed)
+	})
+}
+
+func (r *CustomerRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before model.Customer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, id).Error; err != nil {
+			return err
+		}
+		// Silinen kaydın sıra numarası da saklanır
+		before.Sequence++
+		if err := tx.Model(&before).UpdateColumn("sequence", before.Sequence).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.Customer{}, id).Error; err != nil {
+			return err
+		}
+		if err := writeAudit(ctx, tx, id, model.AuditActionDelete, &before, nil); err != nil {
+			return err
+		}
+		return writeEvent(ctx, tx, kafka.EventCustomerDeleted, &before, nil)
+	})
+}
+
+// Olay değişiklikle aynı transaction'da outbox'a yazılır; kişisel veri taşımaz.
+// Müşterinin güncel durumu da compacted customers.state topic'ine yazılır, silmede tombstone.
+// Durum kaydı da kişisel veri taşımaz, silinen müşterinin verisi compaction'ı beklemez.
+func writeEvent(ctx context.Context, tx *gorm.DB, eventType string, customer *model.Customer, changed []string) error {
+	event := &events.CustomerEvent{
+		CustomerId:    uint32(customer.ID),
+		ChangedFields: changed,
+	}
+	writer := outbox.NewWriter(tx)
+	if err := writer.Publish(ctx, kafka.CustomersTopic, eventType, event,
+		kafka.WithKey(strconv.Itoa(int(customer.ID))),
+		kafka.WithSequence(customer.Sequence)); err != nil {
+		return err
+	}
+
+	if eventType == kafka.EventCustomerDeleted {
+		return writeTombstone(ctx, tx, customer.ID)
+	}
+	state := &events.CustomerState{
+		CustomerId: uint32(customer.ID),
+		Sequence:   customer.Sequence,
+		CreatedAt:  timestamppb.New(customer.CreatedAt),
+		UpdatedAt:  timestamppb.New(customer.UpdatedAt),
+	}
+	return writer.Publish(ctx, kafka.CustomerStateTopic, kafka.EventCustomerState, state, kafka.WithKey(strconv.Itoa(int(customer.ID))))
 }
 
-func (r *CustomerRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Customer{}, id).Error
+func writeTombstone(ctx context.Context, tx *gorm.DB, customerID uint) error {
+	return outbox.NewWriter(tx).Send(ctx, kafka.NewTombstone(kafka.CustomerStateTopic, strconv.Itoa(int(customerID))))
+}
+
+// Sadece değişen alanların adları, değerleri olaya girmez
+func changedFields(before, after *model.Customer) []string {
+	changes := diffSnapshots(snapshotOf(before), snapshotOf(after))
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
 }
 
 func (r *CustomerRepository) List() ([]model.Customer, error) {
 	var customers []model.Customer
-	err := r.db.Find(&customers).Error
-	return customers, err
+	if err := r.db.Find(&customers).Error; err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*model.Customer, len(customers))
+	for i := range customers {
+		ptrs[i] = &customers[i]
+	}
+	if err := r.loadCards(ptrs); err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+func (r *CustomerRepository) ListIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.Customer{}).Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Cards alanı customer_cards okuma modelinden aktif kartlarla doldurulur
+func (r *CustomerRepository) loadCards(customers []*model.Customer) error {
+	if len(customers) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint]*model.Customer, len(customers))
+	ids := make([]uint, len(customers))
+	for i, c := range customers {
+		byID[c.ID] = c
+		ids[i] = c.ID
+		c.Cards = []string{}
+	}
+
+	var cards []model.CustomerCard
+	err := r.db.Where("customer_id IN ? AND is_active = ?", ids, true).Order("card_id").Find(&cards).Error
+	if err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		if c, ok := byID[card.CustomerID]; ok {
+			c.Cards = append(c.Cards, card.MaskedNumber)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"govo/internal/customer/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Güncelleme sadece değişen alanları yazar; kullanılmayan Balance sütununa dokunulmaz
+func TestUpdateWritesChangedColumns(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Now().Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "customers" WHERE "customers"."id" = $1 AND "customers"."deleted_at" IS NULL ORDER BY "customers"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(42, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "first_name", "last_name", "email", "phone", "address", "balance", "sequence"}).
+			AddRow(42, created, created, "Ada", "Lovelace", "ada@example.com", "555", "London", 12.5, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "customers" SET "updated_at"=$1,"email"=$2,"sequence"=$3 WHERE "customers"."deleted_at" IS NULL AND "id" = $4`)).
+		WithArgs(sqlmock.AnyArg(), "ada@example.org", 4, 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "customer_audits"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_messages"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_messages"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	customer := &model.Customer{ID: 42, FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.org", Phone: "555", Address: "London"}
+	if err := NewCustomerRepository(db).Update(context.Background(), customer); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if customer.Balance != 12.5 || customer.Sequence != 4 || !customer.CreatedAt.Equal(created) {
+		t.Errorf("updated customer = %+v", customer)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/customer/model"
+	"govo/internal/inbox"
+	"govo/internal/outbox"
+	"govo/internal/requestctx"
+	"govo/kafka"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ErasureRepository struct {
+	db *gorm.DB
+}
+
+func NewErasureRepository(db *gorm.DB) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// Müşteri PII alanlarını anonimleştirir, erasure talebini oluşturur ve diğer servislere gidecek silme
+// talebi olayını aynı transaction'da outbox'a yazar; olay commit'ten sonra relay tarafından tekrar denenerek gönderilir
+func (r *ErasureRepository) AnonymizeCustomer(ctx context.Context, customerID uint) (*model.ErasureRequest, error) {
+	now := time.Now()
+	request := &model.ErasureRequest{
+		CustomerID: customerID,
+		Status:     model.ErasureStatusInProgress,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var customer model.Customer
+		if err := tx.Unscoped().First(&customer, customerID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"first_name": "Erased",
+			"last_name":  "Customer",
+			"email":      fmt.Sprintf("erased-%d@erased.invalid", customerID),
+			"phone":      "",
+			"address":    "",
+		}
+		if err := tx.Unscoped().Model(&customer).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&customer).Error; err != nil {
+			return err
+		}
+		if err := scrubAudits(tx, customerID); err != nil {
+			return err
+		}
+		if err := writeErasureAudit(ctx, tx, customerID, updates); err != nil {
+			return err
+		}
+
+		cards := tx.Where("customer_id = ?", customerID).Delete(&model.CustomerCard{})
+		if cards.Error != nil {
+			return cards.Error
+		}
+
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+
+		step := model.ErasureStep{
+			ErasureID:         request.ID,
+			Service:           "customer",
+			Status:            model.ErasureStatusCompleted,
+			AnonymizedRecords: 1 + cards.RowsAffected,
+			CompletedAt:       &now,
+		}
+		if err := tx.Create(&step).Error; err != nil {
+			return err
+		}
+		request.Steps = []model.ErasureStep{step}
+
+		records, err := erasureRecords(ctx, request)
+		if err != nil {
+			return err
+		}
+		writer := outbox.NewWriter(tx)
+		for _, rec := range records {
+			if err := writer.Send(ctx, rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *ErasureRepository) GetByID(id uint) (*model.ErasureRequest, error) {
+	var request model.ErasureRequest
+	if err := r.db.Preload("Steps").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *ErasureRepository) ListByCustomerID(customerID uint) ([]model.ErasureRequest, error) {
+	var requests []model.ErasureRequest
+	err := r.db.Preload("Steps").Where("customer_id = ?", customerID).Order("id").Find(&requests).Error
+	return requests, err
+}
+
+// Servis adımını kaydeder; aynı servisten tekrar gelen rapor adımı günceller
+func (r *ErasureRepository) SaveStep(step *model.ErasureStep, key inbox.Key) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "erasure_id"}, {Name: "service"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "anonymized_records", "retained_records", "detail", "completed_at", "updated_at"}),
+		}).Create(step).Error
+	})
+}
+
+func (r *ErasureRepository) Update(request *model.ErasureRequest) error {
+	return r.db.Omit("Steps").Save(request).Error
+}
+
+// Durum kaydı tombstone ile kapatılır, eski kayıtlardaki kişisel veri compaction sonrası topic'ten kalkar
+// (süreler kafka.CustomerStateTopic'te). Silme talebi kart ve ödeme servislerine customers topic'inden gider.
+func erasureRecords(ctx context.Context, request *model.ErasureRequest) ([]*kafka.Record, error) {
+	key := strconv.Itoa(int(request.CustomerID))
+	requested, err := kafka.NewEventRecord(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureRequested, &events.CustomerErasureRequested{
+		ErasureId:   uint32(request.ID),
+		CustomerId:  uint32(request.CustomerID),
+		RequestedAt: timestamppb.New(request.CreatedAt),
+	}, kafka.WithKey(key))
+	if err != nil {
+		return nil, err
+	}
+	return []*kafka.Record{kafka.NewTombstone(kafka.CustomerStateTopic, key), requested}, nil
+}
+
+// Silme kaydında değerler değil sadece anonimleştirilen alan adları tutulur
+func writeErasureAudit(ctx context.Context, tx *gorm.DB, customerID uint, updates map[string]interface{}) error {
+	fields := make(map[string]model.FieldChange, len(updates))
+	for field := range updates {
+		fields[field] = model.FieldChange{}
+	}
+	changes, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	md := requestctx.FromContext(ctx)
+	return tx.Create(&model.CustomerAudit{
+		CustomerID: customerID,
+		Action:     model.AuditActionErase,
+		Changes:    changes,
+		Actor:      md.Actor,
+		Source:     md.Source,
+		RequestID:  md.RequestID,
+	}).Error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/customer/model"
+	"govo/kafka"
+)
+
+// Anonimleştirmeyle birlikte outbox'a hem durum kaydının tombstone'u hem de silme talebi yazılır
+func TestErasureRecords(t *testing.T) {
+	request := &model.ErasureRequest{ID: 3, CustomerID: 42, CreatedAt: time.Now()}
+	records, err := erasureRecords(context.Background(), request)
+	if err != nil {
+		t.Fatalf("erasureRecords: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	tombstone := records[0]
+	if tombstone.Topic != kafka.CustomerStateTopic || string(tombstone.Key) != "42" || !tombstone.IsTombstone() {
+		t.Errorf("state record = %s/%s (tombstone %v), want tombstone for customer 42", tombstone.Topic, tombstone.Key, tombstone.IsTombstone())
+	}
+
+	requested := records[1]
+	if requested.Topic != kafka.CustomersTopic || string(requested.Key) != "42" {
+		t.Errorf("erasure record = %s/%s, want %s/42", requested.Topic, requested.Key, kafka.CustomersTopic)
+	}
+	envelope, err := requested.Envelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envelope.GetType() != kafka.EventCustomerErasureRequested {
+		t.Fatalf("event type = %s", envelope.GetType())
+	}
+	payload, err := envelope.GetPayload().UnmarshalNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := payload.(*events.CustomerErasureRequested)
+	if event.ErasureId != 3 || event.CustomerId != 42 {
+		t.Errorf("event = %+v", event)
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"govo/internal/customer/iban"
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/inbox"
+)
+
+type IBANConfig struct {
+	CountryCode string
+	BankCode    string
+}
+
+// Servis açılırken çağrılır; ülke ve banka kodu hatalıysa hiçbir hesap açılamaz
+func (c IBANConfig) Validate() error {
+	return iban.CheckBankCode(c.CountryCode, c.BankCode)
+}
+
+type AccountService struct {
+	repo         *repository.AccountRepository
+	customerRepo *repository.CustomerRepository
+	ibanConfig   IBANConfig
+}
+
+func NewAccountService(repo *repository.AccountRepository, customerRepo *repository.CustomerRepository, ibanConfig IBANConfig) *AccountService {
+	return &AccountService{
+		repo:         repo,
+		customerRepo: customerRepo,
+		ibanConfig:   ibanConfig,
+	}
+}
+
+func (s *AccountService) CreateAccount(customerID uint, accountType, currency string, jointHolderIDs []uint) (*model.Account, error) {
+	if accountType != model.AccountTypeChecking && accountType != model.AccountTypeSavings && accountType != model.AccountTypeJoint {
+		return nil, errors.New("invalid account type")
+	}
+
+	// Ortak hesaplar en az bir ek hesap sahibi gerektirir
+	if accountType == model.AccountTypeJoint && len(jointHolderIDs) == 0 {
+		return nil, errors.New("joint accounts require at least one joint holder")
+	}
+	if accountType != model.AccountTypeJoint && len(jointHolderIDs) > 0 {
+		return nil, errors.New("only joint accounts can have joint holders")
+	}
+
+	if currency == "" {
+		currency = "TRY"
+	}
+	if len(currency) != 3 {
+		return nil, errors.New("currency must be a 3-letter ISO code")
+	}
+
+	if _, err := s.customerRepo.GetByID(customerID); err != nil {
+		return nil, fmt.Errorf("customer not found: %v", err)
+	}
+
+	holders := []model.AccountHolder{{CustomerID: customerID, Role: model.HolderRoleOwner}}
+	for _, holderID := range jointHolderIDs {
+		if holderID == customerID {
+			continue
+		}
+		if _, err := s.customerRepo.GetByID(holderID); err != nil {
+			return nil, fmt.Errorf("joint holder %d not found: %v", holderID, err)
+		}
+		holders = append(holders, model.AccountHolder{CustomerID: holderID, Role: model.HolderRoleJoint})
+	}
+
+	next, err := s.repo.NextAccountNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate account number: %v", err)
+	}
+	accountNumber, err := iban.AccountNumber(s.ibanConfig.CountryCode, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate account number: %v", err)
+	}
+
+	accountIBAN, err := iban.Generate(s.ibanConfig.CountryCode, s.ibanConfig.BankCode, accountNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate IBAN: %v", err)
+	}
+
+	account := &model.Account{
+		CustomerID:    customerID,
+		AccountType:   accountType,
+		AccountNumber: accountNumber,
+		IBAN:          accountIBAN,
+		Currency:      currency,
+		Status:        model.AccountStatusActive,
+		Holders:       holders,
+	}
+	if err := s.repo.Create(account); err != nil {
+		return nil, fmt.Errorf("failed to create account: %v", err)
+	}
+	return account, nil
+}
+
+func (s *AccountService) GetAccount(id uint) (*model.Account, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *AccountService) ListAccounts(customerID uint) ([]model.Account, error) {
+	return s.repo.ListByCustomerID(customerID)
+}
+
+func (s *AccountService) UpdateAccountStatus(id uint, status string) error {
+	if status != model.AccountStatusActive && status != model.AccountStatusFrozen {
+		return errors.New("status must be ACTIVE or FROZEN")
+	}
+
+	account, err := s.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("account not found: %v", err)
+	}
+	if account.Status == model.AccountStatusClosed {
+		return errors.New("closed accounts cannot be reopened")
+	}
+
+	return s.repo.UpdateStatus(id, status)
+}
+
+func (s *AccountService) CloseAccount(id uint) error {
+	account, err := s.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("account not found: %v", err)
+	}
+
+	// Bakiyesi olan hesap kapatılamaz
+	if account.Balance != 0 {
+		return errors.New("account balance must be zero before closing")
+	}
+
+	return s.repo.UpdateStatus(id, model.AccountStatusClosed)
+}
+
+func (s *AccountService) Debit(accountID uint, amount float64, key inbox.Key) (*model.Account, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	return s.adjustBalance(accountID, -amount, key)
+}
+
+func (s *AccountService) Credit(accountID uint, amount float64, key inbox.Key) (*model.Account, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	return s.adjustBalance(accountID, amount, key)
+}
+
+// Daha önce original anahtarıyla yapılan borçlandırmayı iade eder
+func (s *AccountService) Reverse(accountID uint, amount float64, key, original inbox.Key) (*model.Account, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	if key.IsZero() || original.IsZero() {
+		return nil, errors.New("reversal requires both event keys")
+	}
+
+	account, reversed, err := s.repo.ReverseBalance(accountID, amount, key, original)
+	if errors.Is(err, inbox.ErrDuplicate) {
+		log.Printf("Event %s from %s already applied to account %d", key.EventID, key.Consumer, accountID)
+		return s.repo.GetByID(accountID)
+	}
+	if err == nil && !reversed {
+		log.Printf("Event %s from %s was never applied to account %d, nothing to reverse", original.EventID, original.Consumer, accountID)
+	}
+	return account, err
+}
+
+func (s *AccountService) adjustBalance(accountID uint, delta float64, key inbox.Key) (*model.Account, error) {
+	account, err := s.repo.AdjustBalance(accountID, delta, key)
+	if errors.Is(err, inbox.ErrDuplicate) {
+		// Olay daha önce işlendi, güncel hesabı dön
+		log.Printf("Event %s from %s already applied to account %d", key.EventID, key.Consumer, accountID)
+		return s.repo.GetByID(accountID)
+	}
+	return account, err
+}
+
+func IsAccountHolder(account *model.Account, customerID uint) bool {
+	for _, h := range account.Holders {
+		if h.CustomerID == customerID {
+			return true
+		}
+	}
+	return false
+}
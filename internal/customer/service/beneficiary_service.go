@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/customer/iban"
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/requestctx"
+	"govo/kafka"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	stepUpCodeTTL = 10 * time.Minute
+	// Bu kadar yanlış koddan sonra alıcı kilitlenir, silinip yeniden eklenmesi gerekir
+	maxStepUpAttempts = 5
+	// Yeni kod en erken bu süre sonra ve en fazla bu kadar kez istenebilir
+	stepUpResendInterval = time.Minute
+	maxStepUpResends     = 5
+)
+
+var (
+	ErrBeneficiaryNotFound  = errors.New("beneficiary not found")
+	ErrBeneficiaryNotUsable = errors.New("beneficiary cannot be used yet")
+	ErrBeneficiaryLocked    = errors.New("beneficiary is locked after too many wrong codes")
+	ErrInvalidStepUpCode    = errors.New("invalid or expired confirmation code")
+	ErrResendTooSoon        = errors.New("a new confirmation code cannot be sent yet")
+	ErrCustomerMismatch     = errors.New("records belong to another customer")
+)
+
+type BeneficiaryService struct {
+	repo         *repository.BeneficiaryRepository
+	customerRepo *repository.CustomerRepository
+	publisher    kafka.Publisher
+	coolingOff   time.Duration
+	codeKey      [32]byte
+}
+
+// codeKey bildirim servisiyle paylaşılan anahtardır; onay kodu olaya bununla şifrelenerek yazılır
+func NewBeneficiaryService(repo *repository.BeneficiaryRepository, customerRepo *repository.CustomerRepository, publisher kafka.Publisher, coolingOff time.Duration, codeKey string) *BeneficiaryService {
+	return &BeneficiaryService{
+		repo:         repo,
+		customerRepo: customerRepo,
+		publisher:    publisher,
+		coolingOff:   coolingOff,
+		codeKey:      sha256.Sum256([]byte(codeKey)),
+	}
+}
+
+func (s *BeneficiaryService) CreateBeneficiary(ctx context.Context, beneficiary *model.Beneficiary) error {
+	if err := authorizeCustomer(ctx, beneficiary.CustomerID); err != nil {
+		return err
+	}
+	if beneficiary.Nickname == "" {
+		return errors.New("nickname is required")
+	}
+
+	// Alıcı ya IBAN ya da bankanın başka bir müşterisi olmalı
+	if (beneficiary.IBAN == "") == (beneficiary.TargetCustomerID == 0) {
+		return errors.New("exactly one of iban or target_customer_id is required")
+	}
+
+	if beneficiary.IBAN != "" {
+		beneficiary.IBAN = iban.Normalize(beneficiary.IBAN)
+		if err := iban.Validate(beneficiary.IBAN); err != nil {
+			return err
+		}
+	}
+
+	if beneficiary.TargetCustomerID != 0 {
+		if beneficiary.TargetCustomerID == beneficiary.CustomerID {
+			return errors.New("customer cannot add themselves as a beneficiary")
+		}
+		if _, err := s.customerRepo.GetByID(beneficiary.TargetCustomerID); err != nil {
+			return fmt.Errorf("target customer not found: %v", err)
+		}
+	}
+
+	if _, err := s.customerRepo.GetByID(beneficiary.CustomerID); err != nil {
+		return fmt.Errorf("customer not found: %v", err)
+	}
+
+	beneficiary.ID = 0
+	beneficiary.ConfirmedAt = nil
+	beneficiary.AvailableAt = time.Now().Add(s.coolingOff)
+
+	var code string
+	if beneficiary.RequireStepUp {
+		var err error
+		code, err = generateStepUpCode()
+		if err != nil {
+			return fmt.Errorf("failed to generate confirmation code: %v", err)
+		}
+		now := time.Now()
+		expiresAt := now.Add(stepUpCodeTTL)
+		beneficiary.StepUpCodeHash = hashStepUpCode(code)
+		beneficiary.StepUpCodeExpiresAt = &expiresAt
+		beneficiary.StepUpCodeSentAt = &now
+	}
+	beneficiary.StepUpResends = 0
+	beneficiary.StepUpAttempts = 0
+	beneficiary.LockedAt = nil
+
+	if err := s.repo.Create(beneficiary); err != nil {
+		return fmt.Errorf("failed to create beneficiary: %v", err)
+	}
+
+	if beneficiary.RequireStepUp {
+		s.sendStepUpCode(beneficiary, code)
+	}
+	return nil
+}
+
+func (s *BeneficiaryService) GetBeneficiary(ctx context.Context, id uint) (*model.Beneficiary, error) {
+	owner, ok := ownerScope(ctx)
+	if !ok {
+		return nil, ErrBeneficiaryNotFound
+	}
+	beneficiary, err := s.repo.GetOwned(id, owner)
+	if err != nil {
+		return nil, beneficiaryError(err)
+	}
+	return beneficiary, nil
+}
+
+func (s *BeneficiaryService) ListBeneficiaries(ctx context.Context, customerID uint) ([]model.Beneficiary, error) {
+	if err := authorizeCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCustomerID(customerID)
+}
+
+// Sadece takma ad ve varsayılan açıklama güncellenebilir, alıcı değişikliği yeni kayıt gerektirir.
+// nil alanlar olduğu gibi kalır.
+func (s *BeneficiaryService) UpdateBeneficiary(ctx context.Context, id uint, nickname, defaultReference *string) (*model.Beneficiary, error) {
+	if nickname != nil && *nickname == "" {
+		return nil, errors.New("nickname cannot be empty")
+	}
+	owner, ok := ownerScope(ctx)
+	if !ok {
+		return nil, ErrBeneficiaryNotFound
+	}
+
+	beneficiary, err := s.repo.Modify(id, owner, func(b *model.Beneficiary) (map[string]interface{}, error) {
+		fields := make(map[string]interface{})
+		if nickname != nil {
+			fields["nickname"] = *nickname
+		}
+		if defaultReference != nil {
+			fields["default_reference"] = *defaultReference
+		}
+		return fields, nil
+	})
+	if err != nil {
+		return nil, beneficiaryError(err)
+	}
+	return beneficiary, nil
+}
+
+func (s *BeneficiaryService) DeleteBeneficiary(ctx context.Context, id uint) error {
+	owner, ok := ownerScope(ctx)
+	if !ok {
+		return ErrBeneficiaryNotFound
+	}
+	return beneficiaryError(s.repo.Delete(id, owner))
+}
+
+// Her yanlış kod sayılır, maxStepUpAttempts'e ulaşınca alıcı kilitlenir ve kod geçersiz olur
+func (s *BeneficiaryService) ConfirmBeneficiary(ctx context.Context, id uint, code string) (*model.Beneficiary, error) {
+	owner, ok := ownerScope(ctx)
+	if !ok {
+		return nil, ErrBeneficiaryNotFound
+	}
+
+	beneficiary, err := s.repo.Modify(id, owner, func(b *model.Beneficiary) (map[string]interface{}, error) {
+		if b.LockedAt != nil {
+			return nil, ErrBeneficiaryLocked
+		}
+		if !b.RequireStepUp || b.ConfirmedAt != nil {
+			return nil, nil
+		}
+
+		now := time.Now()
+		if b.StepUpCodeExpiresAt == nil || now.After(*b.StepUpCodeExpiresAt) {
+			return nil, ErrInvalidStepUpCode
+		}
+		if subtle.ConstantTimeCompare([]byte(hashStepUpCode(code)), []byte(b.StepUpCodeHash)) != 1 {
+			attempts := b.StepUpAttempts + 1
+			if attempts < maxStepUpAttempts {
+				return map[string]interface{}{"step_up_attempts": attempts}, ErrInvalidStepUpCode
+			}
+			log.Printf("Beneficiary %d locked after %d wrong confirmation codes", b.ID, attempts)
+			return map[string]interface{}{
+				"step_up_attempts":        attempts,
+				"locked_at":               now,
+				"step_up_code_hash":       "",
+				"step_up_code_expires_at": nil,
+			}, ErrBeneficiaryLocked
+		}
+
+		return map[string]interface{}{
+			"confirmed_at":            now,
+			"step_up_attempts":        0,
+			"step_up_code_hash":       "",
+			"step_up_code_expires_at": nil,
+		}, nil
+	})
+	if err != nil {
+		return nil, beneficiaryError(err)
+	}
+	return beneficiary, nil
+}
+
+// Süresi dolan onay kodu yerine yenisini gönderir; iki gönderim arasında stepUpResendInterval beklenir
+func (s *BeneficiaryService) ResendStepUpCode(ctx context.Context, id uint) error {
+	owner, ok := ownerScope(ctx)
+	if !ok {
+		return ErrBeneficiaryNotFound
+	}
+
+	var code string
+	beneficiary, err := s.repo.Modify(id, owner, func(b *model.Beneficiary) (map[string]interface{}, error) {
+		if b.LockedAt != nil {
+			return nil, ErrBeneficiaryLocked
+		}
+		if !b.RequireStepUp || b.ConfirmedAt != nil {
+			return nil, errors.New("beneficiary does not need confirmation")
+		}
+
+		now := time.Now()
+		if b.StepUpResends >= maxStepUpResends ||
+			(b.StepUpCodeSentAt != nil && now.Before(b.StepUpCodeSentAt.Add(stepUpResendInterval))) {
+			return nil, ErrResendTooSoon
+		}
+
+		var err error
+		code, err = generateStepUpCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate confirmation code: %v", err)
+		}
+		return map[string]interface{}{
+			"step_up_code_hash":       hashStepUpCode(code),
+			"step_up_code_expires_at": now.Add(stepUpCodeTTL),
+			"step_up_code_sent_at":    now,
+			"step_up_resends":         b.StepUpResends + 1,
+		}, nil
+	})
+	if err != nil {
+		return beneficiaryError(err)
+	}
+
+	s.sendStepUpCode(beneficiary, code)
+	return nil
+}
+
+// Ödemede kullanılmadan önce sahiplik, onay ve bekleme süresi kontrol edilir
+func (s *BeneficiaryService) ResolveForPayment(id, customerID uint) (*model.Beneficiary, error) {
+	beneficiary, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, beneficiaryError(err)
+	}
+	if beneficiary.CustomerID != customerID {
+		return nil, fmt.Errorf("beneficiary %d does not belong to customer %d", id, customerID)
+	}
+	if status := beneficiary.Status(time.Now()); status != model.BeneficiaryStatusActive {
+		return nil, fmt.Errorf("%w: %s", ErrBeneficiaryNotUsable, status)
+	}
+	return beneficiary, nil
+}
+
+// Kod bildirim servisi tarafından müşteriye SMS ile iletilir; topic'e açık metin yazılmaz
+func (s *BeneficiaryService) sendStepUpCode(beneficiary *model.Beneficiary, code string) {
+	sealed, err := sealStepUpCode(s.codeKey[:], beneficiary.ID, code)
+	if err != nil {
+		log.Printf("Failed to encrypt step-up code for beneficiary %d: %v", beneficiary.ID, err)
+		return
+	}
+	event := &events.StepUpCodeIssued{
+		CustomerId:    uint32(beneficiary.CustomerID),
+		BeneficiaryId: uint32(beneficiary.ID),
+		EncryptedCode: sealed,
+	}
+	if beneficiary.StepUpCodeExpiresAt != nil {
+		event.ExpiresAt = timestamppb.New(*beneficiary.StepUpCodeExpiresAt)
+	}
+
+	if err := s.publisher.Publish(context.Background(), kafka.NotificationsTopic, kafka.EventStepUpCodeIssued, event, kafka.WithKey(strconv.Itoa(int(beneficiary.CustomerID)))); err != nil {
+		log.Printf("Failed to send step-up code for beneficiary %d: %v", beneficiary.ID, err)
+	}
+}
+
+func generateStepUpCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// AES-256-GCM; nonce başa eklenir, alıcı numarası ek veri olarak bağlanır
+func sealStepUpCode(key []byte, beneficiaryID uint, code string) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, []byte(code), []byte(strconv.FormatUint(uint64(beneficiaryID), 10))), nil
+}
+
+// Yöneticiler tüm kayıtlara erişir (0); müşteriler yalnızca kendi kayıtlarına
+func ownerScope(ctx context.Context) (uint, bool) {
+	md := requestctx.FromContext(ctx)
+	if md.IsAdmin() {
+		return 0, true
+	}
+	return md.CustomerID, md.CustomerID != 0
+}
+
+func authorizeCustomer(ctx context.Context, customerID uint) error {
+	owner, ok := ownerScope(ctx)
+	if !ok || (owner != 0 && owner != customerID) {
+		return ErrCustomerMismatch
+	}
+	return nil
+}
+
+func beneficiaryError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBeneficiaryNotFound
+	}
+	return err
+}
+
+func hashStepUpCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"govo/internal/requestctx"
+)
+
+// Bildirim servisinin yaptığı gibi çözer
+func openStepUpCode(t *testing.T, secret string, beneficiaryID string, sealed []byte) ([]byte, error) {
+	t.Helper()
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, []byte(beneficiaryID))
+}
+
+func TestSealStepUpCode(t *testing.T) {
+	s := NewBeneficiaryService(nil, nil, nil, 0, "notification-key")
+	sealed, err := sealStepUpCode(s.codeKey[:], 42, "123456")
+	if err != nil {
+		t.Fatalf("sealStepUpCode: %v", err)
+	}
+	if bytes.Contains(sealed, []byte("123456")) {
+		t.Fatal("sealed code contains the plaintext")
+	}
+
+	code, err := openStepUpCode(t, "notification-key", "42", sealed)
+	if err != nil || string(code) != "123456" {
+		t.Fatalf("open = %q, %v", code, err)
+	}
+	if _, err := openStepUpCode(t, "notification-key", "43", sealed); err == nil {
+		t.Error("code opened for another beneficiary")
+	}
+	if _, err := openStepUpCode(t, "other-key", "42", sealed); err == nil {
+		t.Error("code opened with another key")
+	}
+}
+
+func TestOwnerScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       requestctx.Metadata
+		customer uint
+		want     error
+	}{
+		{"own customer", requestctx.Metadata{Actor: "user-7", CustomerID: 7, Role: requestctx.RoleCustomer}, 7, nil},
+		{"other customer", requestctx.Metadata{Actor: "user-7", CustomerID: 7, Role: requestctx.RoleCustomer}, 8, ErrCustomerMismatch},
+		{"admin", requestctx.Metadata{Actor: "support-1", Role: requestctx.RoleAdmin}, 8, nil},
+		{"anonymous", requestctx.Metadata{}, 7, ErrCustomerMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := requestctx.WithMetadata(context.Background(), tt.md)
+			if err := authorizeCustomer(ctx, tt.customer); !errors.Is(err, tt.want) {
+				t.Errorf("authorizeCustomer = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Kimliksiz çağrı hiçbir kayda erişemez
+	if _, ok := ownerScope(context.Background()); ok {
+		t.Error("anonymous caller got a scope")
+	}
+}
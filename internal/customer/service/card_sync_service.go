@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cardpb "govo/api/proto/card"
+	"govo/api/proto/events"
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/inbox"
+)
+
+type CardSyncService struct {
+	repo *repository.CustomerCardRepository
+}
+
+func NewCardSyncService(repo *repository.CustomerCardRepository) *CardSyncService {
+	return &CardSyncService{repo: repo}
+}
+
+// CARD_ISSUED, CARD_UPDATED, CARD_STATUS_CHANGED ve CARD_LIMIT_CHANGED olaylarında kartın son hali yazılır
+func (s *CardSyncService) HandleCardChanged(ctx context.Context, event *events.CardEvent) error {
+	if event.CardId == 0 || event.CustomerId == 0 {
+		return fmt.Errorf("invalid card event for card %d", event.CardId)
+	}
+
+	err := s.repo.Upsert(&model.CustomerCard{
+		CustomerID:   uint(event.CustomerId),
+		CardID:       uint(event.CardId),
+		MaskedNumber: event.MaskedNumber,
+		CardType:     event.CardType,
+		IsActive:     event.IsActive,
+	}, inbox.KeyFromContext(ctx))
+	return ignoreDuplicate(err)
+}
+
+func (s *CardSyncService) HandleCardRemoved(ctx context.Context, event *events.CardEvent) error {
+	if event.CardId == 0 {
+		return fmt.Errorf("invalid card_id in card removed event")
+	}
+	return ignoreDuplicate(s.repo.DeleteByCardID(uint(event.CardId), inbox.KeyFromContext(ctx)))
+}
+
+// Okuma modelini kart servisindeki güncel duruma göre yeniden oluşturur
+func (s *CardSyncService) RebuildCustomer(ctx context.Context, cardClient cardpb.CardServiceClient, customerID uint) (int, error) {
+	resp, err := cardClient.GetCustomerCards(ctx, &cardpb.GetCustomerCardsRequest{CustomerId: uint32(customerID)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch cards for customer %d: %v", customerID, err)
+	}
+
+	cards := make([]model.CustomerCard, len(resp.Cards))
+	for i, c := range resp.Cards {
+		cards[i] = model.CustomerCard{
+			CustomerID:   customerID,
+			CardID:       uint(c.Id),
+			MaskedNumber: c.MaskedNumber,
+			CardType:     c.CardType,
+			IsActive:     c.IsActive,
+		}
+	}
+
+	if err := s.repo.ReplaceForCustomer(customerID, cards); err != nil {
+		return 0, fmt.Errorf("failed to rebuild cards for customer %d: %v", customerID, err)
+	}
+	return len(cards), nil
+}
+
+// Tekrar teslim edilen olay daha önce uygulandığı için başarılı sayılır
+func ignoreDuplicate(err error) error {
+	if errors.Is(err, inbox.ErrDuplicate) {
+		return nil
+	}
+	return err
+}
@@ -1,34 +1,77 @@
 package service
 
 import (
+	"context"
+
 	"govo/internal/customer/model"
 	"govo/internal/customer/repository"
 )
 
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize     = 100
+)
+
 type CustomerService struct {
-	repo *repository.CustomerRepository
+	repo      *repository.CustomerRepository
+	auditRepo *repository.CustomerAuditRepository
 }
 
-func NewCustomerService(repo *repository.CustomerRepository) *CustomerService {
-	return &CustomerService{repo: repo}
+func NewCustomerService(repo *repository.CustomerRepository, auditRepo *repository.CustomerAuditRepository) *CustomerService {
+	return &CustomerService{
+		repo:      repo,
+		auditRepo: auditRepo,
+	}
 }
 
-func (s *CustomerService) CreateCustomer(customer *model.Customer) error {
-	return s.repo.Create(customer)
+func (s *CustomerService) CreateCustomer(ctx context.Context, customer *model.Customer) error {
+	return s.repo.Create(ctx, customer)
 }
 
 func (s *CustomerService) GetCustomer(id uint) (*model.Customer, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *CustomerService) UpdateCustomer(customer *model.Customer) error {
-	return s.repo.Update(customer)
+func (s *CustomerService) UpdateCustomer(ctx context.Context, customer *model.Customer) error {
+	return s.repo.Update(ctx, customer)
 }
 
-func (s *CustomerService) DeleteCustomer(id uint) error {
-	return s.repo.Delete(id)
+func (s *CustomerService) DeleteCustomer(ctx context.Context, id uint) error {
+	return s.repo.Delete(ctx, id)
 }
 
 func (s *CustomerService) ListCustomers() ([]model.Customer, error) {
 	return s.repo.List()
 }
+
+// Müşterinin değişiklik geçmişinden bir sayfa; kayıtlar en yeniden eskiye sıralanır
+type HistoryPage struct {
+	Entries  []model.CustomerAudit `json:"entries"`
+	Total    int64                 `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"page_size"`
+}
+
+// Sayfa numarası 1'den başlar. Kayıtlar müşterinin tüm kişisel verisini taşır;
+// müşteriler yalnızca kendi geçmişini, yöneticiler herkesinkini görür.
+func (s *CustomerService) GetCustomerHistory(ctx context.Context, customerID uint, page, pageSize int) (*HistoryPage, error) {
+	if err := authorizeCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	entries, total, err := s.auditRepo.ListByCustomerID(customerID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryPage{Entries: entries, Total: total, Page: page, PageSize: pageSize}, nil
+}
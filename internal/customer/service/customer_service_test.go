@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"govo/internal/requestctx"
+)
+
+// Geçmiş kayıtları tam kişisel veri taşır; başka müşteri ve kimliksiz çağrı depoya ulaşmadan reddedilir
+func TestCustomerHistoryRequiresOwner(t *testing.T) {
+	s := NewCustomerService(nil, nil)
+	callers := map[string]requestctx.Metadata{
+		"other customer": {Actor: "user-7", CustomerID: 7, Role: requestctx.RoleCustomer},
+		"anonymous":      {},
+	}
+	for name, md := range callers {
+		t.Run(name, func(t *testing.T) {
+			ctx := requestctx.WithMetadata(context.Background(), md)
+			if _, err := s.GetCustomerHistory(ctx, 8, 1, 20); !errors.Is(err, ErrCustomerMismatch) {
+				t.Errorf("GetCustomerHistory = %v, want ErrCustomerMismatch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	cardpb "govo/api/proto/card"
+	"govo/api/proto/events"
+	paymentpb "govo/api/proto/payment"
+	"govo/internal/customer/model"
+	"govo/internal/customer/repository"
+	"govo/internal/inbox"
+)
+
+// Silme işleminin tamamlanması için rapor vermesi gereken servisler
+var erasureServices = []string{"customer", "card", "payment"}
+
+type GDPRService struct {
+	customerRepo  *repository.CustomerRepository
+	cardRepo      *repository.CustomerCardRepository
+	erasureRepo   *repository.ErasureRepository
+	cardClient    cardpb.CardServiceClient
+	paymentClient paymentpb.PaymentServiceClient
+}
+
+func NewGDPRService(
+	customerRepo *repository.CustomerRepository,
+	cardRepo *repository.CustomerCardRepository,
+	erasureRepo *repository.ErasureRepository,
+	cardClient cardpb.CardServiceClient,
+	paymentClient paymentpb.PaymentServiceClient,
+) *GDPRService {
+	return &GDPRService{
+		customerRepo:  customerRepo,
+		cardRepo:      cardRepo,
+		erasureRepo:   erasureRepo,
+		cardClient:    cardClient,
+		paymentClient: paymentClient,
+	}
+}
+
+type ExportCard struct {
+	ID           uint    `json:"id"`
+	MaskedNumber string  `json:"masked_number"`
+	CardType     string  `json:"card_type"`
+	ExpiryDate   string  `json:"expiry_date"`
+	CreditLimit  float64 `json:"credit_limit"`
+	Balance      float64 `json:"balance"`
+	IsActive     bool    `json:"is_active"`
+}
+
+type ExportPayment struct {
+	ID          uint      `json:"id"`
+	CardID      uint      `json:"card_id"`
+	Amount      float64   `json:"amount"`
+	PaymentType string    `json:"payment_type"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ExportBundle struct {
+	GeneratedAt     time.Time              `json:"generated_at"`
+	Customer        *model.Customer        `json:"customer"`
+	CardReferences  []model.CustomerCard   `json:"card_references"`
+	Cards           []ExportCard           `json:"cards"`
+	Payments        []ExportPayment        `json:"payments"`
+	ErasureRequests []model.ErasureRequest `json:"erasure_requests"`
+}
+
+// Müşteri, kart ve ödeme servislerinde müşteri hakkında tutulan tüm veriyi toplar
+func (s *GDPRService) Export(ctx context.Context, customerID uint) (*ExportBundle, error) {
+	customer, err := s.customerRepo.GetByID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %v", err)
+	}
+
+	cardRefs, err := s.cardRepo.GetByCustomerID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load card references: %v", err)
+	}
+
+	erasures, err := s.erasureRepo.ListByCustomerID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load erasure requests: %v", err)
+	}
+
+	cardsResp, err := s.cardClient.GetCustomerCards(ctx, &cardpb.GetCustomerCardsRequest{CustomerId: uint32(customerID)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cards: %v", err)
+	}
+
+	paymentsResp, err := s.paymentClient.ListPayments(ctx, &paymentpb.ListPaymentsRequest{CustomerId: uint32(customerID)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payments: %v", err)
+	}
+
+	bundle := &ExportBundle{
+		GeneratedAt:     time.Now().UTC(),
+		Customer:        customer,
+		CardReferences:  cardRefs,
+		Cards:           make([]ExportCard, len(cardsResp.Cards)),
+		Payments:        make([]ExportPayment, len(paymentsResp.Payments)),
+		ErasureRequests: erasures,
+	}
+
+	// Kart numarası dışa aktarımda da maskelenmiş olarak yer alır
+	for i, c := range cardsResp.Cards {
+		bundle.Cards[i] = ExportCard{
+			ID:           uint(c.Id),
+			MaskedNumber: c.MaskedNumber,
+			CardType:     c.CardType,
+			ExpiryDate:   c.ExpiryDate,
+			CreditLimit:  float64(c.CreditLimit),
+			Balance:      float64(c.Balance),
+			IsActive:     c.IsActive,
+		}
+	}
+
+	for i, p := range paymentsResp.Payments {
+		bundle.Payments[i] = ExportPayment{
+			ID:          uint(p.Id),
+			CardID:      uint(p.CardId),
+			Amount:      p.Amount,
+			PaymentType: p.PaymentType,
+			Status:      p.Status,
+			Description: p.Description,
+			CreatedAt:   p.CreatedAt.AsTime(),
+			UpdatedAt:   p.UpdatedAt.AsTime(),
+		}
+	}
+
+	return bundle, nil
+}
+
+func (s *GDPRService) WriteZip(w io.Writer, bundle *ExportBundle) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"customer.json", bundle.Customer},
+		{"card_references.json", bundle.CardReferences},
+		{"cards.json", bundle.Cards},
+		{"payments.json", bundle.Payments},
+		{"erasure_requests.json", bundle.ErasureRequests},
+		{"manifest.json", map[string]interface{}{
+			"customer_id":  bundle.Customer.ID,
+			"generated_at": bundle.GeneratedAt,
+			"sources":      []string{"customer-service", "card-service", "payment-service"},
+		}},
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return fmt.Errorf("failed to write %s: %v", f.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// PII alanlarını anonimleştirir; silme talebi olayı aynı transaction'da outbox'a yazılır,
+// Kafka'ya ulaşılamazsa relay tekrar dener ve talep FAILED olmaz
+func (s *GDPRService) RequestErasure(ctx context.Context, customerID uint) (*model.ErasureRequest, error) {
+	request, err := s.erasureRepo.AnonymizeCustomer(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to anonymize customer: %v", err)
+	}
+	return request, nil
+}
+
+func (s *GDPRService) GetErasureReport(customerID uint) ([]model.ErasureRequest, error) {
+	return s.erasureRepo.ListByCustomerID(customerID)
+}
+
+// Kart ve ödeme servislerinden gelen tamamlanma raporlarını işler
+func (s *GDPRService) HandleErasureCompleted(ctx context.Context, event *events.CustomerErasureCompleted) error {
+	if event.ErasureId == 0 {
+		return fmt.Errorf("invalid erasure_id in erasure completed event")
+	}
+	if event.Service == "" {
+		return fmt.Errorf("invalid service in erasure completed event")
+	}
+
+	now := time.Now()
+	step := &model.ErasureStep{
+		ErasureID:         uint(event.ErasureId),
+		Service:           event.Service,
+		Status:            model.ErasureStatusCompleted,
+		AnonymizedRecords: event.AnonymizedRecords,
+		RetainedRecords:   event.RetainedRecords,
+		CompletedAt:       &now,
+	}
+	if event.Error != "" {
+		step.Status = model.ErasureStatusFailed
+		step.Detail = event.Error
+	}
+
+	if err := s.erasureRepo.SaveStep(step, inbox.KeyFromContext(ctx)); err != nil {
+		if errors.Is(err, inbox.ErrDuplicate) {
+			return nil
+		}
+		return fmt.Errorf("failed to save erasure step: %v", err)
+	}
+
+	request, err := s.erasureRepo.GetByID(uint(event.ErasureId))
+	if err != nil {
+		return fmt.Errorf("erasure request not found: %v", err)
+	}
+
+	status := erasureStatus(request.Steps)
+	if status == request.Status {
+		return nil
+	}
+
+	request.Status = status
+	if status != model.ErasureStatusInProgress {
+		request.CompletedAt = &now
+		log.Printf("Erasure %d for customer %d finished with status %s", request.ID, request.CustomerID, status)
+	}
+	return s.erasureRepo.Update(request)
+}
+
+func erasureStatus(steps []model.ErasureStep) string {
+	done := make(map[string]string, len(steps))
+	for _, step := range steps {
+		done[step.Service] = step.Status
+	}
+
+	for _, service := range erasureServices {
+		if done[service] == model.ErasureStatusFailed {
+			return model.ErasureStatusFailed
+		}
+	}
+	for _, service := range erasureServices {
+		if done[service] != model.ErasureStatusCompleted {
+			return model.ErasureStatusInProgress
+		}
+	}
+	return model.ErasureStatusCompleted
+}
@@ -0,0 +1,149 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"govo/kafka"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kafka en az bir kez teslim eder; işlenen olaylar yan etkiyle aynı transaction'da kaydedilir
+// ve aynı olay tekrar geldiğinde işlem yapılmaz.
+
+// DLQ'dan bu süreden eski bir mesaj tekrar gönderilirse yeniden işlenir
+const DefaultRetention = 7 * 24 * time.Hour
+
+var ErrDuplicate = errors.New("event already processed")
+
+// Aggregate'in önceki olayları henüz işlenmedi; hata tekrar denemeye gider ve eksik olay gelince işlenir
+var ErrOutOfOrder = errors.New("event arrived before earlier events of its aggregate")
+
+type ProcessedEvent struct {
+	Consumer    string    `gorm:"primaryKey;size:100" json:"consumer"`
+	EventID     string    `gorm:"primaryKey;size:36" json:"event_id"`
+	EventType   string    `json:"event_type"`
+	ProcessedAt time.Time `gorm:"not null;index" json:"processed_at"`
+}
+
+// Tüketicinin her aggregate için işlediği son sıra numarası.
+// Temizlenmez; satır silinirse aggregate'in sonraki olayları sıra dışı sayılır.
+type AggregateSequence struct {
+	Consumer    string    `gorm:"primaryKey;size:100" json:"consumer"`
+	AggregateID string    `gorm:"primaryKey;size:100" json:"aggregate_id"`
+	Sequence    uint64    `gorm:"not null" json:"sequence"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type Key struct {
+	Consumer    string
+	EventID     string
+	EventType   string
+	AggregateID string
+	Sequence    uint64
+}
+
+func (k Key) IsZero() bool {
+	return k.Consumer == "" || k.EventID == ""
+}
+
+// Consumer dışından yapılan çağrılarda boş anahtar döner, tekilleştirme yapılmaz
+func KeyFromContext(ctx context.Context) Key {
+	msg, ok := kafka.MessageFromContext(ctx)
+	if !ok {
+		return Key{}
+	}
+	return Key{
+		Consumer:    msg.Group,
+		EventID:     msg.Envelope.GetEventId(),
+		EventType:   msg.Envelope.GetType(),
+		AggregateID: msg.Envelope.GetAggregateId(),
+		Sequence:    msg.Envelope.GetSequence(),
+	}
+}
+
+// Olayı verilen transaction içinde işlenmiş olarak kaydeder, daha önce kaydedildiyse ErrDuplicate döner.
+// Sıra numaralı olaylarda aggregate'in bir önceki olayı işlenmemişse ErrOutOfOrder döner.
+func Claim(tx *gorm.DB, key Key) error {
+	if key.IsZero() {
+		return nil
+	}
+
+	if key.AggregateID != "" && key.Sequence > 0 {
+		if err := advance(tx, key); err != nil {
+			return err
+		}
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ProcessedEvent{
+		Consumer:    key.Consumer,
+		EventID:     key.EventID,
+		EventType:   key.EventType,
+		ProcessedAt: time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDuplicate
+	}
+	return nil
+}
+
+func advance(tx *gorm.DB, key Key) error {
+	var last AggregateSequence
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("consumer = ? AND aggregate_id = ?", key.Consumer, key.AggregateID).
+		Take(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	switch {
+	case key.Sequence <= last.Sequence:
+		// Daha yeni bir olay zaten uygulandı, eski sürüm yok sayılır
+		return ErrDuplicate
+	case key.Sequence > last.Sequence+1:
+		return fmt.Errorf("%w: %s %s has sequence %d, last applied %d", ErrOutOfOrder, key.EventType, key.AggregateID, key.Sequence, last.Sequence)
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "consumer"}, {Name: "aggregate_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sequence", "updated_at"}),
+	}).Create(&AggregateSequence{
+		Consumer:    key.Consumer,
+		AggregateID: key.AggregateID,
+		Sequence:    key.Sequence,
+		UpdatedAt:   time.Now(),
+	}).Error
+}
+
+func Cleanup(db *gorm.DB, retention time.Duration) (int64, error) {
+	result := db.Where("processed_at < ?", time.Now().Add(-retention)).Delete(&ProcessedEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// Tabloyu sınırlı tutmak için eski kayıtları periyodik olarak siler
+func StartCleanup(ctx context.Context, db *gorm.DB, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := Cleanup(db, retention); err != nil {
+			log.Printf("Failed to clean up processed events: %v", err)
+		} else if n > 0 {
+			log.Printf("Removed %d processed events older than %v", n, retention)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
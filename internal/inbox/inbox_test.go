@@ -0,0 +1,142 @@
+package inbox
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	selectSequence  = regexp.QuoteMeta(`SELECT * FROM "aggregate_sequences" WHERE consumer = $1 AND aggregate_id = $2 LIMIT $3 FOR UPDATE`)
+	upsertSequence  = regexp.QuoteMeta(`INSERT INTO "aggregate_sequences"`)
+	insertProcessed = regexp.QuoteMeta(`INSERT INTO "processed_events"`)
+	deleteProcessed = regexp.QuoteMeta(`DELETE FROM "processed_events" WHERE processed_at < $1`)
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		conn.Close()
+	})
+	return db, mock
+}
+
+func TestClaim(t *testing.T) {
+	key := Key{Consumer: "payment-erasure", EventID: "e1", EventType: "CUSTOMER_ERASURE_REQUESTED"}
+
+	tests := []struct {
+		name    string
+		key     Key
+		inserts int64
+		want    error
+	}{
+		{name: "first delivery", key: key, inserts: 1},
+		{name: "redelivery", key: key, inserts: 0, want: ErrDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectExec(insertProcessed).
+				WithArgs(tt.key.Consumer, tt.key.EventID, tt.key.EventType, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, tt.inserts))
+
+			if err := Claim(db, tt.key); !errors.Is(err, tt.want) {
+				t.Errorf("claim = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Consumer dışından gelen çağrılar kaydedilmez
+func TestClaimZeroKey(t *testing.T) {
+	db, _ := newMockDB(t)
+	if err := Claim(db, Key{}); err != nil {
+		t.Errorf("claim = %v", err)
+	}
+}
+
+// Sıra numaralı olaylar aggregate'in son işlenen sırasından devam etmelidir
+func TestClaimSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		last     uint64 // 0: aggregate için kayıt yok
+		sequence uint64
+		want     error
+	}{
+		{name: "first event", sequence: 1},
+		{name: "next event", last: 3, sequence: 4},
+		{name: "same event again", last: 3, sequence: 3, want: ErrDuplicate},
+		{name: "older event", last: 3, sequence: 2, want: ErrDuplicate},
+		{name: "gap", last: 3, sequence: 5, want: ErrOutOfOrder},
+		{name: "gap before the first event", sequence: 2, want: ErrOutOfOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			key := Key{Consumer: "customer-cards", EventID: "e1", EventType: "CUSTOMER_UPDATED", AggregateID: "42", Sequence: tt.sequence}
+
+			rows := sqlmock.NewRows([]string{"consumer", "aggregate_id", "sequence", "updated_at"})
+			if tt.last > 0 {
+				rows.AddRow(key.Consumer, key.AggregateID, tt.last, time.Now())
+			}
+			mock.ExpectQuery(selectSequence).WithArgs(key.Consumer, key.AggregateID, 1).WillReturnRows(rows)
+			if tt.want == nil {
+				mock.ExpectExec(upsertSequence).
+					WithArgs(key.Consumer, key.AggregateID, tt.sequence, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertProcessed).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			if err := Claim(db, key); !errors.Is(err, tt.want) {
+				t.Errorf("claim = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Sınır zamanı çağrı anına göre hesaplandığı için bir dakikalık pay bırakılır
+type around time.Time
+
+func (a around) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	d := t.Sub(time.Time(a))
+	return d > -time.Minute && d < time.Minute
+}
+
+// retention'dan eski işlenmiş olay kayıtları silinir
+func TestCleanup(t *testing.T) {
+	db, mock := newMockDB(t)
+	cutoff := time.Now().Add(-DefaultRetention)
+	mock.ExpectExec(deleteProcessed).WithArgs(around(cutoff)).WillReturnResult(sqlmock.NewResult(0, 12))
+
+	n, err := Cleanup(db, DefaultRetention)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 12 {
+		t.Errorf("removed %d, want 12", n)
+	}
+}
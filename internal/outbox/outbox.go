@@ -0,0 +1,335 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"govo/kafka"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Olaylar iş verisiyle aynı transaction'da outbox tablosuna yazılır, Relay bunları
+// arka planda Kafka'ya gönderir. Böylece istek broker'ı beklemez ve commit edilen
+// her değişikliğin olayı en az bir kez yayınlanır.
+
+const (
+	StatusPending = "PENDING"
+	StatusSending = "SENDING"
+	StatusSent    = "SENT"
+	StatusFailed  = "FAILED"
+)
+
+// Gönderilmiş satırlar bu süreden sonra silinir
+const DefaultRetention = 3 * 24 * time.Hour
+
+type Message struct {
+	ID            uint64     `gorm:"primaryKey" json:"id"`
+	EventID       string     `gorm:"size:36;index" json:"event_id"`
+	EventType     string     `gorm:"size:100" json:"event_type"`
+	Topic         string     `gorm:"size:200;not null" json:"topic"`
+	Key           []byte     `json:"key"`
+	Value         []byte     `gorm:"not null" json:"-"`
+	Headers       string     `gorm:"type:text" json:"headers"`
+	Status        string     `gorm:"size:20;not null;index:idx_outbox_status" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_status" json:"next_attempt_at"`
+	Partition     int32      `gorm:"column:kafka_partition" json:"partition"`
+	Offset        int64      `gorm:"column:kafka_offset" json:"offset"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	SentAt        *time.Time `json:"sent_at"`
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Olayları verilen veritabanı bağlantısına (genelde bir transaction) yazan Publisher
+type Writer struct {
+	db *gorm.DB
+}
+
+func NewWriter(db *gorm.DB) *Writer {
+	return &Writer{db: db}
+}
+
+func (w *Writer) Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...kafka.PublishOption) error {
+	rec, err := kafka.NewEventRecord(ctx, topic, eventType, payload, opts...)
+	if err != nil {
+		return err
+	}
+	return w.Send(ctx, rec)
+}
+
+func (w *Writer) Send(ctx context.Context, rec *kafka.Record) error {
+	headers, err := json.Marshal(rec.Headers)
+	if err != nil {
+		return err
+	}
+
+	msg := &Message{
+		Topic:         rec.Topic,
+		Key:           rec.Key,
+		Value:         append([]byte{}, rec.Value...),
+		Headers:       string(headers),
+		Status:        StatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	if envelope, err := rec.Envelope(); err == nil && envelope != nil {
+		msg.EventID = envelope.EventId
+		msg.EventType = envelope.Type
+	}
+
+	if err := w.db.WithContext(ctx).Create(msg).Error; err != nil {
+		return fmt.Errorf("failed to write outbox message: %v", err)
+	}
+	return nil
+}
+
+func (w *Writer) Close() error {
+	return nil
+}
+
+func (m *Message) record() (*kafka.Record, error) {
+	rec := &kafka.Record{
+		Topic:     m.Topic,
+		Key:       m.Key,
+		Value:     m.Value,
+		Timestamp: m.CreatedAt,
+		Metadata:  m.ID,
+	}
+	// Tombstone boş değerle saklanır, Kafka'ya nil gider
+	if len(m.Value) == 0 {
+		rec.Value = nil
+	}
+	if m.Headers != "" {
+		if err := json.Unmarshal([]byte(m.Headers), &rec.Headers); err != nil {
+			return nil, fmt.Errorf("invalid headers on outbox message %d: %v", m.ID, err)
+		}
+	}
+	return rec, nil
+}
+
+// Bekleyen outbox satırlarını Kafka'ya gönderir. Publisher AsyncPublisher ise satırlar
+// teslimat callback'inde, değilse Send döndükten sonra güncellenir.
+type Relay struct {
+	db          *gorm.DB
+	publisher   kafka.Publisher
+	async       bool
+	batchSize   int
+	interval    time.Duration
+	maxAttempts int
+	// Bu süreden uzun SENDING kalan satırlar (ör. process çöktüyse) tekrar gönderilir
+	sendTimeout time.Duration
+}
+
+func NewRelay(db *gorm.DB, publisher kafka.Publisher) *Relay {
+	r := &Relay{
+		db:          db,
+		publisher:   publisher,
+		batchSize:   100,
+		interval:    500 * time.Millisecond,
+		maxAttempts: 10,
+		sendTimeout: time.Minute,
+	}
+	if async, ok := publisher.(kafka.AsyncPublisher); ok {
+		r.async = true
+		async.OnDelivery(r.delivered)
+	}
+	return r
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		// Dolu batch geldiyse beklemeden devam et
+		n, err := r.Flush(ctx)
+		if err != nil {
+			log.Printf("Failed to relay outbox messages: %v", err)
+		}
+		if n == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ticker.C:
+		case <-cleanup.C:
+			if _, err := Cleanup(r.db, DefaultRetention); err != nil {
+				log.Printf("Failed to clean up outbox: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Gönderilmeye hazır satırlardan bir batch gönderir, gönderilen satır sayısını döner
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	// Producer henüz bağlanmadıysa satırlar PENDING kalır, bağlantı kurulunca gönderilir
+	if reporter, ok := r.publisher.(kafka.ConnectionReporter); ok && !reporter.ConnectionStatus().Connected {
+		return 0, nil
+	}
+
+	// Yarıda kalmış gönderimler
+	if err := r.db.Model(&Message{}).
+		Where("status = ? AND updated_at < ?", StatusSending, now.Add(-r.sendTimeout)).
+		Updates(map[string]interface{}{"status": StatusPending, "updated_at": now}).Error; err != nil {
+		return 0, err
+	}
+
+	// Birden fazla replika aynı satırları almasın diye SKIP LOCKED
+	var batch []*Message
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", StatusPending, now).
+			Order("id").
+			Limit(r.batchSize).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(batch))
+		for i, m := range batch {
+			ids[i] = m.ID
+		}
+		return tx.Model(&Message{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": StatusSending, "updated_at": now}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, m := range batch {
+		rec, err := m.record()
+		if err != nil {
+			r.markFailed(m.ID, err, true)
+			continue
+		}
+		if err := r.publisher.Send(ctx, rec); err != nil {
+			r.markFailed(m.ID, err, false)
+			// Broker'a ulaşılamıyorsa kalan satırlar da gönderilemez
+			if kafka.IsUnavailable(err) {
+				r.release(batch[i+1:], err)
+				return i, nil
+			}
+			continue
+		}
+		if !r.async {
+			r.markSent(m.ID, rec)
+		}
+	}
+	return len(batch), nil
+}
+
+// Gönderilmeden kalan satırlar deneme sayılmadan PENDING'e döner
+func (r *Relay) release(batch []*Message, cause error) {
+	if len(batch) == 0 {
+		return
+	}
+	ids := make([]uint64, len(batch))
+	for i, m := range batch {
+		ids[i] = m.ID
+	}
+	now := time.Now()
+	if err := r.db.Model(&Message{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":          StatusPending,
+		"last_error":      cause.Error(),
+		"next_attempt_at": now.Add(unavailableDelay),
+		"updated_at":      now,
+	}).Error; err != nil {
+		log.Printf("Failed to release outbox messages: %v", err)
+	}
+}
+
+func (r *Relay) delivered(rec *kafka.Record, err error) {
+	id, ok := rec.Metadata.(uint64)
+	if !ok {
+		// Aynı producer'dan giden, outbox dışı bir kayıt
+		return
+	}
+	if err != nil {
+		r.markFailed(id, err, false)
+		return
+	}
+	r.markSent(id, rec)
+}
+
+func (r *Relay) markSent(id uint64, rec *kafka.Record) {
+	now := time.Now()
+	if err := r.db.Model(&Message{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          StatusSent,
+		"kafka_partition": rec.Partition,
+		"kafka_offset":    rec.Offset,
+		"last_error":      "",
+		"sent_at":         now,
+		"updated_at":      now,
+	}).Error; err != nil {
+		log.Printf("Failed to mark outbox message %d as sent: %v", id, err)
+	}
+}
+
+// Kalıcı hatada ya da deneme hakkı bitince satır FAILED olur, aksi halde artan beklemeyle tekrar denenir
+func (r *Relay) markFailed(id uint64, cause error, permanent bool) {
+	var msg Message
+	if err := r.db.First(&msg, id).Error; err != nil {
+		log.Printf("Failed to load outbox message %d: %v", id, err)
+		return
+	}
+
+	now := time.Now()
+	attempts := msg.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts":   attempts,
+		"last_error": cause.Error(),
+		"updated_at": now,
+	}
+	// Kafka kapalıyken olaylar outbox'ta bekler, deneme hakları tükenmez
+	if !permanent && kafka.IsUnavailable(cause) {
+		updates["attempts"] = msg.Attempts
+		updates["status"] = StatusPending
+		updates["next_attempt_at"] = now.Add(unavailableDelay)
+	} else if permanent || attempts >= r.maxAttempts {
+		updates["status"] = StatusFailed
+		log.Printf("Outbox message %d (%s) failed after %d attempts: %v", id, msg.EventType, attempts, cause)
+	} else {
+		updates["status"] = StatusPending
+		updates["next_attempt_at"] = now.Add(backoff(attempts))
+	}
+
+	if err := r.db.Model(&Message{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		log.Printf("Failed to update outbox message %d: %v", id, err)
+	}
+}
+
+// Broker'a ulaşılamadığında satırlar bu süre sonra tekrar denenir
+const unavailableDelay = 5 * time.Second
+
+// 1s, 2s, 4s ... en fazla 1 dakika
+func backoff(attempts int) time.Duration {
+	d := time.Second << (attempts - 1)
+	if d <= 0 || d > time.Minute {
+		return time.Minute
+	}
+	return d
+}
+
+func Cleanup(db *gorm.DB, retention time.Duration) (int64, error) {
+	result := db.Where("status = ? AND sent_at < ?", StatusSent, time.Now().Add(-retention)).Delete(&Message{})
+	return result.RowsAffected, result.Error
+}
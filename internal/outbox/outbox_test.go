@@ -0,0 +1,15 @@
+package outbox
+
+import "testing"
+
+// Tombstone outbox'ta boş değerle saklanır ve Kafka'ya nil değerle gitmelidir
+func TestTombstoneRecord(t *testing.T) {
+	msg := &Message{Topic: "customers.state", Key: []byte("42"), Value: []byte{}, Headers: "{}"}
+	rec, err := msg.record()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.IsTombstone() || string(rec.Key) != "42" {
+		t.Errorf("record = %+v, want tombstone for key 42", rec)
+	}
+}
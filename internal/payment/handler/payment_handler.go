@@ -18,23 +18,27 @@ func NewPaymentHandler(service *service.PaymentService) *PaymentHandler {
 }
 
 type CreatePaymentRequest struct {
-	CustomerID  uint    `json:"customer_id"`
-	CardID      uint    `json:"card_id"`
-	Amount      float64 `json:"amount"`
-	PaymentType string  `json:"payment_type"`
-	Description string  `json:"description"`
+	CustomerID    uint    `json:"customer_id"`
+	CardID        uint    `json:"card_id"`
+	AccountID     uint    `json:"account_id"`
+	BeneficiaryID uint    `json:"beneficiary_id"`
+	Amount        float64 `json:"amount"`
+	PaymentType   string  `json:"payment_type"`
+	Description   string  `json:"description"`
 }
 
 type PaymentResponse struct {
-	ID          uint      `json:"id"`
-	CustomerID  uint      `json:"customer_id"`
-	CardID      uint      `json:"card_id"`
-	Amount      float64   `json:"amount"`
-	PaymentType string    `json:"payment_type"`
-	Status      string    `json:"status"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            uint      `json:"id"`
+	CustomerID    uint      `json:"customer_id"`
+	CardID        uint      `json:"card_id"`
+	AccountID     uint      `json:"account_id"`
+	BeneficiaryID uint      `json:"beneficiary_id"`
+	Amount        float64   `json:"amount"`
+	PaymentType   string    `json:"payment_type"`
+	Status        string    `json:"status"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
@@ -48,6 +52,8 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 		r.Context(),
 		req.CustomerID,
 		req.CardID,
+		req.AccountID,
+		req.BeneficiaryID,
 		req.Amount,
 		req.PaymentType,
 		req.Description,
@@ -58,15 +64,17 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := PaymentResponse{
-		ID:          payment.ID,
-		CustomerID:  payment.CustomerID,
-		CardID:      payment.CardID,
-		Amount:      payment.Amount,
-		PaymentType: payment.PaymentType,
-		Status:      payment.Status,
-		Description: payment.Description,
-		CreatedAt:   payment.CreatedAt,
-		UpdatedAt:   payment.UpdatedAt,
+		ID:            payment.ID,
+		CustomerID:    payment.CustomerID,
+		CardID:        payment.CardID,
+		AccountID:     payment.AccountID,
+		BeneficiaryID: payment.BeneficiaryID,
+		Amount:        payment.Amount,
+		PaymentType:   payment.PaymentType,
+		Status:        payment.Status,
+		Description:   payment.Description,
+		CreatedAt:     payment.CreatedAt,
+		UpdatedAt:     payment.UpdatedAt,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -93,15 +101,17 @@ func (h *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := PaymentResponse{
-		ID:          payment.ID,
-		CustomerID:  payment.CustomerID,
-		CardID:      payment.CardID,
-		Amount:      payment.Amount,
-		PaymentType: payment.PaymentType,
-		Status:      payment.Status,
-		Description: payment.Description,
-		CreatedAt:   payment.CreatedAt,
-		UpdatedAt:   payment.UpdatedAt,
+		ID:            payment.ID,
+		CustomerID:    payment.CustomerID,
+		CardID:        payment.CardID,
+		AccountID:     payment.AccountID,
+		BeneficiaryID: payment.BeneficiaryID,
+		Amount:        payment.Amount,
+		PaymentType:   payment.PaymentType,
+		Status:        payment.Status,
+		Description:   payment.Description,
+		CreatedAt:     payment.CreatedAt,
+		UpdatedAt:     payment.UpdatedAt,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -145,15 +155,17 @@ func (h *PaymentHandler) ListPayments(w http.ResponseWriter, r *http.Request) {
 	response := make([]PaymentResponse, len(payments))
 	for i, p := range payments {
 		response[i] = PaymentResponse{
-			ID:          p.ID,
-			CustomerID:  p.CustomerID,
-			CardID:      p.CardID,
-			Amount:      p.Amount,
-			PaymentType: p.PaymentType,
-			Status:      p.Status,
-			Description: p.Description,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
+			ID:            p.ID,
+			CustomerID:    p.CustomerID,
+			CardID:        p.CardID,
+			AccountID:     p.AccountID,
+			BeneficiaryID: p.BeneficiaryID,
+			Amount:        p.Amount,
+			PaymentType:   p.PaymentType,
+			Status:        p.Status,
+			Description:   p.Description,
+			CreatedAt:     p.CreatedAt,
+			UpdatedAt:     p.UpdatedAt,
 		}
 	}
 
@@ -189,3 +201,42 @@ func (h *PaymentHandler) CancelPayment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
 }
+
+type PaymentEventResponse struct {
+	Version    uint64          `json:"version"`
+	Type       string          `json:"type"`
+	Data       json.RawMessage `json:"data"`
+	Actor      string          `json:"actor,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Ödemenin olay deposundaki değişiklik geçmişi, denetim için
+func (h *PaymentHandler) GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid payment ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.PaymentHistory(r.Context(), uint(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	response := make([]PaymentEventResponse, len(history))
+	for i, event := range history {
+		response[i] = PaymentEventResponse{
+			Version:    event.Version,
+			Type:       event.Type,
+			Data:       json.RawMessage(event.Data),
+			Actor:      event.Actor,
+			RequestID:  event.RequestID,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
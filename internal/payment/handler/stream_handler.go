@@ -0,0 +1,359 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"govo/internal/payment/service"
+	"govo/internal/requestctx"
+	"govo/kafka"
+)
+
+// Tarayıcıdaki EventSource header gönderemediği için bağlantı kısa ömürlü, müşteriye bağlı bir
+// token ile yetkilendirilir. Token gateway arkasındaki POST /api/payments/stream/token ile alınır
+// ve ?access_token= ya da Authorization: Bearer ile verilir.
+type StreamTokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+var (
+	ErrInvalidStreamToken = errors.New("invalid stream token")
+	ErrExpiredStreamToken = errors.New("stream token expired")
+)
+
+// secret boşsa rastgele üretilir; token'lar sadece bu instance'ta geçerli olur, yalnızca GOVO_ENV=dev içindir
+func NewStreamTokens(secret string, ttl time.Duration) *StreamTokens {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(err)
+		}
+		log.Printf("PAYMENT_STREAM_SECRET tanımlı değil, stream token'ları sadece bu instance'ta geçerli")
+	}
+	return &StreamTokens{secret: key, ttl: ttl}
+}
+
+// Token: base64(customerID.expiry).hex(hmac)
+func (t *StreamTokens) Issue(customerID uint) (string, time.Time) {
+	expires := time.Now().Add(t.ttl).Truncate(time.Second)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d.%d", customerID, expires.Unix())))
+	return claims + "." + t.sign(claims), expires
+}
+
+func (t *StreamTokens) Verify(token string) (uint, time.Time, error) {
+	claims, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(t.sign(claims))) {
+		return 0, time.Time{}, ErrInvalidStreamToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(claims)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidStreamToken
+	}
+	id, exp, ok := strings.Cut(string(raw), ".")
+	customerID, err1 := strconv.ParseUint(id, 10, 32)
+	unix, err2 := strconv.ParseInt(exp, 10, 64)
+	if !ok || err1 != nil || err2 != nil {
+		return 0, time.Time{}, ErrInvalidStreamToken
+	}
+	expires := time.Unix(unix, 0)
+	if time.Now().After(expires) {
+		return 0, time.Time{}, ErrExpiredStreamToken
+	}
+	return uint(customerID), expires, nil
+}
+
+func (t *StreamTokens) sign(claims string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(claims))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Ödeme durum değişikliklerini ve kart bakiyelerini Server-Sent Events ile gönderir
+type StreamHandler struct {
+	service   *service.PaymentService
+	tokens    *StreamTokens
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(service *service.PaymentService, tokens *StreamTokens) *StreamHandler {
+	return &StreamHandler{service: service, tokens: tokens, heartbeat: 15 * time.Second}
+}
+
+type StreamTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Token doğrulanmış müşterinin kendisi için verilir; customer_id verilirse aynı müşteri olmalıdır.
+// Yöneticiler customer_id ile herhangi bir müşteri için token alabilir.
+func (h *StreamHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	md := requestctx.FromContext(r.Context())
+	if md.Actor == "" {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var requested uint64
+	if v := r.URL.Query().Get("customer_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || id == 0 {
+			http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+			return
+		}
+		requested = id
+	}
+
+	customerID := md.CustomerID
+	switch {
+	case md.IsAdmin():
+		if requested == 0 {
+			http.Error(w, "customer_id is required", http.StatusBadRequest)
+			return
+		}
+		customerID = uint(requested)
+	case customerID == 0:
+		http.Error(w, "Not a customer", http.StatusForbidden)
+		return
+	case requested != 0 && uint(requested) != customerID:
+		http.Error(w, "Cannot stream another customer's events", http.StatusForbidden)
+		return
+	}
+
+	token, expires := h.tokens.Issue(customerID)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(StreamTokenResponse{Token: token, ExpiresAt: expires})
+}
+
+type PaymentStreamEvent struct {
+	PaymentResponse
+	EventType     string    `json:"event_type"`
+	Sequence      uint64    `json:"sequence"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+}
+
+type CardBalanceEvent struct {
+	CardID       uint32    `json:"card_id"`
+	MaskedNumber string    `json:"masked_number,omitempty"`
+	CardType     string    `json:"card_type,omitempty"`
+	CreditLimit  float64   `json:"credit_limit"`
+	Balance      float64   `json:"balance"`
+	IsActive     bool      `json:"is_active"`
+	Removed      bool      `json:"removed,omitempty"`
+	Sequence     uint64    `json:"sequence"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
+
+type streamMessage struct {
+	event string
+	data  interface{}
+	// Olayın geldiği izlemenin yeni resume token'ı
+	payments string
+	cards    string
+	err      error
+}
+
+// GET /api/payments/stream?customer_id=&access_token=
+//
+// Olaylar: "payment" (durum değişikliği) ve "card" (bakiye, limit, durum). Her olayın id'si iki
+// izlemenin konumunu taşır; tarayıcı yeniden bağlanırken Last-Event-ID ile kaldığı yerden devam eder.
+// Token süresi dolunca bağlantı kapanır, istemci yeni token ile aynı Last-Event-ID'den bağlanır.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	customerID, err := strconv.ParseUint(r.URL.Query().Get("customer_id"), 10, 32)
+	if err != nil || customerID == 0 {
+		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		return
+	}
+
+	// Gateway'den gelen isteklerde Authorization kullanıcı JWT'sini taşır, query önceliklidir
+	token := r.URL.Query().Get("access_token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token == "" {
+		token = bearer
+	}
+	tokenCustomer, expires, err := h.tokens.Verify(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if tokenCustomer != uint(customerID) {
+		http.Error(w, "Token is not valid for this customer", http.StatusForbidden)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	paymentsToken, cardsToken, _ := strings.Cut(lastEventID, ".")
+	// İlk olay sadece bir izlemeden gelse de id iki konumu da taşısın
+	if paymentsToken == "" || cardsToken == "" {
+		payments, cards, err := h.service.StreamPositions(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if paymentsToken == "" {
+			paymentsToken = payments
+		}
+		if cardsToken == "" {
+			cardsToken = cards
+		}
+	}
+
+	ctx, cancel := context.WithDeadline(r.Context(), expires)
+	defer cancel()
+
+	messages := make(chan streamMessage)
+	emit := func(m streamMessage) error {
+		select {
+		case messages <- m:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// Döngü konumları güncellerken izlemeler başlangıç konumlarını kullanır
+	paymentsFrom, cardsFrom := paymentsToken, cardsToken
+	go func() {
+		err := h.service.WatchPayments(ctx, uint(customerID), 0, paymentsFrom, func(u *service.PaymentUpdate) error {
+			return emit(streamMessage{event: "payment", data: paymentStreamEvent(u), payments: u.ResumeToken})
+		})
+		emit(streamMessage{err: err})
+	}()
+	go func() {
+		err := h.service.WatchCardBalances(ctx, uint(customerID), cardsFrom, func(u *service.CardBalanceUpdate) error {
+			return emit(streamMessage{event: "card", data: cardBalanceEvent(u), cards: u.ResumeToken})
+		})
+		emit(streamMessage{err: err})
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	// Tarayıcı bağlantı koparsa 3 saniye sonra tekrar bağlanır
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case m := <-messages:
+			if m.err != nil {
+				// Bağlantı kapanıyorsa hata değildir
+				if ctx.Err() == nil {
+					requestctx.Logf(r.Context(), "Payment stream for customer %d stopped: %v", customerID, m.err)
+					writeStreamError(w, m.err)
+					flusher.Flush()
+				} else {
+					writeTokenExpired(w, ctx)
+					flusher.Flush()
+				}
+				return
+			}
+			if m.payments != "" {
+				paymentsToken = m.payments
+			}
+			if m.cards != "" {
+				cardsToken = m.cards
+			}
+			data, err := json.Marshal(m.data)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s.%s\nevent: %s\ndata: %s\n\n", paymentsToken, cardsToken, m.event, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-ctx.Done():
+			writeTokenExpired(w, ctx)
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+func writeTokenExpired(w http.ResponseWriter, ctx context.Context) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Fprint(w, "event: token-expired\ndata: {}\n\n")
+	}
+}
+
+// Süresi dolmuş konumda istemci Last-Event-ID olmadan yeniden bağlanmalıdır
+func writeStreamError(w http.ResponseWriter, err error) {
+	code := "unavailable"
+	switch {
+	case errors.Is(err, service.ErrInvalidResumeToken):
+		code = "invalid-last-event-id"
+	case errors.Is(err, kafka.ErrPositionExpired):
+		code = "resume-expired"
+	}
+	data, _ := json.Marshal(map[string]string{"code": code, "message": err.Error()})
+	fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+}
+
+func paymentStreamEvent(u *service.PaymentUpdate) PaymentStreamEvent {
+	p := u.Payment
+	return PaymentStreamEvent{
+		PaymentResponse: PaymentResponse{
+			ID:            p.ID,
+			CustomerID:    p.CustomerID,
+			CardID:        p.CardID,
+			AccountID:     p.AccountID,
+			BeneficiaryID: p.BeneficiaryID,
+			Amount:        p.Amount,
+			PaymentType:   p.PaymentType,
+			Status:        p.Status,
+			Description:   p.Description,
+			CreatedAt:     p.CreatedAt,
+			UpdatedAt:     p.UpdatedAt,
+		},
+		EventType:     u.EventType,
+		Sequence:      p.Sequence,
+		OccurredAt:    u.OccurredAt,
+		FailureReason: u.FailureReason,
+	}
+}
+
+func cardBalanceEvent(u *service.CardBalanceUpdate) CardBalanceEvent {
+	s := u.State
+	event := CardBalanceEvent{
+		CardID:       s.CardId,
+		MaskedNumber: s.MaskedNumber,
+		CardType:     s.CardType,
+		CreditLimit:  s.CreditLimit,
+		Balance:      s.Balance,
+		IsActive:     s.IsActive,
+		Removed:      u.Removed,
+		Sequence:     s.Sequence,
+	}
+	if s.UpdatedAt != nil {
+		event.UpdatedAt = s.UpdatedAt.AsTime()
+	}
+	return event
+}
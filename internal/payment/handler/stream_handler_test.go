@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govo/internal/requestctx"
+)
+
+func TestIssueStreamToken(t *testing.T) {
+	tokens := NewStreamTokens("test-secret", time.Hour)
+	h := NewStreamHandler(nil, tokens)
+
+	customer := requestctx.Metadata{Actor: "user-7", CustomerID: 7, Role: requestctx.RoleCustomer}
+	admin := requestctx.Metadata{Actor: "support-1", Role: requestctx.RoleAdmin}
+
+	tests := []struct {
+		name     string
+		md       requestctx.Metadata
+		query    string
+		code     int
+		customer uint
+	}{
+		{"own customer", customer, "", http.StatusOK, 7},
+		{"own customer explicit", customer, "?customer_id=7", http.StatusOK, 7},
+		{"another customer", customer, "?customer_id=8", http.StatusForbidden, 0},
+		{"anonymous", requestctx.Metadata{}, "?customer_id=7", http.StatusUnauthorized, 0},
+		{"actor without customer", requestctx.Metadata{Actor: "svc"}, "?customer_id=7", http.StatusForbidden, 0},
+		{"admin for customer", admin, "?customer_id=8", http.StatusOK, 8},
+		{"admin without customer", admin, "", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/payments/stream/token"+tt.query, nil)
+			req = req.WithContext(requestctx.WithMetadata(context.Background(), tt.md))
+			w := httptest.NewRecorder()
+			h.IssueToken(w, req)
+
+			if w.Code != tt.code {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+			var resp StreamTokenResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			got, _, err := tokens.Verify(resp.Token)
+			if err != nil || got != tt.customer {
+				t.Errorf("token customer = %d, %v; want %d", got, err, tt.customer)
+			}
+		})
+	}
+}
@@ -12,10 +12,23 @@ type Payment struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	CustomerID  uint    `gorm:"not null" json:"customer_id"`
-	CardID      uint    `json:"card_id"` // Optional, for card payments
-	Amount      float64 `gorm:"not null" json:"amount"`
-	PaymentType string  `gorm:"size:10;not null" json:"payment_type"` // "CARD" or "CASH"
-	Status      string  `gorm:"size:20;not null" json:"status"`       // "PENDING", "PROCESSING", "COMPLETED", "FAILED", "CANCELLED"
-	Description string  `json:"description"`
+	CustomerID    uint    `gorm:"not null" json:"customer_id"`
+	CardID        uint    `json:"card_id"`        // Optional, for card payments
+	AccountID     uint    `json:"account_id"`     // Debited account for cash payments
+	BeneficiaryID uint    `json:"beneficiary_id"` // Optional saved payee
+	Amount        float64 `gorm:"not null" json:"amount"`
+	PaymentType   string  `gorm:"size:10;not null" json:"payment_type"` // "CARD" or "CASH"
+	Status        string  `gorm:"size:20;not null" json:"status"`       // "PENDING", "PROCESSING", "COMPLETED", "FAILED", "CANCELLED"
+	Description   string  `json:"description"`
+
+	// Incremented on every state change and carried in the events as the
+	// aggregate sequence, so consumers can tell CANCELLED from a stale CREATED.
+	Sequence uint64 `gorm:"not null;default:0" json:"sequence"`
+
+	// Version of the last stored event applied to this row. The payments
+	// table is a projection of the event store; see PaymentEvent.
+	Version uint64 `gorm:"not null;default:0" json:"version"`
+
+	// Events raised by commands and not yet saved
+	changes []*PaymentEvent
 }
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTransition is returned by a command when the payment is not in a
+// status the command can run from.
+var ErrInvalidTransition = errors.New("payment is not in an expected status")
+
+// ErasedText replaces free text that may contain personal data.
+const ErasedText = "[erased]"
+
+// NewPayment raises the PaymentCreated event of a payment. The ID is taken
+// from the projection's sequence before the payment is saved.
+func NewPayment(id uint, details PaymentEventData) (*Payment, error) {
+	payment := &Payment{ID: id}
+	if err := payment.raise(PaymentCreated, details); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Start moves a pending payment to PROCESSING. It is not published, so the
+// event sequence stays the same.
+func (p *Payment) Start() error {
+	if p.Status != "PENDING" {
+		return fmt.Errorf("%w: payment %d is %s", ErrInvalidTransition, p.ID, p.Status)
+	}
+	return p.raise(PaymentStarted, PaymentEventData{})
+}
+
+func (p *Payment) Complete() error {
+	if p.Status != "PROCESSING" {
+		return fmt.Errorf("%w: payment %d is %s", ErrInvalidTransition, p.ID, p.Status)
+	}
+	return p.raise(PaymentCompleted, PaymentEventData{})
+}
+
+func (p *Payment) Fail(reason string) error {
+	if p.Status != "PENDING" && p.Status != "PROCESSING" {
+		return fmt.Errorf("%w: payment %d is %s", ErrInvalidTransition, p.ID, p.Status)
+	}
+	return p.raise(PaymentFailed, PaymentEventData{Reason: reason})
+}
+
+func (p *Payment) Cancel(reason string) error {
+	if p.Status != "PENDING" && p.Status != "PROCESSING" {
+		return fmt.Errorf("%w: payment %d is %s", ErrInvalidTransition, p.ID, p.Status)
+	}
+	return p.raise(PaymentCancelled, PaymentEventData{Reason: reason})
+}
+
+// Erase clears the description. The payment itself is kept for the legal
+// retention period; erasing an already erased payment raises nothing.
+func (p *Payment) Erase() error {
+	if p.Description == "" || p.Description == ErasedText {
+		return nil
+	}
+	return p.raise(PaymentErased, PaymentEventData{})
+}
+
+// Import records the current state of a payment that has no events yet.
+func (p *Payment) Import() error {
+	if p.Version != 0 {
+		return fmt.Errorf("payment %d already has events", p.ID)
+	}
+	created := p.CreatedAt
+	current := *p
+	p.Sequence, p.Status = 0, ""
+	return p.raise(PaymentImported, PaymentEventData{
+		CustomerID:    current.CustomerID,
+		CardID:        current.CardID,
+		AccountID:     current.AccountID,
+		BeneficiaryID: current.BeneficiaryID,
+		Amount:        current.Amount,
+		PaymentType:   current.PaymentType,
+		Description:   current.Description,
+		Status:        current.Status,
+		Sequence:      current.Sequence,
+		CreatedAt:     &created,
+	})
+}
+
+// Changes returns the events raised since the payment was loaded.
+func (p *Payment) Changes() []*PaymentEvent {
+	return p.changes
+}
+
+// ClearChanges is called once the changes are stored.
+func (p *Payment) ClearChanges() {
+	p.changes = nil
+}
+
+func (p *Payment) raise(eventType string, data PaymentEventData) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	event := &PaymentEvent{
+		PaymentID:  p.ID,
+		Version:    p.Version + 1,
+		Type:       eventType,
+		Data:       string(body),
+		OccurredAt: time.Now(),
+	}
+	if err := p.Apply(event); err != nil {
+		return err
+	}
+	p.changes = append(p.changes, event)
+	return nil
+}
+
+// Apply changes the payment by one stored event. Events must be applied in
+// version order without gaps.
+func (p *Payment) Apply(event *PaymentEvent) error {
+	if event.Version != p.Version+1 {
+		return fmt.Errorf("payment %d: event version %d does not follow %d", event.PaymentID, event.Version, p.Version)
+	}
+
+	var data PaymentEventData
+	if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+		return fmt.Errorf("payment %d: invalid %s event data: %v", event.PaymentID, event.Type, err)
+	}
+
+	switch event.Type {
+	case PaymentCreated:
+		p.CreatedAt = event.OccurredAt
+		p.CustomerID = data.CustomerID
+		p.CardID = data.CardID
+		p.AccountID = data.AccountID
+		p.BeneficiaryID = data.BeneficiaryID
+		p.Amount = data.Amount
+		p.PaymentType = data.PaymentType
+		p.Description = data.Description
+		p.Status = "PENDING"
+		p.Sequence++
+	case PaymentStarted:
+		p.Status = "PROCESSING"
+	case PaymentCompleted:
+		p.Status = "COMPLETED"
+		p.Sequence++
+	case PaymentFailed:
+		p.Status = "FAILED"
+		p.Sequence++
+	case PaymentCancelled:
+		p.Status = "CANCELLED"
+		p.Description = fmt.Sprintf("Cancelled: %s", data.Reason)
+		p.Sequence++
+	case PaymentErased:
+		p.Description = ErasedText
+	case PaymentImported:
+		p.CreatedAt = event.OccurredAt
+		if data.CreatedAt != nil {
+			p.CreatedAt = *data.CreatedAt
+		}
+		p.CustomerID = data.CustomerID
+		p.CardID = data.CardID
+		p.AccountID = data.AccountID
+		p.BeneficiaryID = data.BeneficiaryID
+		p.Amount = data.Amount
+		p.PaymentType = data.PaymentType
+		p.Description = data.Description
+		p.Status = data.Status
+		p.Sequence = data.Sequence
+	default:
+		return fmt.Errorf("payment %d: unknown event type %s", event.PaymentID, event.Type)
+	}
+
+	p.ID = event.PaymentID
+	p.Version = event.Version
+	p.UpdatedAt = event.OccurredAt
+	return nil
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// Event types stored in the payment event store
+const (
+	PaymentCreated   = "PaymentCreated"
+	PaymentStarted   = "PaymentStarted"
+	PaymentCompleted = "PaymentCompleted"
+	PaymentFailed    = "PaymentFailed"
+	PaymentCancelled = "PaymentCancelled"
+	PaymentErased    = "PaymentErased"
+
+	// State of a payment created before the event store existed
+	PaymentImported = "PaymentImported"
+)
+
+// PaymentEvent is an append-only record of a change to a payment. A payment
+// is the result of applying its events in version order; the unique
+// (payment_id, version) index rejects a second writer appending the same
+// version, which is how concurrent commands on a payment are detected.
+type PaymentEvent struct {
+	ID         uint64    `gorm:"primarykey" json:"id"`
+	PaymentID  uint      `gorm:"not null;uniqueIndex:idx_payment_events_version" json:"payment_id"`
+	Version    uint64    `gorm:"not null;uniqueIndex:idx_payment_events_version" json:"version"`
+	Type       string    `gorm:"size:40;not null" json:"type"`
+	Data       string    `gorm:"type:jsonb;not null" json:"data"`
+	Actor      string    `gorm:"size:100" json:"actor,omitempty"`
+	RequestID  string    `gorm:"size:100" json:"request_id,omitempty"`
+	OccurredAt time.Time `gorm:"not null" json:"occurred_at"`
+}
+
+// PaymentEventData is the JSON body of a PaymentEvent. Each event type only
+// sets the fields it changes.
+type PaymentEventData struct {
+	CustomerID    uint    `json:"customer_id,omitempty"`
+	CardID        uint    `json:"card_id,omitempty"`
+	AccountID     uint    `json:"account_id,omitempty"`
+	BeneficiaryID uint    `json:"beneficiary_id,omitempty"`
+	Amount        float64 `json:"amount,omitempty"`
+	PaymentType   string  `json:"payment_type,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Reason        string  `json:"reason,omitempty"`
+
+	// Only set on PaymentImported
+	Status    string     `json:"status,omitempty"`
+	Sequence  uint64     `json:"sequence,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// PaymentSnapshot is the state of a payment at a version, so that loading a
+// payment with a long history only replays the events after it.
+type PaymentSnapshot struct {
+	PaymentID uint      `gorm:"primarykey;autoIncrement:false"`
+	Version   uint64    `gorm:"not null"`
+	State     string    `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
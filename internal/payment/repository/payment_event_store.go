@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"govo/internal/payment/model"
+	"govo/internal/requestctx"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ödemeler olaylarından kurulur. payment_events sadece eklenir; payments tablosu olaylardan
+// türetilen projeksiyondur ve olaylarla aynı transaction'da güncellenir.
+
+// Aynı ödemeye başka bir komut aynı sürümle olay ekledi
+var ErrConcurrencyConflict = errors.New("payment was changed concurrently")
+
+const (
+	// Bu kadar olayda bir ödemenin durumu snapshot olarak yazılır
+	snapshotInterval = 20
+	// Çakışmada komut ödeme yeniden yüklenerek bu kadar denenir
+	commandAttempts = 3
+
+	uniqueViolation = "23505"
+)
+
+// Yeni ödemenin ID'si projeksiyonun sırasından alınır, olaylar projeksiyondan önce yazılır
+func (r *PaymentRepository) NextID(ctx context.Context) (uint, error) {
+	var id uint
+	err := r.db.WithContext(ctx).Raw("SELECT nextval(pg_get_serial_sequence('payments', 'id'))").Scan(&id).Error
+	return id, err
+}
+
+// Ödemeyi son snapshot'ı ve sonraki olaylarından kurar
+func (r *PaymentRepository) Load(ctx context.Context, id uint) (*model.Payment, error) {
+	db := r.db.WithContext(ctx)
+	payment := &model.Payment{}
+
+	var snapshot model.PaymentSnapshot
+	err := db.Where("payment_id = ?", id).Take(&snapshot).Error
+	switch {
+	case err == nil:
+		if err := json.Unmarshal([]byte(snapshot.State), payment); err != nil {
+			return nil, fmt.Errorf("invalid snapshot of payment %d: %v", id, err)
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	var stored []*model.PaymentEvent
+	if err := db.Where("payment_id = ? AND version > ?", id, payment.Version).Order("version").Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	if payment.Version == 0 && len(stored) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	for _, event := range stored {
+		if err := payment.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+// Komutların ürettiği olayları ekler ve projeksiyonu günceller. Olaylar ödemenin yüklendiği
+// sürümden devam eder; araya başka bir olay girdiyse ErrConcurrencyConflict döner.
+func (r *PaymentRepository) Save(ctx context.Context, payment *model.Payment) error {
+	changes := payment.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	md := requestctx.FromContext(ctx)
+	for _, event := range changes {
+		event.Actor = md.Actor
+		event.RequestID = md.RequestID
+	}
+
+	// Dış transaction içinde savepoint olur; çakışmada dış transaction kullanılabilir kalır
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&changes).Error; err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				return fmt.Errorf("payment %d version %d: %w", payment.ID, changes[0].Version, ErrConcurrencyConflict)
+			}
+			return fmt.Errorf("failed to append payment events: %v", err)
+		}
+
+		if err := project(tx, payment); err != nil {
+			return err
+		}
+
+		// Son olaylar bir snapshot sınırını geçtiyse güncel durum saklanır
+		if payment.Version/snapshotInterval > (payment.Version-uint64(len(changes)))/snapshotInterval {
+			return saveSnapshot(tx, payment)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	payment.ClearChanges()
+	return nil
+}
+
+// Ödemeyi yükler, komutu uygular ve olaylarını kaydeder. Komut ErrInvalidTransition gibi bir
+// hata dönerse hiçbir şey yazılmaz; eşzamanlı değişiklikte ödeme yeniden yüklenip komut tekrar çalışır.
+func (r *PaymentRepository) Execute(ctx context.Context, id uint, command func(payment *model.Payment) error) (*model.Payment, error) {
+	for attempt := 1; ; attempt++ {
+		payment, err := r.Load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := command(payment); err != nil {
+			return nil, err
+		}
+
+		err = r.Save(ctx, payment)
+		if errors.Is(err, ErrConcurrencyConflict) && attempt < commandAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return payment, nil
+	}
+}
+
+// Ödemenin tüm olayları; denetim kaydı olarak sürüm sırasıyla döner
+func (r *PaymentRepository) History(ctx context.Context, id uint) ([]*model.PaymentEvent, error) {
+	var stored []*model.PaymentEvent
+	if err := r.db.WithContext(ctx).Where("payment_id = ?", id).Order("version").Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return stored, nil
+}
+
+// Projeksiyon satırını olaylardan yeniden yazar
+func (r *PaymentRepository) Rebuild(ctx context.Context, id uint) (*model.Payment, error) {
+	payment, err := r.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := project(r.db.WithContext(ctx), payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Olay deposundan önce oluşturulmuş ödemelerin o anki durumu PaymentImported olayı olarak yazılır.
+// Açılışta çalışır; olayı olan ödemeler atlanır.
+func (r *PaymentRepository) ImportLegacy(ctx context.Context) (int, error) {
+	var legacy []*model.Payment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("version = 0 AND NOT EXISTS (SELECT 1 FROM payment_events e WHERE e.payment_id = payments.id)").
+		Find(&legacy).Error
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	for _, payment := range legacy {
+		if err := payment.Import(); err != nil {
+			return imported, err
+		}
+		err := r.Save(ctx, payment)
+		if errors.Is(err, ErrConcurrencyConflict) {
+			// Başka bir instance aynı anda aktardı
+			continue
+		}
+		if err != nil {
+			return imported, err
+		}
+		imported++
+	}
+	return imported, nil
+}
+
+func project(tx *gorm.DB, payment *model.Payment) error {
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(payment).Error
+	if err != nil {
+		return fmt.Errorf("failed to update payment projection: %v", err)
+	}
+	return nil
+}
+
+func saveSnapshot(tx *gorm.DB, payment *model.Payment) error {
+	state, err := json.Marshal(payment)
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "payment_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "state", "created_at"}),
+	}).Create(&model.PaymentSnapshot{
+		PaymentID: payment.ID,
+		Version:   payment.Version,
+		State:     string(state),
+		CreatedAt: time.Now(),
+	}).Error
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"govo/internal/payment/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const paymentID = 7
+
+var (
+	selectSnapshot = regexp.QuoteMeta(`SELECT * FROM "payment_snapshots" WHERE payment_id = $1`)
+	selectEvents   = regexp.QuoteMeta(`SELECT * FROM "payment_events" WHERE payment_id = $1 AND version > $2 ORDER BY version`)
+	insertEvents   = regexp.QuoteMeta(`INSERT INTO "payment_events"`)
+	upsertPayment  = regexp.QuoteMeta(`INSERT INTO "payments"`)
+	upsertSnapshot = regexp.QuoteMeta(`INSERT INTO "payment_snapshots"`)
+)
+
+func newMockRepository(t *testing.T) (*PaymentRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		conn.Close()
+	})
+	return NewPaymentRepository(db), mock
+}
+
+func storedEvent(version uint64, eventType string, data model.PaymentEventData) *model.PaymentEvent {
+	body, _ := json.Marshal(data)
+	return &model.PaymentEvent{ID: version, PaymentID: paymentID, Version: version, Type: eventType, Data: string(body), OccurredAt: time.Now()}
+}
+
+func eventRows(events ...*model.PaymentEvent) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "payment_id", "version", "type", "data", "actor", "request_id", "occurred_at"})
+	for _, e := range events {
+		rows.AddRow(e.ID, e.PaymentID, e.Version, e.Type, e.Data, e.Actor, e.RequestID, e.OccurredAt)
+	}
+	return rows
+}
+
+var (
+	created = storedEvent(1, model.PaymentCreated, model.PaymentEventData{CustomerID: 42, CardID: 3, Amount: 100, PaymentType: "CARD", Description: "rent"})
+	started = storedEvent(2, model.PaymentStarted, model.PaymentEventData{})
+)
+
+// Snapshot yok, ödeme olaylarından kurulur
+func expectLoad(mock sqlmock.Sqlmock, events ...*model.PaymentEvent) {
+	mock.ExpectQuery(selectSnapshot).WithArgs(paymentID, 1).WillReturnRows(sqlmock.NewRows([]string{"payment_id", "version", "state", "created_at"}))
+	mock.ExpectQuery(selectEvents).WithArgs(paymentID, 0).WillReturnRows(eventRows(events...))
+}
+
+func expectSave(mock sqlmock.Sqlmock, snapshot bool) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertEvents).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectQuery(upsertPayment).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(paymentID))
+	if snapshot {
+		mock.ExpectExec(upsertSnapshot).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func expectConflict(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertEvents).WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+	mock.ExpectRollback()
+}
+
+// Aynı sürümü başka bir komut yazdıysa unique index ihlali ErrConcurrencyConflict olur
+func TestSaveConcurrencyConflict(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	expectLoad(mock, created)
+	expectConflict(mock)
+
+	payment, err := repo.Load(context.Background(), paymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := payment.Cancel("duplicate"); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.Save(context.Background(), payment)
+	if !errors.Is(err, ErrConcurrencyConflict) {
+		t.Fatalf("save = %v, want ErrConcurrencyConflict", err)
+	}
+	// Yazılamayan olaylar ödemede kalır
+	if len(payment.Changes()) != 1 {
+		t.Errorf("changes = %d, want 1", len(payment.Changes()))
+	}
+}
+
+// Çakışmada ödeme yeniden yüklenir ve komut araya giren olayın üzerinden tekrar çalışır
+func TestExecuteRetriesAfterConflict(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	expectLoad(mock, created)
+	expectConflict(mock)
+	expectLoad(mock, created, started)
+	expectSave(mock, false)
+
+	var seen []string
+	payment, err := repo.Execute(context.Background(), paymentID, func(payment *model.Payment) error {
+		seen = append(seen, payment.Status)
+		return payment.Cancel("customer request")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != 2 || seen[0] != "PENDING" || seen[1] != "PROCESSING" {
+		t.Errorf("command ran on %v, want [PENDING PROCESSING]", seen)
+	}
+	if payment.Status != "CANCELLED" || payment.Version != 3 {
+		t.Errorf("payment = %s v%d, want CANCELLED v3", payment.Status, payment.Version)
+	}
+	if len(payment.Changes()) != 0 {
+		t.Errorf("changes not cleared after save: %d", len(payment.Changes()))
+	}
+}
+
+// Çakışma sürerse komut sınırlı sayıda denenir
+func TestExecuteGivesUpAfterConflicts(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	for i := 0; i < commandAttempts; i++ {
+		expectLoad(mock, created)
+		expectConflict(mock)
+	}
+
+	calls := 0
+	_, err := repo.Execute(context.Background(), paymentID, func(payment *model.Payment) error {
+		calls++
+		return payment.Cancel("customer request")
+	})
+	if !errors.Is(err, ErrConcurrencyConflict) {
+		t.Fatalf("execute = %v, want ErrConcurrencyConflict", err)
+	}
+	if calls != commandAttempts {
+		t.Errorf("command calls = %d, want %d", calls, commandAttempts)
+	}
+}
+
+// Snapshot sadece yeni olaylar bir snapshotInterval sınırını geçtiğinde yazılır
+func TestSaveSnapshotBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  uint64
+		commands int
+		snapshot bool
+	}{
+		{name: "below the boundary", version: snapshotInterval - 2, commands: 1},
+		{name: "reaches the boundary", version: snapshotInterval - 1, commands: 1, snapshot: true},
+		{name: "crosses the boundary", version: snapshotInterval - 1, commands: 2, snapshot: true},
+		{name: "after the boundary", version: snapshotInterval, commands: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			expectSave(mock, tt.snapshot)
+
+			payment := &model.Payment{ID: paymentID, Status: "PENDING", Version: tt.version}
+			if err := payment.Start(); err != nil {
+				t.Fatal(err)
+			}
+			if tt.commands > 1 {
+				if err := payment.Complete(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := repo.Save(context.Background(), payment); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// Snapshot'tan sonra sadece sonraki olaylar okunur
+func TestLoadFromSnapshot(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	state, err := json.Marshal(&model.Payment{ID: paymentID, CustomerID: 42, Amount: 100, PaymentType: "CARD", Status: "PROCESSING", Sequence: 1, Version: snapshotInterval})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(selectSnapshot).WithArgs(paymentID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"payment_id", "version", "state", "created_at"}).AddRow(paymentID, snapshotInterval, string(state), time.Now()))
+	mock.ExpectQuery(selectEvents).WithArgs(paymentID, snapshotInterval).
+		WillReturnRows(eventRows(storedEvent(snapshotInterval+1, model.PaymentCompleted, model.PaymentEventData{})))
+
+	payment, err := repo.Load(context.Background(), paymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.Status != "COMPLETED" || payment.Version != snapshotInterval+1 || payment.Sequence != 2 {
+		t.Errorf("payment = %s v%d seq %d, want COMPLETED v%d seq 2", payment.Status, payment.Version, payment.Sequence, snapshotInterval+1)
+	}
+	if payment.CustomerID != 42 || payment.Amount != 100 {
+		t.Errorf("snapshot state lost: %+v", payment)
+	}
+}
+
+func TestLoadMissingPayment(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	expectLoad(mock)
+
+	if _, err := repo.Load(context.Background(), paymentID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("load = %v, want ErrRecordNotFound", err)
+	}
+}
@@ -2,13 +2,22 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
+	"govo/internal/inbox"
+	"govo/internal/outbox"
 	"govo/internal/payment/model"
+	"govo/internal/saga"
+	"govo/kafka"
 
 	"gorm.io/gorm"
 )
 
+// Ödeme komutun çalışabileceği durumlarda değil
+var ErrInvalidTransition = model.ErrInvalidTransition
+
 type PaymentRepository struct {
 	db *gorm.DB
 }
@@ -17,13 +26,19 @@ func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
 	return &PaymentRepository{db: db}
 }
 
-func (r *PaymentRepository) Create(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
-	if err := r.db.WithContext(ctx).Create(payment).Error; err != nil {
-		return nil, err
-	}
-	return payment, nil
+// fn içindeki repository ve outbox aynı transaction'ı kullanır, olaylar commit ile birlikte kalıcı olur
+func (r *PaymentRepository) Transaction(ctx context.Context, fn func(tx *PaymentRepository, events kafka.Publisher) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PaymentRepository{db: tx}, outbox.NewWriter(tx))
+	})
+}
+
+// Transaction dışında outbox'a yazar
+func (r *PaymentRepository) Outbox() kafka.Publisher {
+	return outbox.NewWriter(r.db)
 }
 
+// Projeksiyondan okur
 func (r *PaymentRepository) GetByID(ctx context.Context, id uint) (*model.Payment, error) {
 	var payment model.Payment
 	if err := r.db.WithContext(ctx).First(&payment, id).Error; err != nil {
@@ -55,10 +70,62 @@ func (r *PaymentRepository) List(ctx context.Context, customerID uint, status st
 	return payments, nil
 }
 
-func (r *PaymentRepository) Update(ctx context.Context, payment *model.Payment) error {
-	return r.db.WithContext(ctx).Save(payment).Error
+// Ödemenin saga'sını başlatır, Transaction içinde ödemeyle birlikte yazılmalıdır
+func (r *PaymentRepository) BeginSaga(sagaType string, paymentID uint) error {
+	return saga.Begin(r.db, sagaType, strconv.Itoa(int(paymentID)))
 }
 
-func (r *PaymentRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Payment{}, id).Error
+// Çalışan saga'yı telafiye yönlendirir
+func (r *PaymentRepository) CancelSaga(paymentID uint) (bool, error) {
+	return saga.RequestCancel(r.db, strconv.Itoa(int(paymentID)))
+}
+
+// Ödemeler yasal saklama süresi boyunca tutulur, sadece serbest metin alanları temizlenir.
+// Olay deposu denetim kaydı olarak değişmez kalır; tek istisna olaylardaki açıklama ve iptal
+// nedenlerinin silinmesidir. Her ödemeye silindiğini gösteren bir olay eklenir.
+func (r *PaymentRepository) AnonymizeByCustomerID(ctx context.Context, customerID uint, key inbox.Key) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := inbox.Claim(tx, key); err != nil {
+			return err
+		}
+
+		var ids []uint
+		if err := tx.Unscoped().Model(&model.Payment{}).Where("customer_id = ?", customerID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		count = int64(len(ids))
+		if len(ids) == 0 {
+			return nil
+		}
+
+		repo := &PaymentRepository{db: tx}
+		for _, id := range ids {
+			if _, err := repo.Execute(ctx, id, func(payment *model.Payment) error {
+				return payment.Erase()
+			}); err != nil {
+				return err
+			}
+		}
+		// Önceki olaylarda kalan açıklamalar ve müşterinin yazdığı iptal nedenleri
+		err := tx.Model(&model.PaymentEvent{}).
+			Where("payment_id IN ? AND data->>'description' IS NOT NULL", ids).
+			Update("data", gorm.Expr("jsonb_set(data, '{description}', to_jsonb(?::text))", model.ErasedText)).Error
+		if err != nil {
+			return fmt.Errorf("failed to erase payment event descriptions: %v", err)
+		}
+		err = tx.Model(&model.PaymentEvent{}).
+			Where("payment_id IN ? AND type = ? AND data->>'reason' IS NOT NULL", ids, model.PaymentCancelled).
+			Update("data", gorm.Expr("jsonb_set(data, '{reason}', to_jsonb(?::text))", model.ErasedText)).Error
+		if err != nil {
+			return fmt.Errorf("failed to erase payment cancel reasons: %v", err)
+		}
+		// Snapshot'lar da açıklamayı taşır, sonraki olaylarda yeniden yazılır
+		if err := tx.Where("payment_id IN ?", ids).Delete(&model.PaymentSnapshot{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+	return count, err
 }
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	cardpb "govo/api/proto/card"
+	"govo/internal/payment/model"
+)
+
+// Kart ödemesi saga'sının kart servisinden kullandığı çağrılar
+type CardHolds interface {
+	Authorize(ctx context.Context, payment *model.Payment) error
+	Capture(ctx context.Context, paymentID uint) error
+	Release(ctx context.Context, paymentID uint) error
+}
+
+// Kart servisindeki ödeme hold'ları için gRPC istemcisi. Çağrılar ödeme ID'siyle
+// tekilleşir, saga adımı tekrar çalıştığında limit iki kez ayrılmaz.
+type CardClient struct {
+	client cardpb.CardServiceClient
+}
+
+func NewCardClient(client cardpb.CardServiceClient) *CardClient {
+	return &CardClient{client: client}
+}
+
+func (c *CardClient) Authorize(ctx context.Context, payment *model.Payment) error {
+	_, err := c.client.AuthorizePayment(ctx, &cardpb.AuthorizePaymentRequest{
+		CardId:     uint32(payment.CardID),
+		CustomerId: uint32(payment.CustomerID),
+		PaymentId:  uint32(payment.ID),
+		Amount:     payment.Amount,
+	})
+	return err
+}
+
+func (c *CardClient) Capture(ctx context.Context, paymentID uint) error {
+	_, err := c.client.CapturePayment(ctx, &cardpb.CardHoldRequest{PaymentId: uint32(paymentID)})
+	return err
+}
+
+// Hold'u bırakır, bakiyeye işlendiyse geri alır; yetkilendirilmemiş ödemeyi de kapatır
+func (c *CardClient) Release(ctx context.Context, paymentID uint) error {
+	_, err := c.client.ReleasePayment(ctx, &cardpb.CardHoldRequest{PaymentId: uint32(paymentID)})
+	return err
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	customerpb "govo/api/proto/customer"
+	"govo/internal/inbox"
+)
+
+// Ödeme servisinin ve nakit ödeme saga'sının müşteri servisinden kullandığı çağrılar
+type CustomerAccounts interface {
+	ValidateAccount(ctx context.Context, accountID, customerID uint) error
+	DebitAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key) error
+	RefundAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key, reverses string) error
+	ResolveBeneficiary(ctx context.Context, beneficiaryID, customerID uint) (*customerpb.Beneficiary, error)
+}
+
+// Müşteri servisindeki hesaplar ve kayıtlı alıcılar için gRPC istemcisi
+type CustomerClient struct {
+	client customerpb.CustomerServiceClient
+}
+
+func NewCustomerClient(client customerpb.CustomerServiceClient) *CustomerClient {
+	return &CustomerClient{client: client}
+}
+
+// Hesabın aktif olduğunu ve müşterinin hesap üzerinde yetkili olduğunu doğrular
+func (c *CustomerClient) ValidateAccount(ctx context.Context, accountID, customerID uint) error {
+	resp, err := c.client.GetAccount(ctx, &customerpb.GetAccountRequest{Id: uint32(accountID)})
+	if err != nil {
+		return fmt.Errorf("account %d not found: %v", accountID, err)
+	}
+
+	if resp.Account.Status != "ACTIVE" {
+		return fmt.Errorf("account %d is %s", accountID, resp.Account.Status)
+	}
+
+	for _, holderID := range resp.Account.HolderIds {
+		if uint(holderID) == customerID {
+			return nil
+		}
+	}
+	return fmt.Errorf("customer %d is not a holder of account %d", customerID, accountID)
+}
+
+// Aynı (consumer, event_id) ile tekrar çağrıldığında müşteri servisi bakiyeyi tekrar değiştirmez
+func (c *CustomerClient) DebitAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key) error {
+	_, err := c.client.DebitAccount(ctx, adjustBalanceRequest(accountID, amount, key))
+	return err
+}
+
+// reverses ile yapılan borçlandırmayı iade eder; o borçlandırma hiç uygulanmadıysa bakiye değişmez
+func (c *CustomerClient) RefundAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key, reverses string) error {
+	req := adjustBalanceRequest(accountID, amount, key)
+	req.ReversesEventId = reverses
+	_, err := c.client.CreditAccount(ctx, req)
+	return err
+}
+
+func adjustBalanceRequest(accountID uint, amount float64, key inbox.Key) *customerpb.AdjustBalanceRequest {
+	return &customerpb.AdjustBalanceRequest{
+		AccountId:   uint32(accountID),
+		Amount:      amount,
+		EventId:     key.EventID,
+		Consumer:    key.Consumer,
+		AggregateId: key.AggregateID,
+		Sequence:    key.Sequence,
+	}
+}
+
+// Alıcının müşteriye ait olduğunu, onaylandığını ve bekleme süresinin dolduğunu doğrular
+func (c *CustomerClient) ResolveBeneficiary(ctx context.Context, beneficiaryID, customerID uint) (*customerpb.Beneficiary, error) {
+	resp, err := c.client.ResolveBeneficiary(ctx, &customerpb.ResolveBeneficiaryRequest{
+		Id:         uint32(beneficiaryID),
+		CustomerId: uint32(customerID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("beneficiary %d cannot be used: %v", beneficiaryID, err)
+	}
+	return resp.Beneficiary, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"govo/internal/inbox"
+	"govo/internal/payment/model"
+	"govo/internal/payment/repository"
+	"govo/internal/saga"
+	"govo/kafka"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	SagaCardPayment = "CARD_PAYMENT"
+	SagaCashPayment = "CASH_PAYMENT"
+
+	// Hesap hareketleri müşteri servisinde bu tüketici adıyla tekilleşir
+	sagaConsumer = "payment-saga"
+
+	remoteStepTimeout = 30 * time.Second
+	localStepTimeout  = 10 * time.Second
+)
+
+// Kart ödemesi: limitten yer ayır, bakiyeye işle, tamamla.
+// Nakit ödeme: hesaptan düş, tamamla.
+// Bir adım başarısız olursa hold bırakılır / hesap iade edilir ve ödeme FAILED olur.
+func NewPaymentSagas(customerClient CustomerAccounts, cardClient CardHolds) []*saga.Definition {
+	s := &paymentSagas{customerClient: customerClient, cardClient: cardClient, store: repositoryStoreFor}
+	return s.definitions()
+}
+
+func (s *paymentSagas) definitions() []*saga.Definition {
+	start := saga.Step{Name: "start", Timeout: localStepTimeout, Action: s.start, Compensate: s.fail}
+	complete := saga.Step{Name: "complete", Timeout: localStepTimeout, Action: s.complete}
+
+	return []*saga.Definition{
+		{
+			Type: SagaCardPayment,
+			Steps: []saga.Step{
+				start,
+				{Name: "authorize-card", Remote: true, Timeout: remoteStepTimeout, Action: s.authorizeCard, Compensate: s.releaseCard},
+				// Release bakiyeye işlenmiş tutarı da geri aldığı için ayrı telafisi yok
+				{Name: "capture-card", Remote: true, Timeout: remoteStepTimeout, Action: s.captureCard},
+				complete,
+			},
+		},
+		{
+			Type: SagaCashPayment,
+			Steps: []saga.Step{
+				start,
+				{Name: "debit-account", Remote: true, Timeout: remoteStepTimeout, Action: s.debitAccount, Compensate: s.refundAccount},
+				complete,
+			},
+		},
+	}
+}
+
+func sagaType(payment *model.Payment) string {
+	if payment.PaymentType == "CARD" {
+		return SagaCardPayment
+	}
+	return SagaCashPayment
+}
+
+type paymentSagas struct {
+	customerClient CustomerAccounts
+	cardClient     CardHolds
+	// Adımın transaction'ındaki depo ve outbox; uzak adımlarda tx transaction değildir, sadece okunur
+	store func(tx *gorm.DB) PaymentStore
+}
+
+func (s *paymentSagas) start(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	payment, err := s.payment(ctx, tx, inst)
+	if err != nil {
+		return err
+	}
+	if payment.Status == "PROCESSING" {
+		return nil
+	}
+
+	_, err = s.store(tx).Execute(ctx, payment.ID, func(payment *model.Payment) error {
+		return payment.Start()
+	})
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		return saga.Permanent(err)
+	}
+	return err
+}
+
+// Ödemeyi FAILED yapar ve olayını yayınlar; iptal edilmiş ödemenin durumu değişmez
+func (s *paymentSagas) fail(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	id, err := sagaPaymentID(inst)
+	if err != nil {
+		return err
+	}
+
+	payment, err := s.store(tx).Execute(ctx, id, func(payment *model.Payment) error {
+		return payment.Fail(inst.LastError)
+	})
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	event := paymentEvent(payment)
+	event.FailureReason = inst.LastError
+	return s.store(tx).Outbox().Publish(ctx, kafka.PaymentsTopic, kafka.EventPaymentFailed, event, paymentKey(payment)...)
+}
+
+func (s *paymentSagas) complete(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	id, err := sagaPaymentID(inst)
+	if err != nil {
+		return err
+	}
+
+	// İptal edildiyse tamamlanmaz, saga telafiye geçer
+	payment, err := s.store(tx).Execute(ctx, id, func(payment *model.Payment) error {
+		return payment.Complete()
+	})
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		return saga.Permanent(fmt.Errorf("payment %d is no longer processing", id))
+	}
+	if err != nil {
+		return err
+	}
+
+	event := paymentEvent(payment)
+	event.CompletedAt = timestamppb.New(payment.UpdatedAt)
+	return s.store(tx).Outbox().Publish(ctx, kafka.PaymentsTopic, kafka.EventPaymentCompleted, event, paymentKey(payment)...)
+}
+
+func (s *paymentSagas) authorizeCard(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	payment, err := s.payment(ctx, tx, inst)
+	if err != nil {
+		return err
+	}
+	return remoteError(s.cardClient.Authorize(ctx, payment))
+}
+
+func (s *paymentSagas) captureCard(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	id, err := sagaPaymentID(inst)
+	if err != nil {
+		return err
+	}
+	return remoteError(s.cardClient.Capture(ctx, id))
+}
+
+func (s *paymentSagas) releaseCard(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	id, err := sagaPaymentID(inst)
+	if err != nil {
+		return err
+	}
+	return s.cardClient.Release(ctx, id)
+}
+
+func (s *paymentSagas) debitAccount(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	payment, err := s.payment(ctx, tx, inst)
+	if err != nil {
+		return err
+	}
+	if payment.AccountID == 0 {
+		return saga.Permanent(fmt.Errorf("payment %d has no account to debit", payment.ID))
+	}
+	return remoteError(s.customerClient.DebitAccount(ctx, payment.AccountID, payment.Amount, debitKey(payment.ID)))
+}
+
+// Borçlandırma hiç uygulanmadıysa müşteri servisi iade etmez, geç gelen borçlandırmayı da reddeder
+func (s *paymentSagas) refundAccount(ctx context.Context, tx *gorm.DB, inst *saga.Instance) error {
+	payment, err := s.payment(ctx, tx, inst)
+	if err != nil {
+		return err
+	}
+	if payment.AccountID == 0 {
+		return nil
+	}
+	return s.customerClient.RefundAccount(ctx, payment.AccountID, payment.Amount, refundKey(payment.ID), debitKey(payment.ID).EventID)
+}
+
+func debitKey(paymentID uint) inbox.Key {
+	return inbox.Key{Consumer: sagaConsumer, EventID: fmt.Sprintf("payment-%d-debit", paymentID)}
+}
+
+func refundKey(paymentID uint) inbox.Key {
+	return inbox.Key{Consumer: sagaConsumer, EventID: fmt.Sprintf("payment-%d-refund", paymentID)}
+}
+
+func sagaPaymentID(inst *saga.Instance) (uint, error) {
+	id, err := strconv.Atoi(inst.AggregateID)
+	if err != nil {
+		return 0, saga.Permanent(fmt.Errorf("invalid payment id %q", inst.AggregateID))
+	}
+	return uint(id), nil
+}
+
+func (s *paymentSagas) payment(ctx context.Context, tx *gorm.DB, inst *saga.Instance) (*model.Payment, error) {
+	id, err := sagaPaymentID(inst)
+	if err != nil {
+		return nil, err
+	}
+	payment, err := s.store(tx).GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, saga.Permanent(fmt.Errorf("payment %d not found", id))
+	}
+	return payment, err
+}
+
+// Karşı servisin reddettiği istekler tekrar denenmez; ağ hataları ve zaman aşımları adımın süresi boyunca denenir
+func remoteError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition, codes.PermissionDenied:
+		return saga.Permanent(err)
+	}
+	return err
+}
@@ -4,26 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"govo/api/proto/events"
+	"govo/internal/inbox"
 	"govo/internal/payment/model"
 	"govo/internal/payment/repository"
+	"govo/internal/requestctx"
 	"govo/kafka"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Olaylar repository üzerinden outbox'a yazılır, Kafka'ya outbox.Relay gönderir
 type PaymentService struct {
-	repo        *repository.PaymentRepository
-	kafkaClient *kafka.Client
+	repo           PaymentStore
+	customerClient CustomerAccounts
+	updates        *kafka.Feed
+	cardStates     *kafka.Feed
 }
 
-func NewPaymentService(repo *repository.PaymentRepository, kafkaClient *kafka.Client) *PaymentService {
+func NewPaymentService(repo PaymentStore, customerClient CustomerAccounts) *PaymentService {
 	return &PaymentService{
-		repo:        repo,
-		kafkaClient: kafkaClient,
+		repo:           repo,
+		customerClient: customerClient,
 	}
 }
 
-func (s *PaymentService) CreatePayment(ctx context.Context, customerID, cardID uint, amount float64, paymentType, description string) (*model.Payment, error) {
+func (s *PaymentService) CreatePayment(ctx context.Context, customerID, cardID, accountID, beneficiaryID uint, amount float64, paymentType, description string) (*model.Payment, error) {
 	// Ödeme tipi kontrolü
 	if paymentType != "CARD" && paymentType != "CASH" {
 		return nil, errors.New("invalid payment type")
@@ -34,43 +43,71 @@ func (s *PaymentService) CreatePayment(ctx context.Context, customerID, cardID u
 		return nil, errors.New("card ID is required for card payments")
 	}
 
-	// Ödeme kaydı oluştur
-	payment := &model.Payment{
-		CustomerID:  customerID,
-		CardID:      cardID,
-		Amount:      amount,
-		PaymentType: paymentType,
-		Status:      "PENDING",
-		Description: description,
+	// Nakit ödeme seçilen hesaptan düşülür
+	if paymentType == "CASH" {
+		if accountID == 0 {
+			return nil, errors.New("account ID is required for cash payments")
+		}
+		if err := s.customerClient.ValidateAccount(ctx, accountID, customerID); err != nil {
+			return nil, err
+		}
 	}
 
-	// Ödeme kaydını veritabanına kaydet
-	payment, err := s.repo.Create(ctx, payment)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create payment: %v", err)
+	// Kayıtlı alıcıya yapılan ödemelerde açıklama boşsa alıcının varsayılan açıklaması kullanılır
+	if beneficiaryID != 0 {
+		beneficiary, err := s.customerClient.ResolveBeneficiary(ctx, beneficiaryID, customerID)
+		if err != nil {
+			return nil, err
+		}
+		if description == "" {
+			description = beneficiary.DefaultReference
+		}
 	}
 
-	// Kafka'ya ödeme olayını gönder
-	event := map[string]interface{}{
-		"event_type":   "PAYMENT_CREATED",
-		"payment_id":   payment.ID,
-		"customer_id":  payment.CustomerID,
-		"card_id":      payment.CardID,
-		"amount":       payment.Amount,
-		"payment_type": payment.PaymentType,
-		"status":       payment.Status,
-		"description":  payment.Description,
-		"created_at":   payment.CreatedAt,
-	}
-
-	if err := s.kafkaClient.SendMessage("payments", event); err != nil {
-		// Kafka hatası ödemeyi etkilemesin, sadece logla
-		fmt.Printf("Failed to send payment event to Kafka: %v\n", err)
+	// Ödeme olayı, projeksiyonu ve Kafka olayı aynı transaction'da yazılır, olay outbox üzerinden Kafka'ya gider
+	var payment *model.Payment
+	err := s.repo.Transaction(ctx, func(tx PaymentStore, outbox kafka.Publisher) error {
+		id, err := tx.NextID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to allocate payment ID: %v", err)
+		}
+		payment, err = model.NewPayment(id, model.PaymentEventData{
+			CustomerID:    customerID,
+			CardID:        cardID,
+			AccountID:     accountID,
+			BeneficiaryID: beneficiaryID,
+			Amount:        amount,
+			PaymentType:   paymentType,
+			Description:   description,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create payment: %v", err)
+		}
+		// Bakiye hareketlerini saga yürütür, commit'ten sonra orchestrator alır
+		if err := tx.BeginSaga(sagaType(payment), payment.ID); err != nil {
+			return fmt.Errorf("failed to start payment saga: %v", err)
+		}
+
+		event := paymentEvent(payment)
+		event.CreatedAt = timestamppb.New(payment.CreatedAt)
+		return outbox.Publish(ctx, kafka.PaymentsTopic, kafka.EventPaymentCreated, event, paymentKey(payment)...)
+	})
+	if err != nil {
+		return nil, err
 	}
+	requestctx.Logf(ctx, "Payment %d created for customer %d", payment.ID, payment.CustomerID)
 
 	return payment, nil
 }
 
+// Ödemenin değişiklik geçmişi; kim, ne zaman, hangi istekle
+func (s *PaymentService) PaymentHistory(ctx context.Context, id uint) ([]*model.PaymentEvent, error) {
+	return s.repo.History(ctx, id)
+}
+
 func (s *PaymentService) GetPayment(ctx context.Context, id uint) (*model.Payment, error) {
 	return s.repo.GetByID(ctx, id)
 }
@@ -80,41 +117,91 @@ func (s *PaymentService) ListPayments(ctx context.Context, customerID uint, stat
 }
 
 func (s *PaymentService) CancelPayment(ctx context.Context, id uint, reason string) error {
-	payment, err := s.repo.GetByID(ctx, id)
-	if err != nil {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
 		return fmt.Errorf("payment not found: %v", err)
 	}
 
-	// Sadece PENDING veya PROCESSING durumundaki ödemeler iptal edilebilir
-	if payment.Status != "PENDING" && payment.Status != "PROCESSING" {
-		return errors.New("only pending or processing payments can be cancelled")
-	}
+	return s.repo.Transaction(ctx, func(tx PaymentStore, outbox kafka.Publisher) error {
+		// Sadece PENDING veya PROCESSING durumundaki ödemeler iptal edilebilir; saga aynı anda
+		// ödemeyi tamamlarsa olaylardan biri sürüm çakışmasına düşer ve güncel durumla tekrar denenir
+		payment, err := tx.Execute(ctx, id, func(payment *model.Payment) error {
+			return payment.Cancel(reason)
+		})
+		if errors.Is(err, repository.ErrInvalidTransition) {
+			return errors.New("only pending or processing payments can be cancelled")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to cancel payment: %v", err)
+		}
+
+		// Yapılmış hold ve borçlandırmalar saga'nın telafileriyle geri alınır
+		if _, err := tx.CancelSaga(payment.ID); err != nil {
+			return fmt.Errorf("failed to cancel payment saga: %v", err)
+		}
+
+		event := paymentEvent(payment)
+		event.CancelledAt = timestamppb.Now()
+		return outbox.Publish(ctx, kafka.PaymentsTopic, kafka.EventPaymentCancelled, event, paymentKey(payment)...)
+	})
+}
 
-	// Ödeme durumunu güncelle
-	payment.Status = "CANCELLED"
-	payment.Description = fmt.Sprintf("Cancelled: %s", reason)
+// Müşteri silme talebinde ödemeler saklanır, açıklamalardaki olası kişisel veriler temizlenir
+func (s *PaymentService) HandleErasureRequested(ctx context.Context, event *events.CustomerErasureRequested) error {
+	if event.ErasureId == 0 || event.CustomerId == 0 {
+		return errors.New("invalid erasure requested event")
+	}
 
-	if err := s.repo.Update(ctx, payment); err != nil {
-		return fmt.Errorf("failed to cancel payment: %v", err)
+	report := &events.CustomerErasureCompleted{
+		ErasureId:  event.ErasureId,
+		CustomerId: event.CustomerId,
+		Service:    "payment",
 	}
 
-	// Kafka'ya iptal olayını gönder
-	event := map[string]interface{}{
-		"event_type":   "PAYMENT_CANCELLED",
-		"payment_id":   payment.ID,
-		"customer_id":  payment.CustomerID,
-		"card_id":      payment.CardID,
-		"amount":       payment.Amount,
-		"payment_type": payment.PaymentType,
-		"status":       payment.Status,
-		"description":  payment.Description,
-		"cancelled_at": time.Now(),
+	key := kafka.WithKey(strconv.Itoa(int(event.CustomerId)))
+
+	// Rapor anonimleştirmeyle aynı transaction'da outbox'a yazılır
+	err := s.repo.Transaction(ctx, func(tx PaymentStore, outbox kafka.Publisher) error {
+		count, err := tx.AnonymizeByCustomerID(ctx, uint(event.CustomerId), inbox.KeyFromContext(ctx))
+		if err != nil {
+			return err
+		}
+		report.AnonymizedRecords = count
+		report.RetainedRecords = count
+		return outbox.Publish(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureCompleted, report, key)
+	})
+	if errors.Is(err, inbox.ErrDuplicate) {
+		return nil
 	}
+	if err != nil {
+		// Hata raporu transaction geri alındıktan sonra ayrıca yazılır
+		report.AnonymizedRecords = 0
+		report.RetainedRecords = 0
+		report.Error = err.Error()
+		if err := s.repo.Outbox().Publish(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureCompleted, report, key); err != nil {
+			return fmt.Errorf("failed to send erasure report: %v", err)
+		}
+	}
+	return nil
+}
 
-	if err := s.kafkaClient.SendMessage("payments", event); err != nil {
-		// Kafka hatası işlemi etkilemesin, sadece logla
-		fmt.Printf("Failed to send cancellation event to Kafka: %v\n", err)
+// Aynı ödemenin olayları aynı partition'a gider ve sırayla işlenir
+func paymentKey(payment *model.Payment) []kafka.PublishOption {
+	return []kafka.PublishOption{
+		kafka.WithKey(strconv.Itoa(int(payment.ID))),
+		kafka.WithSequence(payment.Sequence),
 	}
+}
 
-	return nil
+func paymentEvent(payment *model.Payment) *events.PaymentEvent {
+	return &events.PaymentEvent{
+		PaymentId:     uint32(payment.ID),
+		CustomerId:    uint32(payment.CustomerID),
+		CardId:        uint32(payment.CardID),
+		AccountId:     uint32(payment.AccountID),
+		BeneficiaryId: uint32(payment.BeneficiaryID),
+		Amount:        payment.Amount,
+		PaymentType:   payment.PaymentType,
+		Status:        payment.Status,
+		Description:   payment.Description,
+	}
 }
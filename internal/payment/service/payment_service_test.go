@@ -0,0 +1,611 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	customerpb "govo/api/proto/customer"
+	"govo/api/proto/events"
+	"govo/internal/inbox"
+	"govo/internal/payment/model"
+	"govo/internal/saga"
+	"govo/kafka"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// Olay deposunu bellekte tutar; transaction içinde yayınlanan olaylar commit'te bus'a gider (outbox gibi)
+type memoryStore struct {
+	state   *memoryState
+	bus     *kafka.MemoryBus
+	pending *[]*kafka.Record // Transaction içindeyse nil değil
+}
+
+type memoryState struct {
+	mu      sync.Mutex
+	events  map[uint][]*model.PaymentEvent
+	sagas   map[uint]string
+	claimed map[string]bool
+	nextID  uint
+}
+
+func newMemoryStore(bus *kafka.MemoryBus) *memoryStore {
+	return &memoryStore{
+		bus: bus,
+		state: &memoryState{
+			events:  make(map[uint][]*model.PaymentEvent),
+			sagas:   make(map[uint]string),
+			claimed: make(map[string]bool),
+		},
+	}
+}
+
+// Transaction dışındaki çağrılar kilidi kendisi alır, içindekiler Transaction'ın kilidini kullanır
+func (s *memoryStore) lock() func() {
+	if s.pending != nil {
+		return func() {}
+	}
+	s.state.mu.Lock()
+	return s.state.mu.Unlock
+}
+
+func (s *memoryStore) Transaction(ctx context.Context, fn func(tx PaymentStore, events kafka.Publisher) error) error {
+	if s.pending != nil {
+		return fn(s, s.Outbox())
+	}
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	events := make(map[uint][]*model.PaymentEvent, len(s.state.events))
+	for id, stored := range s.state.events {
+		events[id] = stored
+	}
+	sagas := make(map[uint]string, len(s.state.sagas))
+	for id, sagaType := range s.state.sagas {
+		sagas[id] = sagaType
+	}
+	claimed := make(map[string]bool, len(s.state.claimed))
+	for key := range s.state.claimed {
+		claimed[key] = true
+	}
+
+	var pending []*kafka.Record
+	tx := &memoryStore{state: s.state, bus: s.bus, pending: &pending}
+	if err := fn(tx, tx.Outbox()); err != nil {
+		s.state.events, s.state.sagas, s.state.claimed = events, sagas, claimed
+		return err
+	}
+	for _, rec := range pending {
+		if err := s.bus.Send(ctx, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Outbox() kafka.Publisher {
+	return memoryOutbox{s}
+}
+
+type memoryOutbox struct {
+	store *memoryStore
+}
+
+func (o memoryOutbox) Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...kafka.PublishOption) error {
+	if o.store.pending == nil {
+		return o.store.bus.Publish(ctx, topic, eventType, payload, opts...)
+	}
+	rec, err := kafka.NewEventRecord(ctx, topic, eventType, payload, opts...)
+	if err != nil {
+		return err
+	}
+	*o.store.pending = append(*o.store.pending, rec)
+	return nil
+}
+
+func (o memoryOutbox) Send(ctx context.Context, rec *kafka.Record) error {
+	if o.store.pending == nil {
+		return o.store.bus.Send(ctx, rec)
+	}
+	*o.store.pending = append(*o.store.pending, rec)
+	return nil
+}
+
+func (o memoryOutbox) Close() error { return nil }
+
+func (s *memoryStore) NextID(ctx context.Context) (uint, error) {
+	defer s.lock()()
+	s.state.nextID++
+	return s.state.nextID, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, payment *model.Payment) error {
+	defer s.lock()()
+	return s.save(payment)
+}
+
+func (s *memoryStore) save(payment *model.Payment) error {
+	changes := payment.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+	if stored := uint64(len(s.state.events[payment.ID])); changes[0].Version != stored+1 {
+		return fmt.Errorf("payment %d version %d does not follow %d", payment.ID, changes[0].Version, stored)
+	}
+	// Geri alınabilmesi için her yazımda yeni slice
+	s.state.events[payment.ID] = append(append([]*model.PaymentEvent(nil), s.state.events[payment.ID]...), changes...)
+	payment.ClearChanges()
+	return nil
+}
+
+func (s *memoryStore) load(id uint) (*model.Payment, error) {
+	stored := s.state.events[id]
+	if len(stored) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	payment := &model.Payment{ID: id}
+	for _, event := range stored {
+		if err := payment.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+func (s *memoryStore) Execute(ctx context.Context, id uint, command func(payment *model.Payment) error) (*model.Payment, error) {
+	defer s.lock()()
+	payment, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := command(payment); err != nil {
+		return nil, err
+	}
+	if err := s.save(payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (s *memoryStore) GetByID(ctx context.Context, id uint) (*model.Payment, error) {
+	defer s.lock()()
+	return s.load(id)
+}
+
+func (s *memoryStore) History(ctx context.Context, id uint) ([]*model.PaymentEvent, error) {
+	defer s.lock()()
+	if len(s.state.events[id]) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.state.events[id], nil
+}
+
+func (s *memoryStore) List(ctx context.Context, customerID uint, status string, startDate, endDate *time.Time) ([]*model.Payment, error) {
+	defer s.lock()()
+	var payments []*model.Payment
+	for id := range s.state.events {
+		payment, err := s.load(id)
+		if err != nil {
+			return nil, err
+		}
+		if payment.CustomerID == customerID && (status == "" || payment.Status == status) {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+func (s *memoryStore) BeginSaga(sagaType string, paymentID uint) error {
+	defer s.lock()()
+	s.state.sagas[paymentID] = sagaType
+	return nil
+}
+
+func (s *memoryStore) CancelSaga(paymentID uint) (bool, error) {
+	return false, nil
+}
+
+func (s *memoryStore) AnonymizeByCustomerID(ctx context.Context, customerID uint, key inbox.Key) (int64, error) {
+	defer s.lock()()
+	if !key.IsZero() {
+		claim := key.Consumer + "/" + key.EventID
+		if s.state.claimed[claim] {
+			return 0, inbox.ErrDuplicate
+		}
+		s.state.claimed[claim] = true
+	}
+
+	var count int64
+	for id := range s.state.events {
+		payment, err := s.load(id)
+		if err != nil {
+			return 0, err
+		}
+		if payment.CustomerID != customerID {
+			continue
+		}
+		count++
+		if err := payment.Erase(); err != nil {
+			return 0, err
+		}
+		if err := s.save(payment); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// Müşteri servisi gibi bakiye yetmezse FailedPrecondition döner, aynı event_id ile iki kez borçlandırmaz
+type fakeAccounts struct {
+	mu       sync.Mutex
+	balances map[uint]float64
+	applied  map[string]bool
+	refunds  []string
+}
+
+func (a *fakeAccounts) ValidateAccount(ctx context.Context, accountID, customerID uint) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.balances[accountID]; !ok {
+		return fmt.Errorf("account %d not found", accountID)
+	}
+	return nil
+}
+
+func (a *fakeAccounts) DebitAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.applied[key.EventID] {
+		return nil
+	}
+	if a.balances[accountID] < amount {
+		return status.Error(codes.FailedPrecondition, "insufficient balance")
+	}
+	a.balances[accountID] -= amount
+	a.applied[key.EventID] = true
+	return nil
+}
+
+func (a *fakeAccounts) RefundAccount(ctx context.Context, accountID uint, amount float64, key inbox.Key, reverses string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.refunds = append(a.refunds, reverses)
+	if a.applied[reverses] && !a.applied[key.EventID] {
+		a.balances[accountID] += amount
+		a.applied[key.EventID] = true
+	}
+	return nil
+}
+
+func (a *fakeAccounts) ResolveBeneficiary(ctx context.Context, beneficiaryID, customerID uint) (*customerpb.Beneficiary, error) {
+	return &customerpb.Beneficiary{Id: uint32(beneficiaryID), CustomerId: uint32(customerID), DefaultReference: "rent"}, nil
+}
+
+type fakeCards struct {
+	mu       sync.Mutex
+	held     map[uint]bool
+	captured map[uint]bool
+}
+
+func (c *fakeCards) Authorize(ctx context.Context, payment *model.Payment) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.held[payment.ID] = true
+	return nil
+}
+
+func (c *fakeCards) Capture(ctx context.Context, paymentID uint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.held[paymentID] {
+		return status.Error(codes.FailedPrecondition, "no hold")
+	}
+	c.captured[paymentID] = true
+	return nil
+}
+
+func (c *fakeCards) Release(ctx context.Context, paymentID uint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.held, paymentID)
+	delete(c.captured, paymentID)
+	return nil
+}
+
+type paymentFixture struct {
+	bus      *kafka.MemoryBus
+	store    *memoryStore
+	accounts *fakeAccounts
+	cards    *fakeCards
+	service  *PaymentService
+	sagas    []*saga.Definition
+}
+
+// main.go'daki silme talebi consumer'ı bellek içi bus üzerinde çalışır
+func newPaymentFixture(t *testing.T) (*paymentFixture, context.Context) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	bus := kafka.NewMemoryBus(3)
+	t.Cleanup(func() {
+		cancel()
+		bus.Close()
+	})
+
+	f := &paymentFixture{
+		bus:      bus,
+		store:    newMemoryStore(bus),
+		accounts: &fakeAccounts{balances: map[uint]float64{10: 100}, applied: make(map[string]bool)},
+		cards:    &fakeCards{held: make(map[uint]bool), captured: make(map[uint]bool)},
+	}
+	f.service = NewPaymentService(f.store, f.accounts)
+	sagas := &paymentSagas{customerClient: f.accounts, cardClient: f.cards, store: func(*gorm.DB) PaymentStore { return f.store }}
+	f.sagas = sagas.definitions()
+
+	customerConsumer := kafka.NewConsumer(bus, "payment-erasure", kafka.CustomersTopic)
+	kafka.HandleEvent(customerConsumer, kafka.EventCustomerErasureRequested, f.service.HandleErasureRequested)
+	go customerConsumer.Start(ctx)
+	return f, ctx
+}
+
+func (f *paymentFixture) drain(t *testing.T, ctx context.Context, groupID, topic string) {
+	t.Helper()
+	if err := f.bus.Drain(ctx, groupID, topic); err != nil {
+		t.Fatalf("Drain %s: %v", groupID, err)
+	}
+}
+
+// Orchestrator gibi adımları sırayla çalıştırır; kalıcı hatada başarısız adım dahil geriye doğru telafi eder
+func (f *paymentFixture) runSaga(t *testing.T, payment *model.Payment) string {
+	t.Helper()
+	f.store.state.mu.Lock()
+	sagaType := f.store.state.sagas[payment.ID]
+	f.store.state.mu.Unlock()
+
+	var def *saga.Definition
+	for _, d := range f.sagas {
+		if d.Type == sagaType {
+			def = d
+		}
+	}
+	if def == nil {
+		t.Fatalf("payment %d has no saga (%q)", payment.ID, sagaType)
+	}
+
+	ctx := context.Background()
+	inst := &saga.Instance{Type: def.Type, AggregateID: strconv.Itoa(int(payment.ID)), Status: saga.StatusRunning}
+	for i, step := range def.Steps {
+		err := step.Action(ctx, nil, inst)
+		if err == nil {
+			continue
+		}
+		if !saga.IsPermanent(err) {
+			t.Fatalf("step %s: unexpected retryable error: %v", step.Name, err)
+		}
+		inst.LastError = fmt.Sprintf("%s: %v", step.Name, err)
+		for j := i; j >= 0; j-- {
+			if compensate := def.Steps[j].Compensate; compensate != nil {
+				if err := compensate(ctx, nil, inst); err != nil {
+					t.Fatalf("compensation of %s: %v", def.Steps[j].Name, err)
+				}
+			}
+		}
+		return saga.StatusCompensated
+	}
+	return saga.StatusCompleted
+}
+
+// Topic'e giden olay tipleri, yayınlanma sırasıyla
+func (f *paymentFixture) publishedEvents(t *testing.T, topic string) []string {
+	t.Helper()
+	var types []string
+	for _, rec := range f.bus.Records(topic) {
+		envelope, err := rec.Envelope()
+		if err != nil {
+			t.Fatalf("envelope: %v", err)
+		}
+		types = append(types, envelope.GetType())
+	}
+	return types
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPaymentCompletes(t *testing.T) {
+	tests := []struct {
+		name        string
+		paymentType string
+		cardID      uint
+		accountID   uint
+	}{
+		{"card", "CARD", 5, 0},
+		{"cash", "CASH", 0, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ctx := newPaymentFixture(t)
+			payment, err := f.service.CreatePayment(ctx, 7, tt.cardID, tt.accountID, 3, 40, tt.paymentType, "")
+			if err != nil {
+				t.Fatalf("CreatePayment: %v", err)
+			}
+			if payment.Description != "rent" {
+				t.Errorf("description = %q, want beneficiary default reference", payment.Description)
+			}
+
+			if got := f.runSaga(t, payment); got != saga.StatusCompleted {
+				t.Fatalf("saga = %s, want %s", got, saga.StatusCompleted)
+			}
+
+			stored, err := f.service.GetPayment(ctx, payment.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != "COMPLETED" {
+				t.Errorf("status = %s, want COMPLETED", stored.Status)
+			}
+			want := []string{kafka.EventPaymentCreated, kafka.EventPaymentCompleted}
+			if got := f.publishedEvents(t, kafka.PaymentsTopic); !equalStrings(got, want) {
+				t.Errorf("events = %v, want %v", got, want)
+			}
+
+			switch tt.paymentType {
+			case "CARD":
+				if !f.cards.captured[payment.ID] {
+					t.Error("card hold was not captured")
+				}
+			case "CASH":
+				if balance := f.accounts.balances[10]; balance != 60 {
+					t.Errorf("balance = %.2f, want 60", balance)
+				}
+			}
+		})
+	}
+}
+
+// Bakiye yetmezse borçlandırma telafi edilir ve ödeme FAILED olur
+func TestInsufficientBalanceCompensates(t *testing.T) {
+	f, ctx := newPaymentFixture(t)
+	payment, err := f.service.CreatePayment(ctx, 7, 0, 10, 0, 250, "CASH", "laptop")
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	if got := f.runSaga(t, payment); got != saga.StatusCompensated {
+		t.Fatalf("saga = %s, want %s", got, saga.StatusCompensated)
+	}
+
+	stored, err := f.service.GetPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "FAILED" {
+		t.Errorf("status = %s, want FAILED", stored.Status)
+	}
+	if balance := f.accounts.balances[10]; balance != 100 {
+		t.Errorf("balance = %.2f, want 100", balance)
+	}
+	// Uygulanmamış borçlandırmanın iadesi yine istenir; müşteri servisi onu çitler
+	if want := debitKey(payment.ID).EventID; len(f.accounts.refunds) != 1 || f.accounts.refunds[0] != want {
+		t.Errorf("refunds = %v, want [%s]", f.accounts.refunds, want)
+	}
+
+	want := []string{kafka.EventPaymentCreated, kafka.EventPaymentFailed}
+	if got := f.publishedEvents(t, kafka.PaymentsTopic); !equalStrings(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+// İptal edilen ödemenin saga'sı tamamlamaz, ödeme CANCELLED kalır
+func TestCancelledPaymentNotCompleted(t *testing.T) {
+	f, ctx := newPaymentFixture(t)
+	payment, err := f.service.CreatePayment(ctx, 7, 0, 10, 0, 40, "CASH", "laptop")
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	if err := f.service.CancelPayment(ctx, payment.ID, "changed my mind"); err != nil {
+		t.Fatalf("CancelPayment: %v", err)
+	}
+
+	if got := f.runSaga(t, payment); got != saga.StatusCompensated {
+		t.Fatalf("saga = %s, want %s", got, saga.StatusCompensated)
+	}
+	stored, err := f.service.GetPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "CANCELLED" {
+		t.Errorf("status = %s, want CANCELLED", stored.Status)
+	}
+	if balance := f.accounts.balances[10]; balance != 100 {
+		t.Errorf("balance = %.2f, want 100", balance)
+	}
+	want := []string{kafka.EventPaymentCreated, kafka.EventPaymentCancelled}
+	if got := f.publishedEvents(t, kafka.PaymentsTopic); !equalStrings(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	// İptal edilmiş ödeme tekrar iptal edilemez
+	if err := f.service.CancelPayment(ctx, payment.ID, "again"); err == nil {
+		t.Error("cancelled payment cancelled twice")
+	}
+}
+
+func TestCashPaymentRequiresAccount(t *testing.T) {
+	f, ctx := newPaymentFixture(t)
+
+	if _, err := f.service.CreatePayment(ctx, 7, 0, 0, 0, 40, "CASH", ""); err == nil {
+		t.Error("cash payment without an account accepted")
+	}
+	if _, err := f.service.CreatePayment(ctx, 7, 0, 99, 0, 40, "CASH", ""); err == nil {
+		t.Error("cash payment from an unknown account accepted")
+	}
+	if records := f.bus.Records(kafka.PaymentsTopic); len(records) != 0 {
+		t.Errorf("rejected payments published %d events", len(records))
+	}
+}
+
+// Aynı silme talebi iki kez teslim edilirse inbox ikincisini düşürür, tek rapor yayınlanır
+func TestDuplicateErasureDroppedByInbox(t *testing.T) {
+	f, ctx := newPaymentFixture(t)
+
+	payment, err := f.service.CreatePayment(ctx, 7, 0, 10, 0, 20, "CASH", "gift for Ayşe")
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	if err := f.bus.Publish(ctx, kafka.CustomersTopic, kafka.EventCustomerErasureRequested,
+		&events.CustomerErasureRequested{ErasureId: 1, CustomerId: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.bus.Send(ctx, f.bus.Records(kafka.CustomersTopic)[0]); err != nil {
+		t.Fatal(err)
+	}
+	f.drain(t, ctx, "payment-erasure", kafka.CustomersTopic)
+
+	var reports int
+	for _, eventType := range f.publishedEvents(t, kafka.CustomersTopic) {
+		if eventType == kafka.EventCustomerErasureCompleted {
+			reports++
+		}
+	}
+	if reports != 1 {
+		t.Errorf("erasure reports = %d, want 1", reports)
+	}
+
+	history, err := f.service.PaymentHistory(ctx, payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var erased int
+	for _, event := range history {
+		if event.Type == model.PaymentErased {
+			erased++
+		}
+	}
+	if erased != 1 {
+		t.Errorf("erased events = %d, want 1", erased)
+	}
+	stored, err := f.service.GetPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Description != model.ErasedText {
+		t.Errorf("description = %q, want %q", stored.Description, model.ErasedText)
+	}
+}
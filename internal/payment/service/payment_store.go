@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"govo/internal/inbox"
+	"govo/internal/payment/model"
+	"govo/internal/payment/repository"
+	"govo/kafka"
+
+	"gorm.io/gorm"
+)
+
+// Ödeme servisinin ve saga adımlarının kullandığı depo. Transaction içinde verilen store ve
+// publisher aynı transaction'ı kullanır; olaylar commit ile birlikte kalıcı olur.
+type PaymentStore interface {
+	Transaction(ctx context.Context, fn func(tx PaymentStore, events kafka.Publisher) error) error
+	Outbox() kafka.Publisher
+
+	NextID(ctx context.Context) (uint, error)
+	Save(ctx context.Context, payment *model.Payment) error
+	Execute(ctx context.Context, id uint, command func(payment *model.Payment) error) (*model.Payment, error)
+	GetByID(ctx context.Context, id uint) (*model.Payment, error)
+	History(ctx context.Context, id uint) ([]*model.PaymentEvent, error)
+	List(ctx context.Context, customerID uint, status string, startDate, endDate *time.Time) ([]*model.Payment, error)
+
+	BeginSaga(sagaType string, paymentID uint) error
+	CancelSaga(paymentID uint) (bool, error)
+	AnonymizeByCustomerID(ctx context.Context, customerID uint, key inbox.Key) (int64, error)
+}
+
+// PostgreSQL'deki olay deposu
+func NewRepositoryStore(repo *repository.PaymentRepository) PaymentStore {
+	return repositoryStore{repo}
+}
+
+// Saga adımları orchestrator'ın transaction'ını alır
+func repositoryStoreFor(tx *gorm.DB) PaymentStore {
+	return NewRepositoryStore(repository.NewPaymentRepository(tx))
+}
+
+type repositoryStore struct {
+	*repository.PaymentRepository
+}
+
+func (s repositoryStore) Transaction(ctx context.Context, fn func(tx PaymentStore, events kafka.Publisher) error) error {
+	return s.PaymentRepository.Transaction(ctx, func(tx *repository.PaymentRepository, events kafka.Publisher) error {
+		return fn(repositoryStore{tx}, events)
+	})
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/payment/model"
+	"govo/kafka"
+
+	"gorm.io/gorm"
+)
+
+// Token olmadan izlenen ödemenin ilk mesajı
+const UpdateSnapshot = "SNAPSHOT"
+
+var (
+	ErrWatchUnavailable   = errors.New("payment updates are not available")
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+)
+
+type PaymentUpdate struct {
+	Payment       *model.Payment
+	EventType     string
+	OccurredAt    time.Time
+	FailureReason string
+	ResumeToken   string
+}
+
+// Ödeme izleme payments topic'ini okuyan Feed üzerinden çalışır
+func (s *PaymentService) WithUpdates(feed *kafka.Feed) *PaymentService {
+	s.updates = feed
+	return s
+}
+
+func finalStatus(status string) bool {
+	return status == "COMPLETED" || status == "FAILED" || status == "CANCELLED"
+}
+
+// paymentID verilirse o ödemenin, yoksa müşterinin tüm ödemelerinin durum değişikliklerini gönderir.
+// Tek ödeme izlenirken token yoksa önce güncel durum gönderilir; ödeme sonuçlanınca izleme biter.
+func (s *PaymentService) WatchPayments(ctx context.Context, customerID, paymentID uint, resumeToken string, send func(*PaymentUpdate) error) error {
+	if s.updates == nil {
+		return ErrWatchUnavailable
+	}
+	if customerID == 0 && paymentID == 0 {
+		return errors.New("customer ID or payment ID is required")
+	}
+
+	var from kafka.Position
+	var lastSequence uint64
+	if resumeToken != "" {
+		var err error
+		if from, err = kafka.ParsePosition(s.updates.Topic(), resumeToken); err != nil {
+			return ErrInvalidResumeToken
+		}
+	} else {
+		// Konum veritabanı okumasından önce alınır, aradaki değişiklikler kaçmaz
+		var err error
+		if from, err = s.updates.Position(ctx); err != nil {
+			return err
+		}
+		if paymentID != 0 {
+			payment, err := s.repo.GetByID(ctx, paymentID)
+			if err != nil {
+				return err
+			}
+			if customerID != 0 && payment.CustomerID != customerID {
+				return fmt.Errorf("payment %d: %w", paymentID, gorm.ErrRecordNotFound)
+			}
+			lastSequence = payment.Sequence
+			err = send(&PaymentUpdate{
+				Payment:     payment,
+				EventType:   UpdateSnapshot,
+				OccurredAt:  payment.UpdatedAt,
+				ResumeToken: from.Token(s.updates.Topic()),
+			})
+			if err != nil || finalStatus(payment.Status) {
+				return err
+			}
+		}
+	}
+
+	updates, err := s.updates.Subscribe(ctx, from)
+	if err != nil {
+		return err
+	}
+	key := strconv.Itoa(int(paymentID))
+	for ev := range updates {
+		if ev.Err != nil {
+			return ev.Err
+		}
+		// Tek ödeme izlenirken diğer anahtarlar çözülmeden atlanır
+		if paymentID != 0 && string(ev.Record.Key) != key {
+			continue
+		}
+
+		envelope, err := ev.Record.Envelope()
+		if err != nil || envelope == nil {
+			continue
+		}
+		event := &events.PaymentEvent{}
+		if envelope.GetPayload().UnmarshalTo(event) != nil {
+			continue
+		}
+		if customerID != 0 && uint(event.CustomerId) != customerID {
+			continue
+		}
+		// Snapshot'ta zaten görülmüş olay
+		if paymentID != 0 && envelope.GetSequence() != 0 && envelope.GetSequence() <= lastSequence {
+			continue
+		}
+		lastSequence = envelope.GetSequence()
+
+		update := &PaymentUpdate{
+			Payment:       paymentFromEvent(event, envelope.GetSequence(), envelope.GetOccurredAt().AsTime()),
+			EventType:     envelope.GetType(),
+			OccurredAt:    envelope.GetOccurredAt().AsTime(),
+			FailureReason: event.FailureReason,
+			ResumeToken:   ev.Position.Token(s.updates.Topic()),
+		}
+		if err := send(update); err != nil {
+			return err
+		}
+		if paymentID != 0 && finalStatus(event.Status) {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+func paymentFromEvent(event *events.PaymentEvent, sequence uint64, occurredAt time.Time) *model.Payment {
+	payment := &model.Payment{
+		ID:            uint(event.PaymentId),
+		UpdatedAt:     occurredAt,
+		CustomerID:    uint(event.CustomerId),
+		CardID:        uint(event.CardId),
+		AccountID:     uint(event.AccountId),
+		BeneficiaryID: uint(event.BeneficiaryId),
+		Amount:        event.Amount,
+		PaymentType:   event.PaymentType,
+		Status:        event.Status,
+		Description:   event.Description,
+		Sequence:      sequence,
+	}
+	if event.CreatedAt != nil {
+		payment.CreatedAt = event.CreatedAt.AsTime()
+	}
+	return payment
+}
+
+// Müşterinin kartlarındaki bakiye, limit ve durum değişikliği; Removed ise kart silinmiştir
+type CardBalanceUpdate struct {
+	State       *events.CardState
+	Removed     bool
+	ResumeToken string
+}
+
+// Kart bakiyeleri kart servisinin yayınladığı compacted cards.state topic'inden okunur
+func (s *PaymentService) WithCardStates(feed *kafka.Feed) *PaymentService {
+	s.cardStates = feed
+	return s
+}
+
+// Müşterinin kartlarının değişikliklerini gönderir. Silinen kartın tombstone'u müşteri bilgisi
+// taşımadığından sadece bu izlemede görülmüş kartlar için bildirilir.
+func (s *PaymentService) WatchCardBalances(ctx context.Context, customerID uint, resumeToken string, send func(*CardBalanceUpdate) error) error {
+	if s.cardStates == nil {
+		return ErrWatchUnavailable
+	}
+
+	var from kafka.Position
+	var err error
+	if resumeToken != "" {
+		if from, err = kafka.ParsePosition(s.cardStates.Topic(), resumeToken); err != nil {
+			return ErrInvalidResumeToken
+		}
+	} else if from, err = s.cardStates.Position(ctx); err != nil {
+		return err
+	}
+
+	updates, err := s.cardStates.Subscribe(ctx, from)
+	if err != nil {
+		return err
+	}
+	cards := make(map[string]bool)
+	for ev := range updates {
+		if ev.Err != nil {
+			return ev.Err
+		}
+		key := string(ev.Record.Key)
+		token := ev.Position.Token(s.cardStates.Topic())
+
+		if ev.Record.IsTombstone() {
+			if !cards[key] {
+				continue
+			}
+			delete(cards, key)
+			id, _ := strconv.Atoi(key)
+			if err := send(&CardBalanceUpdate{State: &events.CardState{CardId: uint32(id), CustomerId: uint32(customerID)}, Removed: true, ResumeToken: token}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		envelope, err := ev.Record.Envelope()
+		if err != nil || envelope == nil {
+			continue
+		}
+		state := &events.CardState{}
+		if envelope.GetPayload().UnmarshalTo(state) != nil || uint(state.CustomerId) != customerID {
+			continue
+		}
+		cards[key] = true
+		if err := send(&CardBalanceUpdate{State: state, ResumeToken: token}); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// İki izlemenin şu anki konumları; ikisini birlikte devam ettiren istemciler (ör. SSE) için
+func (s *PaymentService) StreamPositions(ctx context.Context) (string, string, error) {
+	if s.updates == nil || s.cardStates == nil {
+		return "", "", ErrWatchUnavailable
+	}
+	payments, err := s.updates.Position(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	cards, err := s.cardStates.Position(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return payments.Token(s.updates.Topic()), cards.Token(s.cardStates.Topic()), nil
+}
@@ -0,0 +1,151 @@
+package requestctx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Kimlik gateway'in doğruladığı HS256 JWT'den okunur (Authorization: Bearer). Token'daki sub aktör,
+// customer_id müşteri, role rol olur; X-Actor-ID gibi istemcinin gönderdiği header'lara güvenilmez.
+// Servisler arası gRPC çağrılarında kimlik aynı anahtarla imzalanmış kısa ömürlü bir token ile taşınır.
+// Anahtar yalnızca GOVO_ENV=dev iken boş olabilir; o zaman kimlik header'lardan okunur.
+
+const (
+	HeaderAuthorization = "Authorization"
+
+	// Servislerin kendi ürettiği token'ların ömrü
+	serviceTokenTTL = 5 * time.Minute
+	// Saat farkı toleransı
+	clockSkew = 30 * time.Second
+)
+
+var tokenSecret atomic.Pointer[[]byte]
+
+// Süreçteki tüm middleware ve interceptor'lar bu anahtarı kullanır
+func UseTokenSecret(secret string) {
+	key := []byte(secret)
+	tokenSecret.Store(&key)
+}
+
+// GOVO_ENV=dev değilse boş sır hata döner; servisler açılışta kontrol eder
+func SecretFromEnv(name string) (string, error) {
+	secret := os.Getenv(name)
+	if secret == "" && os.Getenv("GOVO_ENV") != "dev" {
+		return "", fmt.Errorf("%s is required outside dev (GOVO_ENV=dev)", name)
+	}
+	return secret, nil
+}
+
+func secret() []byte {
+	if key := tokenSecret.Load(); key != nil {
+		return *key
+	}
+	return nil
+}
+
+type claims struct {
+	Subject    string `json:"sub"`
+	CustomerID uint   `json:"customer_id,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Expires    int64  `json:"exp"`
+	NotBefore  int64  `json:"nbf,omitempty"`
+}
+
+// Doğrulanamayan istek anonimdir
+func authenticate(get func(string) string) Metadata {
+	key := secret()
+	if len(key) == 0 {
+		md := Metadata{Actor: get(HeaderActorID), Role: get(HeaderActorRole)}
+		if id, err := strconv.ParseUint(get(HeaderCustomerID), 10, 32); err == nil {
+			md.CustomerID = uint(id)
+		}
+		return md
+	}
+
+	token, ok := strings.CutPrefix(get(HeaderAuthorization), "Bearer ")
+	if !ok {
+		return Metadata{}
+	}
+	c, err := verifyToken(key, token, time.Now())
+	if err != nil {
+		return Metadata{}
+	}
+	return Metadata{Actor: c.Subject, CustomerID: c.CustomerID, Role: c.Role}
+}
+
+// Giden gRPC çağrısının kimlik header'ları; kimlik yoksa boş
+func identityPairs(md Metadata) []string {
+	if md.Actor == "" && md.CustomerID == 0 && md.Role == "" {
+		return nil
+	}
+	key := secret()
+	if len(key) == 0 {
+		pairs := []string{HeaderActorID, md.Actor, HeaderActorRole, md.Role}
+		if md.CustomerID != 0 {
+			pairs = append(pairs, HeaderCustomerID, strconv.FormatUint(uint64(md.CustomerID), 10))
+		}
+		return pairs
+	}
+	return []string{HeaderAuthorization, "Bearer " + IssueToken(key, md, serviceTokenTTL)}
+}
+
+// Yönetici araçları ve testler kimliği imzalı token ile gönderir
+func IssueToken(key []byte, md Metadata, ttl time.Duration) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, _ := json.Marshal(claims{
+		Subject:    md.Actor,
+		CustomerID: md.CustomerID,
+		Role:       md.Role,
+		Expires:    time.Now().Add(ttl).Unix(),
+	})
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + sign(key, signed)
+}
+
+func verifyToken(key []byte, token string, now time.Time) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(raw, &header) != nil || header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported token header")
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(sign(key, parts[0]+"."+parts[1]))) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	var c claims
+	raw, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(raw, &c) != nil {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	if c.Expires == 0 || now.After(time.Unix(c.Expires, 0).Add(clockSkew)) {
+		return nil, fmt.Errorf("token expired")
+	}
+	if c.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(c.NotBefore, 0)) {
+		return nil, fmt.Errorf("token not valid yet")
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	return &c, nil
+}
+
+func sign(key []byte, signed string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
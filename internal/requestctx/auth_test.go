@@ -0,0 +1,143 @@
+package requestctx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func serve(t *testing.T, req *http.Request) Metadata {
+	t.Helper()
+	var md Metadata
+	HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md = FromContext(r.Context())
+	})).ServeHTTP(httptest.NewRecorder(), req)
+	return md
+}
+
+func TestHTTPIdentityFromToken(t *testing.T) {
+	key := []byte("test-secret")
+	UseTokenSecret(string(key))
+	defer UseTokenSecret("")
+
+	tests := []struct {
+		name      string
+		setup     func(r *http.Request)
+		actor     string
+		customer  uint
+		source    string
+		anonymous bool
+	}{
+		{
+			name: "customer token",
+			setup: func(r *http.Request) {
+				r.Header.Set(HeaderAuthorization, "Bearer "+IssueToken(key, Metadata{Actor: "user-7", CustomerID: 7, Role: RoleCustomer}, time.Minute))
+			},
+			actor: "user-7", customer: 7, source: SourceREST,
+		},
+		{
+			name: "admin token",
+			setup: func(r *http.Request) {
+				r.Header.Set(HeaderAuthorization, "Bearer "+IssueToken(key, Metadata{Actor: "support-1", Role: RoleAdmin}, time.Minute))
+			},
+			actor: "support-1", source: SourceAdmin,
+		},
+		{
+			name: "forged headers are ignored",
+			setup: func(r *http.Request) {
+				r.Header.Set(HeaderActorID, "admin")
+				r.Header.Set(HeaderCustomerID, "7")
+				r.Header.Set(HeaderActorRole, RoleAdmin)
+			},
+			source: SourceREST, anonymous: true,
+		},
+		{
+			name: "token signed with another key",
+			setup: func(r *http.Request) {
+				r.Header.Set(HeaderAuthorization, "Bearer "+IssueToken([]byte("other"), Metadata{Actor: "user-7", CustomerID: 7}, time.Minute))
+			},
+			source: SourceREST, anonymous: true,
+		},
+		{
+			name: "expired token",
+			setup: func(r *http.Request) {
+				r.Header.Set(HeaderAuthorization, "Bearer "+IssueToken(key, Metadata{Actor: "user-7", CustomerID: 7}, -time.Hour))
+			},
+			source: SourceREST, anonymous: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setup(req)
+			md := serve(t, req)
+			if tt.anonymous && (md.Actor != "" || md.CustomerID != 0 || md.Role != "") {
+				t.Fatalf("expected anonymous request, got %+v", md)
+			}
+			if md.Actor != tt.actor || md.CustomerID != tt.customer || md.Source != tt.source {
+				t.Errorf("metadata = %+v, want actor %q customer %d source %q", md, tt.actor, tt.customer, tt.source)
+			}
+		})
+	}
+}
+
+// Servisler arası çağrıda kimlik yeni bir token ile taşınır ve karşı tarafta aynen okunur
+func TestGRPCIdentityPropagation(t *testing.T) {
+	UseTokenSecret("test-secret")
+	defer UseTokenSecret("")
+
+	ctx := WithMetadata(context.Background(), Metadata{Actor: "support-1", Role: RoleAdmin, RequestID: "req-1"})
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	if err := UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if len(outgoing.Get(HeaderActorID)) != 0 {
+		t.Errorf("actor header sent in clear: %v", outgoing)
+	}
+
+	md := fromIncoming(metadata.NewIncomingContext(context.Background(), outgoing))
+	if md.Actor != "support-1" || md.Role != RoleAdmin || md.Source != SourceAdmin || md.RequestID != "req-1" {
+		t.Errorf("incoming metadata = %+v", md)
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+// Stream handler'ı da unary çağrılar gibi aktör ve kaynağı context'ten okur
+func TestStreamServerInterceptor(t *testing.T) {
+	key := []byte("test-secret")
+	UseTokenSecret(string(key))
+	defer UseTokenSecret("")
+
+	incoming := metadata.Pairs(
+		HeaderAuthorization, "Bearer "+IssueToken(key, Metadata{Actor: "user-7", CustomerID: 7, Role: RoleCustomer}, time.Minute),
+		HeaderRequestID, "req-9",
+	)
+	stream := &testServerStream{ctx: metadata.NewIncomingContext(context.Background(), incoming)}
+
+	var md Metadata
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		md = FromContext(ss.Context())
+		return nil
+	}
+	if err := StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/svc/Watch"}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if md.Actor != "user-7" || md.CustomerID != 7 || md.Source != SourceGRPC || md.RequestID != "req-9" {
+		t.Errorf("stream metadata = %+v", md)
+	}
+}
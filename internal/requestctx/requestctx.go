@@ -0,0 +1,171 @@
+package requestctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	SourceGRPC  = "grpc"
+	SourceREST  = "rest"
+	SourceAdmin = "admin"
+	SourceKafka = "kafka"
+
+	HeaderActorID     = "X-Actor-ID"
+	HeaderCustomerID  = "X-Customer-ID"
+	HeaderActorRole   = "X-Actor-Role"
+	HeaderRequestID   = "X-Request-ID"
+	HeaderTenantID    = "X-Tenant-ID"
+	HeaderTraceParent = "traceparent"
+
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+)
+
+// İsteği yapan kişi ve isteğin kaynağı, denetim kayıtlarında kullanılır. Aktör, müşteri ve rol
+// sadece token doğrulanırsa doldurulur (bkz. auth.go).
+// İstek kimliği ve trace bilgisi gRPC çağrıları ve Kafka olaylarıyla sonraki servislere taşınır.
+type Metadata struct {
+	Actor string
+	// Aktörün müşteri olarak işlem yaptığı müşteri; yönetici ve servis isteklerinde 0
+	CustomerID  uint
+	Role        string
+	Source      string
+	RequestID   string
+	Tenant      string
+	TraceParent string
+}
+
+// Yönetici araçlarından ve gateway'de yönetici rolüyle gelen istekler
+func (md Metadata) IsAdmin() bool {
+	return md.Role == RoleAdmin
+}
+
+type contextKey struct{}
+
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, contextKey{}, md)
+}
+
+func FromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(contextKey{}).(Metadata)
+	return md
+}
+
+// gRPC isteklerinde aktör ve istek kimliği metadata'dan okunur
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(WithMetadata(ctx, fromIncoming(ctx)), req)
+	}
+}
+
+// Stream'lerde de (WatchPayments gibi) handler'ın context'i aynı metadata'yı taşır
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: WithMetadata(ss.Context(), fromIncoming(ss.Context()))})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func fromIncoming(ctx context.Context) Metadata {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string { return first(incoming.Get(key)) }
+
+	md := authenticate(get)
+	md.Source = SourceGRPC
+	md.RequestID = get(HeaderRequestID)
+	md.Tenant = get(HeaderTenantID)
+	md.TraceParent = ChildSpan(get(HeaderTraceParent))
+	if md.RequestID == "" {
+		md.RequestID = NewRequestID()
+	}
+	if md.IsAdmin() {
+		md.Source = SourceAdmin
+	}
+	return md
+}
+
+// Giden gRPC çağrılarına aktör, istek kimliği, tenant ve traceparent eklenir
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md := FromContext(ctx)
+		// Kimlik bu servisin imzaladığı kısa ömürlü token ile taşınır
+		pairs := identityPairs(md)
+		if md.RequestID != "" {
+			pairs = append(pairs, HeaderRequestID, md.RequestID)
+		}
+		if md.Tenant != "" {
+			pairs = append(pairs, HeaderTenantID, md.Tenant)
+		}
+		if md.TraceParent != "" {
+			pairs = append(pairs, HeaderTraceParent, ChildSpan(md.TraceParent))
+		}
+		if len(pairs) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// REST isteklerinde aktör ve istek kimliği header'lardan okunur
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		md := fromHTTPHeader(c.Request.Header)
+		c.Header(HeaderRequestID, md.RequestID)
+		c.Request = c.Request.WithContext(WithMetadata(c.Request.Context(), md))
+		c.Next()
+	}
+}
+
+// gorilla/mux kullanan servisler için aynı middleware
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := fromHTTPHeader(r.Header)
+		w.Header().Set(HeaderRequestID, md.RequestID)
+		next.ServeHTTP(w, r.WithContext(WithMetadata(r.Context(), md)))
+	})
+}
+
+func fromHTTPHeader(h http.Header) Metadata {
+	md := authenticate(h.Get)
+	md.Source = SourceREST
+	md.RequestID = h.Get(HeaderRequestID)
+	md.Tenant = h.Get(HeaderTenantID)
+	md.TraceParent = ChildSpan(h.Get(HeaderTraceParent))
+	if md.RequestID == "" {
+		md.RequestID = NewRequestID()
+	}
+	if md.IsAdmin() {
+		md.Source = SourceAdmin
+	}
+	return md
+}
+
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,81 @@
+package requestctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// W3C Trace Context: "00-<trace-id>-<span-id>-<flags>".
+// Her servis gelen trace-id'yi korur, kendi işi için yeni bir span-id üretir.
+
+const traceVersion = "00"
+
+func NewTraceParent() string {
+	return fmt.Sprintf("%s-%s-%s-01", traceVersion, randomHex(16), randomHex(8))
+}
+
+// Aynı trace içinde yeni bir span başlatır; parent geçersizse yeni trace açılır
+func ChildSpan(parent string) string {
+	traceID, flags, ok := parseTraceParent(parent)
+	if !ok {
+		return NewTraceParent()
+	}
+	return fmt.Sprintf("%s-%s-%s-%s", traceVersion, traceID, randomHex(8), flags)
+}
+
+func TraceID(traceparent string) string {
+	traceID, _, ok := parseTraceParent(traceparent)
+	if !ok {
+		return ""
+	}
+	return traceID
+}
+
+func (md Metadata) TraceID() string {
+	return TraceID(md.TraceParent)
+}
+
+// Log satırının başına trace ve istek kimliğini ekler, zincir loglarda takip edilebilir
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	md := FromContext(ctx)
+	if md.RequestID == "" && md.TraceParent == "" {
+		log.Printf(format, args...)
+		return
+	}
+	log.Printf("[trace=%s request=%s] "+format, append([]interface{}{md.TraceID(), md.RequestID}, args...)...)
+}
+
+func parseTraceParent(s string) (traceID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+	traceID, spanID, flags := parts[1], parts[2], parts[3]
+	if !isHex(traceID, 32) || !isHex(spanID, 16) || !isHex(flags, 2) {
+		return "", "", false
+	}
+	if strings.Trim(traceID, "0") == "" || strings.Trim(spanID, "0") == "" {
+		return "", "", false
+	}
+	return traceID, flags, true
+}
+
+func isHex(s string, n int) bool {
+	if len(s) != n || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return strings.Repeat("0", 2*n-1) + "1"
+	}
+	return hex.EncodeToString(b)
+}
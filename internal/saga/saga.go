@@ -0,0 +1,432 @@
+package saga
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Birden fazla servise dokunan iş akışları adım adım yürütülür ve her adımdan sonra
+// durum veritabanına yazılır. Bir adım kalıcı hata verirse ya da süresi dolarsa tamamlanan
+// adımların telafileri ters sırayla çalıştırılır. Process yeniden başladığında yarıda kalan
+// saga'lar kaldığı adımdan devam eder.
+
+const (
+	StatusRunning      = "RUNNING"
+	StatusCompensating = "COMPENSATING"
+	StatusCompleted    = "COMPLETED"
+	StatusCompensated  = "COMPENSATED"
+)
+
+type Instance struct {
+	ID          uint64 `gorm:"primaryKey" json:"id"`
+	Type        string `gorm:"size:50;not null" json:"type"`
+	AggregateID string `gorm:"size:100;not null;uniqueIndex:idx_saga_aggregate" json:"aggregate_id"`
+	Status      string `gorm:"size:20;not null;index:idx_saga_due" json:"status"`
+	// RUNNING iken çalıştırılacak adım, COMPENSATING iken telafisi çalıştırılacak adım
+	Step            int       `gorm:"not null;default:0" json:"step"`
+	Attempts        int       `gorm:"not null;default:0" json:"attempts"`
+	LastError       string    `json:"last_error"`
+	StepStartedAt   time.Time `json:"step_started_at"`
+	NextRunAt       time.Time `gorm:"not null;index:idx_saga_due" json:"next_run_at"`
+	CancelRequested bool      `gorm:"not null;default:false" json:"cancel_requested"`
+	// Uzak adım çalışırken dolu; süre dolmadan başka bir tur saga'yı almaz
+	Lease       string     `gorm:"size:32" json:"-"`
+	LeasedUntil *time.Time `json:"leased_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (Instance) TableName() string {
+	return "sagas"
+}
+
+// Yerel adımlarda Action ve Compensate, saga satırını kilitleyen transaction ile çağrılır; yerel
+// değişiklikler adımın ilerlemesiyle birlikte commit edilir.
+// Remote adımlarda satır kilitli tutulmaz: adım kiralanıp commit edilir, çağrı transaction dışında
+// yapılır ve sonucu ikinci kısa bir transaction'da yazılır. tx bu durumda sadece okuma içindir.
+// Uzak çağrılar tekrar edilebilir olmalıdır, çünkü sonuç yazılmadan process çökerse adım yeniden çalışır.
+type Step struct {
+	Name string
+	// gRPC gibi uzak çağrı yapan adım; süresi boyunca veritabanı transaction'ı açık kalmaz
+	Remote bool
+	// Adımın tekrar denemeler dahil toplam süresi; dolarsa saga telafiye geçer.
+	// Telafinin her denemesi de bu süreyle sınırlanır.
+	Timeout time.Duration
+	Action  func(ctx context.Context, tx *gorm.DB, inst *Instance) error
+	// Adımın hiç uygulanmamış olabileceğini de kaldırmalıdır: süre dolan adımın
+	// uzak tarafta uygulanıp uygulanmadığı bilinmez, telafisi yine de çalıştırılır.
+	Compensate func(ctx context.Context, tx *gorm.DB, inst *Instance) error
+}
+
+type Definition struct {
+	Type  string
+	Steps []Step
+}
+
+// Tekrar denenmeden telafiye geçilmesi gereken hata
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+var ErrUnknownType = errors.New("unknown saga type")
+
+const (
+	// Timeout verilmeyen adımlar için
+	defaultStepTimeout = 30 * time.Second
+	// Uzak çağrı bittikten sonra sonucun yazılması için kiraya eklenen süre
+	leaseGrace = 5 * time.Second
+)
+
+// Saga'yı verilen transaction içinde başlatır; iş verisiyle birlikte commit edilince Orchestrator alır
+func Begin(tx *gorm.DB, sagaType, aggregateID string) error {
+	now := time.Now()
+	return tx.Create(&Instance{
+		Type:        sagaType,
+		AggregateID: aggregateID,
+		Status:      StatusRunning,
+		NextRunAt:   now,
+	}).Error
+}
+
+// Çalışan saga'yı durdurur, sıradaki turda tamamlanan adımlar telafi edilir.
+// Saga yoksa ya da çoktan bittiyse false döner.
+func RequestCancel(tx *gorm.DB, aggregateID string) (bool, error) {
+	result := tx.Model(&Instance{}).
+		Where("aggregate_id = ? AND status = ?", aggregateID, StatusRunning).
+		Updates(map[string]interface{}{"cancel_requested": true, "next_run_at": time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
+
+type Orchestrator struct {
+	db          *gorm.DB
+	definitions map[string]*Definition
+	batchSize   int
+	interval    time.Duration
+}
+
+func NewOrchestrator(db *gorm.DB) *Orchestrator {
+	return &Orchestrator{
+		db:          db,
+		definitions: make(map[string]*Definition),
+		batchSize:   50,
+		interval:    500 * time.Millisecond,
+	}
+}
+
+func (o *Orchestrator) Register(def *Definition) {
+	o.definitions[def.Type] = def
+}
+
+func (o *Orchestrator) Start(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := o.RunDue(ctx); err != nil {
+			log.Printf("Failed to run sagas: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Zamanı gelen saga'ları bekleme gerektiren bir adıma ya da sona kadar ilerletir.
+// Yeniden başlatmadan sonra yarıda kalanlar da burada alınır.
+func (o *Orchestrator) RunDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	var ids []uint64
+	if err := o.db.WithContext(ctx).Model(&Instance{}).
+		Where("status IN ? AND next_run_at <= ? AND (leased_until IS NULL OR leased_until <= ?)",
+			[]string{StatusRunning, StatusCompensating}, now, now).
+		Order("next_run_at").
+		Limit(o.batchSize).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		for {
+			more, err := o.advance(ctx, id)
+			if err != nil {
+				log.Printf("Failed to advance saga %d: %v", id, err)
+			}
+			if !more || err != nil || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return len(ids), nil
+}
+
+// Transaction dışında yapılacak uzak çağrı
+type remoteCall struct {
+	step         Step
+	fn           func(context.Context, *gorm.DB, *Instance) error
+	deadline     time.Time
+	compensating bool
+}
+
+// Saga'nın bir adımını çalıştırır; hemen devam edilebilecekse true döner
+func (o *Orchestrator) advance(ctx context.Context, id uint64) (bool, error) {
+	var (
+		inst Instance
+		def  *Definition
+		call *remoteCall
+		more bool
+	)
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Başka bir replika bu saga'yı çalıştırıyorsa ya da uzak adımı sürüyorsa atla
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND status IN ? AND next_run_at <= ? AND (leased_until IS NULL OR leased_until <= ?)",
+				id, []string{StatusRunning, StatusCompensating}, now, now).
+			Take(&inst).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		if def, ok = o.definitions[inst.Type]; !ok {
+			return fmt.Errorf("%w %q", ErrUnknownType, inst.Type)
+		}
+
+		if inst.Status == StatusRunning {
+			more, call = o.forward(ctx, tx, def, &inst)
+		} else {
+			more, call = o.backward(ctx, tx, def, &inst)
+		}
+		return tx.Save(&inst).Error
+	})
+	if err != nil || call == nil {
+		return more, err
+	}
+
+	// Kiralama commit edildi, satır kilitli değil
+	callErr := o.call(ctx, &inst, call)
+	return o.record(ctx, id, inst.Lease, call, callErr)
+}
+
+// Uzak adımın sonucunu kısa bir transaction'da yazar. Kira bu arada başka bir tura geçtiyse
+// (süre doldu, adım yeniden çalıştı) sonuç atılır; adım tekrar edilebilir olduğundan kayıp olmaz.
+func (o *Orchestrator) record(ctx context.Context, id uint64, lease string, call *remoteCall, callErr error) (bool, error) {
+	more := false
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var inst Instance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&inst, id).Error; err != nil {
+			return err
+		}
+		if inst.Lease != lease {
+			log.Printf("Saga %d (%s %s): lease of step %s expired, result dropped", inst.ID, inst.Type, inst.AggregateID, call.step.Name)
+			return nil
+		}
+		more = complete(&inst, call, callErr)
+		return tx.Save(&inst).Error
+	})
+	return more, err
+}
+
+func (o *Orchestrator) call(ctx context.Context, inst *Instance, call *remoteCall) error {
+	if !time.Now().Before(call.deadline) {
+		return context.DeadlineExceeded
+	}
+	ctx, cancel := context.WithDeadline(ctx, call.deadline)
+	defer cancel()
+	return call.fn(ctx, o.db.WithContext(ctx), inst)
+}
+
+// Uzak adımı bu tura ayırır; kira adımın süresi dolunca kendiliğinden biter
+func lease(inst *Instance, step Step, fn func(context.Context, *gorm.DB, *Instance) error, deadline time.Time, compensating bool) *remoteCall {
+	until := deadline.Add(leaseGrace)
+	inst.Lease = newLease()
+	inst.LeasedUntil = &until
+	return &remoteCall{step: step, fn: fn, deadline: deadline, compensating: compensating}
+}
+
+func complete(inst *Instance, call *remoteCall, err error) bool {
+	inst.Lease = ""
+	inst.LeasedUntil = nil
+	if call.compensating {
+		return compensated(inst, call.step, err, time.Now())
+	}
+	return stepped(inst, call.step, call.deadline, err, time.Now())
+}
+
+func (o *Orchestrator) forward(ctx context.Context, tx *gorm.DB, def *Definition, inst *Instance) (bool, *remoteCall) {
+	now := time.Now()
+
+	if inst.Step >= len(def.Steps) {
+		inst.Status = StatusCompleted
+		return false, nil
+	}
+	if inst.CancelRequested {
+		// Denenmiş ama sonuçlanmamış adım da telafi edilir
+		if inst.StepStartedAt.IsZero() {
+			inst.Step--
+		}
+		compensate(inst, "cancelled", now)
+		return true, nil
+	}
+
+	step := def.Steps[inst.Step]
+	if inst.StepStartedAt.IsZero() {
+		inst.StepStartedAt = now
+	}
+	deadline := inst.StepStartedAt.Add(timeout(step))
+
+	if step.Remote && now.Before(deadline) {
+		return false, lease(inst, step, step.Action, deadline, false)
+	}
+	return stepped(inst, step, deadline, o.run(ctx, tx, deadline, inst, step.Action), now), nil
+}
+
+// Adımın sonucunu saga'ya işler
+func stepped(inst *Instance, step Step, deadline time.Time, err error, now time.Time) bool {
+	if err == nil {
+		log.Printf("Saga %d (%s %s): step %s done", inst.ID, inst.Type, inst.AggregateID, step.Name)
+		inst.Step++
+		inst.Attempts = 0
+		inst.LastError = ""
+		inst.StepStartedAt = time.Time{}
+		inst.NextRunAt = now
+		return true
+	}
+
+	inst.Attempts++
+	inst.LastError = fmt.Sprintf("%s: %v", step.Name, err)
+	if IsPermanent(err) || !now.Before(deadline) {
+		log.Printf("Saga %d (%s %s): step %s failed, compensating: %v", inst.ID, inst.Type, inst.AggregateID, step.Name, err)
+		// Başarısız adımın kendisi de telafi edilir, uzak tarafta uygulanmış olabilir
+		compensate(inst, inst.LastError, now)
+		return true
+	}
+
+	inst.NextRunAt = now.Add(backoff(inst.Attempts))
+	if inst.NextRunAt.After(deadline) {
+		inst.NextRunAt = deadline
+	}
+	log.Printf("Saga %d (%s %s): step %s failed (attempt %d), retrying: %v", inst.ID, inst.Type, inst.AggregateID, step.Name, inst.Attempts, err)
+	return false
+}
+
+// Telafiler süresiz tekrar denenir, para hareketi yarıda bırakılmaz
+func (o *Orchestrator) backward(ctx context.Context, tx *gorm.DB, def *Definition, inst *Instance) (bool, *remoteCall) {
+	now := time.Now()
+
+	if inst.Step < 0 {
+		inst.Status = StatusCompensated
+		log.Printf("Saga %d (%s %s) compensated: %s", inst.ID, inst.Type, inst.AggregateID, inst.LastError)
+		return false, nil
+	}
+	if inst.Step >= len(def.Steps) {
+		inst.Step = len(def.Steps) - 1
+	}
+
+	step := def.Steps[inst.Step]
+	if step.Compensate == nil {
+		inst.Step--
+		return true, nil
+	}
+
+	deadline := now.Add(timeout(step))
+	if step.Remote {
+		return false, lease(inst, step, step.Compensate, deadline, true)
+	}
+	return compensated(inst, step, o.run(ctx, tx, deadline, inst, step.Compensate), now), nil
+}
+
+func compensated(inst *Instance, step Step, err error, now time.Time) bool {
+	if err != nil {
+		inst.Attempts++
+		inst.NextRunAt = now.Add(backoff(inst.Attempts))
+		log.Printf("Saga %d (%s %s): compensation of %s failed (attempt %d): %v", inst.ID, inst.Type, inst.AggregateID, step.Name, inst.Attempts, err)
+		return false
+	}
+
+	inst.Step--
+	inst.Attempts = 0
+	inst.NextRunAt = now
+	return true
+}
+
+// fn'in yerel değişiklikleri hata durumunda savepoint'e geri alınır, saga'nın durumu yine de kaydedilir
+func (o *Orchestrator) run(ctx context.Context, tx *gorm.DB, deadline time.Time, inst *Instance, fn func(context.Context, *gorm.DB, *Instance) error) error {
+	if !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	if err := tx.SavePoint("saga_step").Error; err != nil {
+		return err
+	}
+	if err := fn(ctx, tx.WithContext(ctx), inst); err != nil {
+		if rerr := tx.RollbackTo("saga_step").Error; rerr != nil {
+			return fmt.Errorf("%v (rollback failed: %v)", err, rerr)
+		}
+		return err
+	}
+	return nil
+}
+
+func timeout(step Step) time.Duration {
+	if step.Timeout <= 0 {
+		return defaultStepTimeout
+	}
+	return step.Timeout
+}
+
+func compensate(inst *Instance, reason string, now time.Time) {
+	inst.Status = StatusCompensating
+	inst.Attempts = 0
+	inst.LastError = reason
+	inst.StepStartedAt = time.Time{}
+	inst.NextRunAt = now
+}
+
+func newLease() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// 1s, 2s, 4s ... en fazla 1 dakika
+func backoff(attempts int) time.Duration {
+	d := time.Second << (attempts - 1)
+	if d <= 0 || d > time.Minute {
+		return time.Minute
+	}
+	return d
+}
@@ -0,0 +1,102 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func remoteDefinition(calls *int) *Definition {
+	call := func(context.Context, *gorm.DB, *Instance) error {
+		*calls++
+		return nil
+	}
+	return &Definition{
+		Type: "TEST",
+		Steps: []Step{
+			{Name: "reserve", Remote: true, Action: call, Compensate: call},
+			{Name: "charge", Remote: true, Action: call},
+		},
+	}
+}
+
+// Uzak adım transaction içinde çalışmaz, kiralanıp commit'ten sonra çağrılır
+func TestRemoteStepLeased(t *testing.T) {
+	calls := 0
+	def := remoteDefinition(&calls)
+	o := NewOrchestrator(nil)
+	inst := &Instance{Type: def.Type, Status: StatusRunning}
+
+	more, call := o.forward(context.Background(), nil, def, inst)
+	if more || call == nil {
+		t.Fatalf("forward = %v, %v, want a remote call", more, call)
+	}
+	if calls != 0 {
+		t.Fatal("remote step ran inside the transaction")
+	}
+	if inst.Lease == "" || inst.LeasedUntil == nil || !inst.LeasedUntil.After(call.deadline) {
+		t.Fatalf("lease = %q until %v, deadline %v", inst.Lease, inst.LeasedUntil, call.deadline)
+	}
+	if inst.StepStartedAt.IsZero() {
+		t.Fatal("step start not recorded with the lease")
+	}
+
+	if !complete(inst, call, nil) {
+		t.Fatal("completed step should continue")
+	}
+	if inst.Step != 1 || inst.Lease != "" || inst.LeasedUntil != nil || !inst.StepStartedAt.IsZero() {
+		t.Errorf("after success: step %d, lease %q until %v, started %v", inst.Step, inst.Lease, inst.LeasedUntil, inst.StepStartedAt)
+	}
+}
+
+func TestRemoteStepFailure(t *testing.T) {
+	calls := 0
+	def := remoteDefinition(&calls)
+	o := NewOrchestrator(nil)
+
+	inst := &Instance{Type: def.Type, Status: StatusRunning}
+	_, call := o.forward(context.Background(), nil, def, inst)
+	if complete(inst, call, errors.New("unavailable")) {
+		t.Fatal("retryable failure should wait for backoff")
+	}
+	if inst.Status != StatusRunning || inst.Attempts != 1 || inst.Lease != "" || !inst.NextRunAt.After(time.Now()) {
+		t.Errorf("after retryable failure: %s attempts %d lease %q next %v", inst.Status, inst.Attempts, inst.Lease, inst.NextRunAt)
+	}
+
+	_, call = o.forward(context.Background(), nil, def, inst)
+	complete(inst, call, Permanent(errors.New("declined")))
+	if inst.Status != StatusCompensating || inst.Step != 0 {
+		t.Fatalf("after permanent failure: %s step %d", inst.Status, inst.Step)
+	}
+
+	// Telafi de uzak adımdır
+	more, call := o.backward(context.Background(), nil, def, inst)
+	if more || call == nil || !call.compensating || calls != 0 {
+		t.Fatalf("backward = %v, %+v, calls %d", more, call, calls)
+	}
+	if !complete(inst, call, nil) || inst.Step != -1 {
+		t.Errorf("after compensation: step %d", inst.Step)
+	}
+}
+
+// Kiradayken gelen iptal, çağrısı yapılmış adımı da telafi eder
+func TestCancelAfterLeasedStep(t *testing.T) {
+	calls := 0
+	def := remoteDefinition(&calls)
+	o := NewOrchestrator(nil)
+
+	inst := &Instance{Type: def.Type, Status: StatusRunning}
+	o.forward(context.Background(), nil, def, inst)
+	// Process çöktü, kira doldu; sonuç yazılmadı
+	inst.Lease = ""
+	inst.LeasedUntil = nil
+	inst.CancelRequested = true
+
+	o.forward(context.Background(), nil, def, inst)
+	if inst.Status != StatusCompensating || inst.Step != 0 {
+		t.Errorf("cancel: %s step %d, want %s step 0", inst.Status, inst.Step, StatusCompensating)
+	}
+}
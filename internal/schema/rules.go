@@ -0,0 +1,171 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Yeni şema eski şemayla yazılmış veriyi okuyabilir mi (BACKWARD). Alan eklemek uyumludur;
+// silinen alanın numarası ve adı reserved olmalıdır, mevcut alanlar değişemez.
+func ProtoBackward(old, next *Proto) []string {
+	var problems []string
+	if old.Payload != next.Payload {
+		return []string{fmt.Sprintf("payload changed from %s to %s", old.Payload, next.Payload)}
+	}
+	for name, o := range old.Messages {
+		n, ok := next.Messages[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("message %s was removed", name))
+			continue
+		}
+		p, _ := CompareMessage(name, o, n)
+		problems = append(problems, p...)
+	}
+	return problems
+}
+
+// Okuyan şema yazan şemanın verisini çözebilir mi. Protobuf'ta bilinmeyen alanlar yok sayılır,
+// eksik alanlar varsayılan değeri alır; aynı numaralı alanlar ise aynı olmalıdır.
+// FORWARD kontrolünde okuyan eski, tüketicide okuyan tüketicinin kendi şemasıdır.
+func CanReadProto(reader, writer *Proto) []string {
+	if reader.Payload != writer.Payload {
+		return []string{fmt.Sprintf("payload %s cannot be read as %s", writer.Payload, reader.Payload)}
+	}
+
+	var problems []string
+	for name, w := range writer.Messages {
+		r, ok := reader.Messages[name]
+		if !ok {
+			continue
+		}
+		fields := make(map[int32]Field, len(r.Fields))
+		for _, f := range r.Fields {
+			fields[f.Number] = f
+		}
+		reserved := make(map[int32]bool, len(r.ReservedNumbers))
+		for _, n := range r.ReservedNumbers {
+			reserved[n] = true
+		}
+
+		for _, wf := range w.Fields {
+			if reserved[wf.Number] {
+				problems = append(problems, fmt.Sprintf("%s: field %s = %d uses a number reserved by the reader", name, wf.Name, wf.Number))
+				continue
+			}
+			rf, ok := fields[wf.Number]
+			if !ok {
+				continue
+			}
+			problems = append(problems, compareField(name, rf, wf)...)
+		}
+	}
+	return problems
+}
+
+// Eski ve yeni mesajı karşılaştırır; ikinci değer mesajın değişip değişmediğidir.
+// Silinen alanın numarası ve adı reserved olmalıdır, reserved numara tekrar kullanılamaz.
+func CompareMessage(name string, old, current Message) ([]string, bool) {
+	var problems []string
+
+	fields := make(map[int32]Field, len(current.Fields))
+	for _, f := range current.Fields {
+		fields[f.Number] = f
+	}
+	reservedNumbers := make(map[int32]bool)
+	for _, n := range current.ReservedNumbers {
+		reservedNumbers[n] = true
+	}
+	reservedNames := make(map[string]bool)
+	for _, n := range current.ReservedNames {
+		reservedNames[n] = true
+	}
+
+	for _, of := range old.Fields {
+		f, ok := fields[of.Number]
+		if !ok {
+			if !reservedNumbers[of.Number] || !reservedNames[of.Name] {
+				problems = append(problems, fmt.Sprintf("%s: field %s = %d was removed without reserving its number and name", name, of.Name, of.Number))
+			}
+			continue
+		}
+		problems = append(problems, compareField(name, of, f)...)
+	}
+
+	for _, n := range old.ReservedNumbers {
+		if _, ok := fields[n]; ok {
+			problems = append(problems, fmt.Sprintf("%s: reserved field number %d was reused", name, n))
+		}
+	}
+
+	oldNumbers := make(map[int32]bool, len(old.Fields))
+	for _, of := range old.Fields {
+		oldNumbers[of.Number] = true
+	}
+	diff := len(old.Fields) != len(current.Fields) || len(problems) > 0
+	for _, f := range current.Fields {
+		if !oldNumbers[f.Number] {
+			diff = true
+		}
+	}
+	return problems, diff
+}
+
+func compareField(message string, old, current Field) []string {
+	var problems []string
+	if current.Name != old.Name {
+		problems = append(problems, fmt.Sprintf("%s: field %d was renamed from %s to %s", message, old.Number, old.Name, current.Name))
+	}
+	if current.Type != old.Type {
+		problems = append(problems, fmt.Sprintf("%s: field %s changed type from %s to %s", message, old.Name, old.Type, current.Type))
+	}
+	if current.Cardinality != old.Cardinality {
+		problems = append(problems, fmt.Sprintf("%s: field %s changed cardinality from %s to %s", message, old.Name, old.Cardinality, current.Cardinality))
+	}
+	return problems
+}
+
+// Okuyan JSON şeması yazan şemaya uyan her belgeyi kabul eder mi
+func CanReadJSON(reader, writer *JSONSchema) []string {
+	return canReadJSON("$", reader, writer)
+}
+
+func canReadJSON(path string, reader, writer *JSONSchema) []string {
+	if reader == nil || writer == nil {
+		return nil
+	}
+
+	var problems []string
+	// integer her zaman number olarak okunabilir
+	if reader.Type != "" && reader.Type != writer.Type && !(reader.Type == "number" && writer.Type == "integer") {
+		return []string{fmt.Sprintf("%s: type %q cannot be read as %q", path, writer.Type, reader.Type)}
+	}
+
+	writerRequired := make(map[string]bool, len(writer.Required))
+	for _, name := range writer.Required {
+		writerRequired[name] = true
+	}
+	for _, name := range reader.Required {
+		if !writerRequired[name] {
+			problems = append(problems, fmt.Sprintf("%s: property %s is required by the reader but optional for the writer", path, name))
+		}
+	}
+
+	names := make([]string, 0, len(writer.Properties))
+	for name := range writer.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r, ok := reader.Properties[name]
+		if !ok {
+			if reader.AdditionalProperties != nil && !*reader.AdditionalProperties {
+				problems = append(problems, fmt.Sprintf("%s: property %s is not allowed by the reader", path, name))
+			}
+			continue
+		}
+		problems = append(problems, canReadJSON(path+"."+name, r, writer.Properties[name])...)
+	}
+
+	problems = append(problems, canReadJSON(path+"[]", reader.Items, writer.Items)...)
+	return problems
+}
@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"govo/api/proto/events"
+)
+
+const payment = "events.Payment"
+
+func proto(fields []Field, reserved ...Field) *Proto {
+	msg := Message{Fields: fields}
+	for _, f := range reserved {
+		msg.ReservedNumbers = append(msg.ReservedNumbers, f.Number)
+		msg.ReservedNames = append(msg.ReservedNames, f.Name)
+	}
+	return &Proto{Payload: payment, Messages: map[string]Message{payment: msg}}
+}
+
+var (
+	paymentID = Field{Number: 1, Name: "payment_id", Type: "uint32", Cardinality: "optional"}
+	amount    = Field{Number: 2, Name: "amount", Type: "double", Cardinality: "optional"}
+	base      = proto([]Field{paymentID, amount})
+)
+
+// Her değişiklik her kuralda; ok=false kaydın reddedilmesi gerektiğini gösterir
+func TestCheckProto(t *testing.T) {
+	changes := []struct {
+		name string
+		next *Proto
+		// Backward, Forward, Full
+		ok [3]bool
+	}{
+		{
+			name: "field added",
+			next: proto([]Field{paymentID, amount, {Number: 3, Name: "currency", Type: "string", Cardinality: "optional"}}),
+			ok:   [3]bool{true, true, true},
+		},
+		{
+			// Eski okuyucu eksik alanı varsayılan değerle okur, yeni okuyucu eski veride numarayı bilmez
+			name: "field removed",
+			next: proto([]Field{paymentID}),
+			ok:   [3]bool{false, true, false},
+		},
+		{
+			name: "field removed and reserved",
+			next: proto([]Field{paymentID}, amount),
+			ok:   [3]bool{true, true, true},
+		},
+		{
+			// Eski okuyucu yeni numarayı bilinmeyen alan olarak atlar
+			name: "field renumbered",
+			next: proto([]Field{paymentID, {Number: 3, Name: "amount", Type: "double", Cardinality: "optional"}}),
+			ok:   [3]bool{false, true, false},
+		},
+		{
+			name: "field type changed",
+			next: proto([]Field{paymentID, {Number: 2, Name: "amount", Type: "string", Cardinality: "optional"}}),
+			ok:   [3]bool{false, false, false},
+		},
+		{
+			name: "field renamed",
+			next: proto([]Field{paymentID, {Number: 2, Name: "total", Type: "double", Cardinality: "optional"}}),
+			ok:   [3]bool{false, false, false},
+		},
+	}
+	modes := []Compatibility{Backward, Forward, Full}
+
+	for _, tt := range changes {
+		for i, mode := range modes {
+			t.Run(tt.name+"/"+string(mode), func(t *testing.T) {
+				problems, err := Check(mode, TypeProtobuf, []string{base.String()}, tt.next.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ok := len(problems) == 0; ok != tt.ok[i] {
+					t.Errorf("compatible = %v, want %v (problems: %v)", ok, tt.ok[i], problems)
+				}
+			})
+		}
+	}
+}
+
+// Transitive olmayan kural sadece son sürüme bakar
+func TestCheckTransitive(t *testing.T) {
+	removed := proto([]Field{paymentID})
+	readded := proto([]Field{paymentID, {Number: 2, Name: "amount", Type: "string", Cardinality: "optional"}})
+	history := []string{base.String(), removed.String()}
+
+	problems, err := Check(Backward, TypeProtobuf, history, readded.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("backward against the latest version: %v", problems)
+	}
+
+	problems, err = Check(BackwardTransitive, TypeProtobuf, history, readded.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) == 0 {
+		t.Error("backward transitive accepted a type change against the first version")
+	}
+}
+
+func TestCheckNone(t *testing.T) {
+	changed := proto([]Field{{Number: 1, Name: "payment_id", Type: "string", Cardinality: "optional"}})
+	if problems, err := Check(None, TypeProtobuf, []string{base.String()}, changed.String()); err != nil || len(problems) != 0 {
+		t.Errorf("problems = %v, err = %v, want none", problems, err)
+	}
+	if _, err := Check(None, TypeProtobuf, nil, "{}"); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("err = %v, want ErrInvalidSchema", err)
+	}
+}
+
+func TestCanReadProto(t *testing.T) {
+	tests := []struct {
+		name   string
+		reader *Proto
+		writer *Proto
+		want   string
+	}{
+		{name: "same schema", reader: base, writer: base},
+		{name: "writer has extra field", reader: base, writer: proto([]Field{paymentID, amount, {Number: 3, Name: "currency", Type: "string", Cardinality: "optional"}})},
+		{name: "writer lacks a field", reader: base, writer: proto([]Field{paymentID})},
+		{
+			name:   "type changed",
+			reader: base,
+			writer: proto([]Field{paymentID, {Number: 2, Name: "amount", Type: "string", Cardinality: "optional"}}),
+			want:   "changed type from double to string",
+		},
+		{
+			name:   "cardinality changed",
+			reader: base,
+			writer: proto([]Field{paymentID, {Number: 2, Name: "amount", Type: "double", Cardinality: "repeated"}}),
+			want:   "changed cardinality",
+		},
+		{
+			name:   "writer uses a number the reader reserved",
+			reader: proto([]Field{paymentID}, amount),
+			writer: base,
+			want:   "reserved by the reader",
+		},
+		{
+			name:   "different payload",
+			reader: base,
+			writer: &Proto{Payload: "events.Card", Messages: map[string]Message{"events.Card": {}}},
+			want:   "cannot be read as",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CanReadProto(tt.reader, tt.writer)
+			if tt.want == "" {
+				if len(problems) != 0 {
+					t.Errorf("problems = %v, want none", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.want) {
+				t.Errorf("problems = %v, want one containing %q", problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	p := Describe((&events.StepUpCodeIssued{}).ProtoReflect().Descriptor())
+
+	if p.Payload != "events.StepUpCodeIssued" {
+		t.Fatalf("payload = %s", p.Payload)
+	}
+	msg, ok := p.Messages[p.Payload]
+	if !ok {
+		t.Fatal("payload message is not described")
+	}
+	// Başka paketteki mesajlar (Timestamp) şemaya eklenmez
+	if len(p.Messages) != 1 {
+		t.Errorf("messages = %v, want only the payload", p.Messages)
+	}
+	for i := 1; i < len(msg.Fields); i++ {
+		if msg.Fields[i-1].Number >= msg.Fields[i].Number {
+			t.Errorf("fields are not sorted by number: %v", msg.Fields)
+		}
+	}
+
+	fields := make(map[string]Field)
+	for _, f := range msg.Fields {
+		fields[f.Name] = f
+	}
+	if f := fields["customer_id"]; f.Number != 1 || f.Type != "uint32" || f.Cardinality != "optional" {
+		t.Errorf("customer_id = %+v", f)
+	}
+	if f := fields["expires_at"]; f.Number != 4 || f.Type != "google.protobuf.Timestamp" {
+		t.Errorf("expires_at = %+v", f)
+	}
+	if len(msg.ReservedNumbers) != 1 || msg.ReservedNumbers[0] != 3 {
+		t.Errorf("reserved numbers = %v, want [3]", msg.ReservedNumbers)
+	}
+	if len(msg.ReservedNames) != 1 || msg.ReservedNames[0] != "code" {
+		t.Errorf("reserved names = %v, want [code]", msg.ReservedNames)
+	}
+}
+
+func TestParseProto(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		valid bool
+	}{
+		{name: "described schema", text: base.String(), valid: true},
+		{name: "not json", text: "message Payment {}"},
+		{name: "missing payload", text: `{"messages":{"events.Payment":{"fields":[]}}}`},
+		{name: "payload not described", text: `{"payload":"events.Payment","messages":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseProto(tt.text)
+			if !tt.valid {
+				if !errors.Is(err, ErrInvalidSchema) {
+					t.Errorf("err = %v, want ErrInvalidSchema", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.String() != tt.text {
+				t.Errorf("round trip = %s, want %s", p.String(), tt.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Olay şemalarının kayıt defterinde (cmd/schemaregistry), üreticilerde ve tüketicilerde ortak
+// kullanılan tanımı ve uyumluluk kuralları. Protobuf payload'ları alan numarası, adı, tipi ve
+// cardinality'si ile; webhook gibi JSON gövdeleri JSON Schema'nın bir alt kümesiyle tanımlanır.
+
+const (
+	TypeProtobuf = "PROTOBUF"
+	TypeJSON     = "JSON"
+)
+
+// Şema çözülemedi ya da tipi desteklenmiyor
+var ErrInvalidSchema = errors.New("invalid schema")
+
+type Compatibility string
+
+const (
+	None     Compatibility = "NONE"
+	Backward Compatibility = "BACKWARD"
+	Forward  Compatibility = "FORWARD"
+	Full     Compatibility = "FULL"
+	// Transitive kurallar sadece son sürümle değil tüm sürümlerle karşılaştırır
+	BackwardTransitive Compatibility = "BACKWARD_TRANSITIVE"
+	ForwardTransitive  Compatibility = "FORWARD_TRANSITIVE"
+	FullTransitive     Compatibility = "FULL_TRANSITIVE"
+)
+
+func ParseCompatibility(s string) (Compatibility, error) {
+	switch c := Compatibility(s); c {
+	case None, Backward, Forward, Full, BackwardTransitive, ForwardTransitive, FullTransitive:
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid compatibility %q", s)
+}
+
+func (c Compatibility) Transitive() bool {
+	return c == BackwardTransitive || c == ForwardTransitive || c == FullTransitive
+}
+
+type Field struct {
+	Number      int32  `json:"number"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Cardinality string `json:"cardinality"`
+}
+
+type Message struct {
+	Fields          []Field  `json:"fields"`
+	ReservedNumbers []int32  `json:"reserved_numbers,omitempty"`
+	ReservedNames   []string `json:"reserved_names,omitempty"`
+}
+
+// Protobuf şeması: payload mesajı ve payload ile aynı paketteki, alanlarından ulaşılan mesajlar
+type Proto struct {
+	Payload  string             `json:"payload"`
+	Messages map[string]Message `json:"messages"`
+}
+
+func Describe(md protoreflect.MessageDescriptor) *Proto {
+	p := &Proto{Payload: string(md.FullName()), Messages: make(map[string]Message)}
+	AddMessage(p.Messages, md)
+	return p
+}
+
+// Mesajı ve alanlarındaki aynı paketten mesajları ekler; alanlar numara sırasıyla tutulur
+func AddMessage(messages map[string]Message, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := messages[name]; ok {
+		return
+	}
+
+	msg := Message{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		msg.Fields = append(msg.Fields, Field{
+			Number:      int32(fd.Number()),
+			Name:        string(fd.Name()),
+			Type:        fieldType(fd),
+			Cardinality: fd.Cardinality().String(),
+		})
+	}
+	sort.Slice(msg.Fields, func(i, j int) bool { return msg.Fields[i].Number < msg.Fields[j].Number })
+	ranges := md.ReservedRanges()
+	for i := 0; i < ranges.Len(); i++ {
+		r := ranges.Get(i)
+		for n := r[0]; n < r[1]; n++ {
+			msg.ReservedNumbers = append(msg.ReservedNumbers, int32(n))
+		}
+	}
+	names := md.ReservedNames()
+	for i := 0; i < names.Len(); i++ {
+		msg.ReservedNames = append(msg.ReservedNames, string(names.Get(i)))
+	}
+	messages[name] = msg
+
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); fd.Message() != nil && fd.Message().ParentFile().Package() == md.ParentFile().Package() {
+			AddMessage(messages, fd.Message())
+		}
+	}
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.Message() != nil:
+		return string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+// Kayıt defterinin sakladığı metin hali
+func (p *Proto) String() string {
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+func ParseProto(text string) (*Proto, error) {
+	p := &Proto{}
+	if err := json.Unmarshal([]byte(text), p); err != nil {
+		return nil, fmt.Errorf("%w: protobuf: %v", ErrInvalidSchema, err)
+	}
+	if p.Payload == "" {
+		return nil, fmt.Errorf("%w: protobuf: payload is required", ErrInvalidSchema)
+	}
+	if _, ok := p.Messages[p.Payload]; !ok {
+		return nil, fmt.Errorf("%w: protobuf: payload message %s is not described", ErrInvalidSchema, p.Payload)
+	}
+	return p, nil
+}
+
+// JSON Schema'nın kullanılan alt kümesi
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+}
+
+func ParseJSON(text string) (*JSONSchema, error) {
+	s := &JSONSchema{}
+	if err := json.Unmarshal([]byte(text), s); err != nil {
+		return nil, fmt.Errorf("%w: json: %v", ErrInvalidSchema, err)
+	}
+	return s, nil
+}
+
+// previous sürümlerinden sonra next'in kaydedilmesine engel olan sorunlar; previous eskiden yeniye sıralıdır.
+// Transitive olmayan kurallarda sadece son sürüme bakılır.
+func Check(mode Compatibility, schemaType string, previous []string, next string) ([]string, error) {
+	if mode == None || len(previous) == 0 {
+		return nil, validate(schemaType, next)
+	}
+	if !mode.Transitive() {
+		previous = previous[len(previous)-1:]
+	}
+
+	var problems []string
+	for _, old := range previous {
+		p, err := check(mode, schemaType, old, next)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, p...)
+	}
+	return dedupe(problems), nil
+}
+
+func validate(schemaType, text string) error {
+	switch schemaType {
+	case TypeProtobuf:
+		_, err := ParseProto(text)
+		return err
+	case TypeJSON:
+		_, err := ParseJSON(text)
+		return err
+	}
+	return fmt.Errorf("%w: unsupported type %q", ErrInvalidSchema, schemaType)
+}
+
+func check(mode Compatibility, schemaType, old, next string) ([]string, error) {
+	backward := mode == Backward || mode == BackwardTransitive || mode == Full || mode == FullTransitive
+	forward := mode == Forward || mode == ForwardTransitive || mode == Full || mode == FullTransitive
+
+	var problems []string
+	switch schemaType {
+	case TypeProtobuf:
+		o, err := ParseProto(old)
+		if err != nil {
+			return nil, err
+		}
+		n, err := ParseProto(next)
+		if err != nil {
+			return nil, err
+		}
+		if backward {
+			problems = append(problems, ProtoBackward(o, n)...)
+		}
+		if forward {
+			problems = append(problems, CanReadProto(o, n)...)
+		}
+	case TypeJSON:
+		o, err := ParseJSON(old)
+		if err != nil {
+			return nil, err
+		}
+		n, err := ParseJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		if backward {
+			problems = append(problems, CanReadJSON(n, o)...)
+		}
+		if forward {
+			problems = append(problems, CanReadJSON(o, n)...)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidSchema, schemaType)
+	}
+	return problems, nil
+}
+
+func dedupe(problems []string) []string {
+	seen := make(map[string]bool, len(problems))
+	var out []string
+	for _, p := range problems {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
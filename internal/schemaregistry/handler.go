@@ -0,0 +1,224 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"govo/internal/schema"
+
+	"github.com/gorilla/mux"
+)
+
+// Confluent Schema Registry REST API'sinin kullanılan kısmı; hata gövdeleri ve kodlar aynıdır,
+// böylece mevcut araçlar da bu servisle konuşabilir. AVRO desteklenmez, schemaType boşsa PROTOBUF'tur.
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Confluent hata kodları
+const (
+	codeSubjectNotFound      = 40401
+	codeVersionNotFound      = 40402
+	codeSchemaNotFound       = 40403
+	codeIncompatible         = 409
+	codeInvalidSchema        = 42201
+	codeInvalidVersion       = 42202
+	codeInvalidCompatibility = 42203
+)
+
+type Handler struct {
+	store *Store
+}
+
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+func (h *Handler) Routes(router *mux.Router) {
+	router.HandleFunc("/subjects", h.ListSubjects).Methods("GET")
+	router.HandleFunc("/subjects/{subject}/versions", h.ListVersions).Methods("GET")
+	router.HandleFunc("/subjects/{subject}/versions", h.Register).Methods("POST")
+	router.HandleFunc("/subjects/{subject}/versions/{version}", h.GetVersion).Methods("GET")
+	router.HandleFunc("/schemas/ids/{id}", h.GetSchema).Methods("GET")
+	router.HandleFunc("/compatibility/subjects/{subject}/versions/{version}", h.CheckCompatibility).Methods("POST")
+	router.HandleFunc("/config", h.GetConfig).Methods("GET")
+	router.HandleFunc("/config", h.SetConfig).Methods("PUT")
+	router.HandleFunc("/config/{subject}", h.GetConfig).Methods("GET")
+	router.HandleFunc("/config/{subject}", h.SetConfig).Methods("PUT")
+}
+
+type SchemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type ConfigRequest struct {
+	Compatibility string `json:"compatibility"`
+}
+
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func (h *Handler) ListSubjects(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.store.Subjects())
+}
+
+func (h *Handler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.store.Versions(mux.Vars(r)["subject"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, versions)
+}
+
+func (h *Handler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	version, ok := parseVersion(w, mux.Vars(r)["version"])
+	if !ok {
+		return
+	}
+	v, err := h.store.Version(mux.Vars(r)["subject"], version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (h *Handler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, ErrSchemaNotFound)
+		return
+	}
+	v, err := h.store.SchemaByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, SchemaRequest{Schema: v.Schema, SchemaType: v.SchemaType})
+}
+
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeSchema(w, r)
+	if !ok {
+		return
+	}
+	v, err := h.store.Register(mux.Vars(r)["subject"], req.SchemaType, req.Schema)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"id": v.ID})
+}
+
+// Sadece "latest" ile subject'in kuralına göre kontrol edilir; belirli bir sürüm verilirse onunla karşılaştırılır
+func (h *Handler) CheckCompatibility(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeSchema(w, r)
+	if !ok {
+		return
+	}
+	name := mux.Vars(r)["subject"]
+	version, ok := parseVersion(w, mux.Vars(r)["version"])
+	if !ok {
+		return
+	}
+
+	var problems []string
+	var err error
+	if version == 0 {
+		problems, err = h.store.Check(name, req.SchemaType, req.Schema)
+	} else {
+		var v *Version
+		if v, err = h.store.Version(name, version); err == nil {
+			mode := h.store.Compatibility(name)
+			problems, err = schema.Check(mode, req.SchemaType, []string{v.Schema}, req.Schema)
+		}
+	}
+	if errors.Is(err, ErrSubjectNotFound) {
+		// Henüz sürümü olmayan subject'e her şema uyumludur
+		err = nil
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"is_compatible": len(problems) == 0,
+		"messages":      problems,
+	})
+}
+
+func (h *Handler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"compatibilityLevel": string(h.store.Compatibility(mux.Vars(r)["subject"]))})
+}
+
+func (h *Handler) SetConfig(w http.ResponseWriter, r *http.Request) {
+	var req ConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: codeInvalidCompatibility, Message: "Invalid request body"})
+		return
+	}
+	c, err := schema.ParseCompatibility(req.Compatibility)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: codeInvalidCompatibility, Message: err.Error()})
+		return
+	}
+	if err := h.store.SetCompatibility(mux.Vars(r)["subject"], c); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ConfigRequest{Compatibility: string(c)})
+}
+
+func decodeSchema(w http.ResponseWriter, r *http.Request) (*SchemaRequest, bool) {
+	var req SchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Schema == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: codeInvalidSchema, Message: "Invalid schema"})
+		return nil, false
+	}
+	if req.SchemaType == "" {
+		req.SchemaType = schema.TypeProtobuf
+	}
+	return &req, true
+}
+
+func parseVersion(w http.ResponseWriter, value string) (int, bool) {
+	if value == "latest" || value == "-1" {
+		return 0, true
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: codeInvalidVersion, Message: "Invalid version " + value})
+		return 0, false
+	}
+	return version, true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var incompatible *IncompatibleError
+	switch {
+	case errors.As(err, &incompatible):
+		writeJSON(w, http.StatusConflict, errorResponse{ErrorCode: codeIncompatible, Message: err.Error()})
+	case errors.Is(err, ErrSubjectNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{ErrorCode: codeSubjectNotFound, Message: err.Error()})
+	case errors.Is(err, ErrVersionNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{ErrorCode: codeVersionNotFound, Message: err.Error()})
+	case errors.Is(err, ErrSchemaNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{ErrorCode: codeSchemaNotFound, Message: err.Error()})
+	case errors.Is(err, schema.ErrInvalidSchema):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{ErrorCode: codeInvalidSchema, Message: err.Error()})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{ErrorCode: 50001, Message: err.Error()})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
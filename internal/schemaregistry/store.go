@@ -0,0 +1,279 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"govo/internal/schema"
+)
+
+var (
+	ErrSubjectNotFound = errors.New("subject not found")
+	ErrVersionNotFound = errors.New("version not found")
+	ErrSchemaNotFound  = errors.New("schema not found")
+)
+
+// Uyumsuz şema kaydedilmez; Problems nedenleri taşır
+type IncompatibleError struct {
+	Subject  string
+	Problems []string
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("schema is incompatible with subject %s: %v", e.Subject, e.Problems)
+}
+
+type Version struct {
+	Subject    string `json:"subject"`
+	Version    int    `json:"version"`
+	ID         int    `json:"id"`
+	SchemaType string `json:"schemaType"`
+	Schema     string `json:"schema"`
+}
+
+type subject struct {
+	Compatibility schema.Compatibility `json:"compatibility,omitempty"`
+	Versions      []*Version           `json:"versions"`
+}
+
+type state struct {
+	NextID        int                  `json:"next_id"`
+	Compatibility schema.Compatibility `json:"compatibility"`
+	Subjects      map[string]*subject  `json:"subjects"`
+}
+
+// Şemaları bellekte tutar, path verilmişse her değişiklikte dosyaya yazar
+type Store struct {
+	path string
+
+	mu    sync.RWMutex
+	state state
+}
+
+func NewStore(path string, compatibility schema.Compatibility) (*Store, error) {
+	s := &Store{
+		path: path,
+		state: state{
+			NextID:        1,
+			Compatibility: compatibility,
+			Subjects:      make(map[string]*subject),
+		},
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if s.state.Subjects == nil {
+		s.state.Subjects = make(map[string]*subject)
+	}
+	return s, nil
+}
+
+func (s *Store) Subjects() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.state.Subjects))
+	for name := range s.state.Subjects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Store) Versions(name string) ([]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.state.Subjects[name]
+	if !ok || len(sub.Versions) == 0 {
+		return nil, ErrSubjectNotFound
+	}
+	versions := make([]int, len(sub.Versions))
+	for i, v := range sub.Versions {
+		versions[i] = v.Version
+	}
+	return versions, nil
+}
+
+// version 0 en son sürümdür
+func (s *Store) Version(name string, version int) (*Version, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.state.Subjects[name]
+	if !ok || len(sub.Versions) == 0 {
+		return nil, ErrSubjectNotFound
+	}
+	if version == 0 {
+		return sub.Versions[len(sub.Versions)-1], nil
+	}
+	for _, v := range sub.Versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, ErrVersionNotFound
+}
+
+func (s *Store) SchemaByID(id int) (*Version, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.state.Subjects {
+		for _, v := range sub.Versions {
+			if v.ID == id {
+				return v, nil
+			}
+		}
+	}
+	return nil, ErrSchemaNotFound
+}
+
+func (s *Store) Compatibility(name string) schema.Compatibility {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.compatibility(name)
+}
+
+func (s *Store) compatibility(name string) schema.Compatibility {
+	if sub, ok := s.state.Subjects[name]; ok && sub.Compatibility != "" {
+		return sub.Compatibility
+	}
+	return s.state.Compatibility
+}
+
+// name boşsa varsayılan kural değişir
+func (s *Store) SetCompatibility(name string, c schema.Compatibility) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == "" {
+		s.state.Compatibility = c
+	} else {
+		sub, ok := s.state.Subjects[name]
+		if !ok {
+			sub = &subject{}
+			s.state.Subjects[name] = sub
+		}
+		sub.Compatibility = c
+	}
+	return s.save()
+}
+
+// Şemanın subject'in kuralına göre kaydedilebilmesini engelleyen sorunlar
+func (s *Store) Check(name, schemaType, text string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.check(name, schemaType, text)
+}
+
+func (s *Store) check(name, schemaType, text string) ([]string, error) {
+	var previous []string
+	if sub, ok := s.state.Subjects[name]; ok {
+		for _, v := range sub.Versions {
+			// Tip değişikliği uyumlu sayılmaz
+			if v.SchemaType != schemaType {
+				return []string{fmt.Sprintf("schema type changed from %s to %s", v.SchemaType, schemaType)}, nil
+			}
+			previous = append(previous, v.Schema)
+		}
+	}
+	return schema.Check(s.compatibility(name), schemaType, previous, text)
+}
+
+// Aynı şema zaten kayıtlıysa mevcut sürüm döner; uyumsuzsa IncompatibleError
+func (s *Store) Register(name, schemaType, text string) (*Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.state.Subjects[name]
+	if ok {
+		for _, v := range sub.Versions {
+			if v.SchemaType == schemaType && v.Schema == text {
+				return v, nil
+			}
+		}
+	}
+
+	problems, err := s.check(name, schemaType, text)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, &IncompatibleError{Subject: name, Problems: problems}
+	}
+
+	if !ok {
+		sub = &subject{}
+		s.state.Subjects[name] = sub
+	}
+	version := &Version{
+		Subject:    name,
+		Version:    1,
+		ID:         s.state.NextID,
+		SchemaType: schemaType,
+		Schema:     text,
+	}
+	if n := len(sub.Versions); n > 0 {
+		version.Version = sub.Versions[n-1].Version + 1
+	}
+	// Başka bir subject'te aynı şema varsa aynı ID kullanılır
+	if existing := s.findSchema(schemaType, text); existing != nil {
+		version.ID = existing.ID
+	} else {
+		s.state.NextID++
+	}
+	sub.Versions = append(sub.Versions, version)
+
+	if err := s.save(); err != nil {
+		sub.Versions = sub.Versions[:len(sub.Versions)-1]
+		return nil, err
+	}
+	return version, nil
+}
+
+func (s *Store) findSchema(schemaType, text string) *Version {
+	for _, sub := range s.state.Subjects {
+		for _, v := range sub.Versions {
+			if v.SchemaType == schemaType && v.Schema == text {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// Yarım yazılmış dosya kalmasın diye önce geçici dosyaya yazılır
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
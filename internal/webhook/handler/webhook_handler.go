@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"govo/internal/webhook/model"
+	"govo/internal/webhook/service"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(service *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+type RegisterEndpointRequest struct {
+	OwnerType  string   `json:"owner_type"`
+	OwnerID    string   `json:"owner_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+type EndpointResponse struct {
+	*model.Endpoint
+	EventTypes []string `json:"event_types"`
+	// Sadece kayıt cevabında döner
+	Secret string `json:"secret,omitempty"`
+}
+
+func (h *WebhookHandler) RegisterEndpoint(w http.ResponseWriter, r *http.Request) {
+	var req RegisterEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	endpoint, err := h.service.RegisterEndpoint(req.OwnerType, req.OwnerID, req.URL, req.EventTypes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(EndpointResponse{Endpoint: endpoint, EventTypes: endpoint.Events(), Secret: endpoint.Secret})
+}
+
+func (h *WebhookHandler) ListEndpoints(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := h.service.ListEndpoints(r.URL.Query().Get("owner_type"), r.URL.Query().Get("owner_id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]EndpointResponse, len(endpoints))
+	for i, e := range endpoints {
+		response[i] = EndpointResponse{Endpoint: e, EventTypes: e.Events()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := queryID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(uint(id)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Endpoint'e WEBHOOK_TEST olayı gönderir
+func (h *WebhookHandler) SendTest(w http.ResponseWriter, r *http.Request) {
+	id, ok := queryID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.SendTest(uint(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(delivery)
+}
+
+// id verilirse denemeleriyle tek teslimatı, endpoint_id verilirse son teslimatları döner
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("id") != "" {
+		id, ok := queryID(w, r, "id")
+		if !ok {
+			return
+		}
+		delivery, err := h.service.GetDelivery(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(delivery)
+		return
+	}
+
+	endpointID, ok := queryID(w, r, "endpoint_id")
+	if !ok {
+		return
+	}
+	deliveries, err := h.service.ListDeliveries(uint(endpointID), r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, ok := queryID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Redeliver(id); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func queryID(w http.ResponseWriter, r *http.Request, name string) (uint64, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		http.Error(w, name+" is required", http.StatusBadRequest)
+		return 0, false
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	OwnerCustomer = "CUSTOMER"
+	OwnerPartner  = "PARTNER"
+
+	// Subscribes an endpoint to every deliverable event type
+	AllEvents = "*"
+)
+
+const (
+	DeliveryPending   = "PENDING"
+	DeliverySending   = "SENDING"
+	DeliverySucceeded = "SUCCEEDED"
+	DeliveryFailed    = "FAILED"
+)
+
+// Endpoint is a URL registered by a customer or a partner app. Customer
+// endpoints only receive events about that customer; partner endpoints
+// receive every event of the subscribed types.
+type Endpoint struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	OwnerType string `gorm:"size:20;not null;index:idx_webhook_owner" json:"owner_type"` // "CUSTOMER" or "PARTNER"
+	OwnerID   string `gorm:"size:100;not null;index:idx_webhook_owner" json:"owner_id"`
+	URL       string `gorm:"size:500;not null" json:"url"`
+	// HMAC-SHA256 key for the signature header; only returned when the endpoint is created
+	Secret string `gorm:"size:100;not null" json:"-"`
+	// Comma separated and wrapped in commas (",PAYMENT_CREATED,CARD_ISSUED,") so it can be matched with LIKE
+	EventTypes string `gorm:"type:text;not null" json:"-"`
+	Active     bool   `gorm:"not null;default:true" json:"active"`
+}
+
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+func (e *Endpoint) Events() []string {
+	return strings.Split(strings.Trim(e.EventTypes, ","), ",")
+}
+
+func JoinEventTypes(types []string) string {
+	return "," + strings.Join(types, ",") + ","
+}
+
+// Delivery is one event to be sent to one endpoint. The (endpoint, event)
+// pair is unique, so a Kafka redelivery does not send the event twice.
+type Delivery struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	EndpointID     uint       `gorm:"not null;uniqueIndex:idx_webhook_delivery_event" json:"endpoint_id"`
+	EventID        string     `gorm:"size:36;not null;uniqueIndex:idx_webhook_delivery_event" json:"event_id"`
+	EventType      string     `gorm:"size:100;not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"size:20;not null;index:idx_webhook_delivery_due" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_delivery_due" json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+
+	AttemptLog []DeliveryAttempt `gorm:"foreignKey:DeliveryID" json:"attempt_log,omitempty"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// DeliveryAttempt records the outcome of every HTTP call, including manual redeliveries
+type DeliveryAttempt struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	DeliveryID uint64    `gorm:"not null;index" json:"delivery_id"`
+	Attempt    int       `gorm:"not null" json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (DeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"time"
+
+	"govo/internal/webhook/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) CreateEndpoint(endpoint *model.Endpoint) error {
+	return r.db.Create(endpoint).Error
+}
+
+func (r *WebhookRepository) GetEndpoint(id uint) (*model.Endpoint, error) {
+	var endpoint model.Endpoint
+	if err := r.db.First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *WebhookRepository) ListEndpoints(ownerType, ownerID string) ([]*model.Endpoint, error) {
+	var endpoints []*model.Endpoint
+	query := r.db.Order("id")
+	if ownerType != "" {
+		query = query.Where("owner_type = ?", ownerType)
+	}
+	if ownerID != "" {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	if err := query.Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookRepository) DeleteEndpoint(id uint) error {
+	return r.db.Delete(&model.Endpoint{}, id).Error
+}
+
+// Olay tipine abone aktif endpoint'ler; müşteri endpoint'leri sadece kendi olaylarını alır
+func (r *WebhookRepository) MatchingEndpoints(eventType, customerID string) ([]*model.Endpoint, error) {
+	var endpoints []*model.Endpoint
+	query := r.db.Where("active = ?", true).
+		Where("event_types LIKE ? OR event_types LIKE ?", "%,"+eventType+",%", "%,"+model.AllEvents+",%")
+	if customerID != "" {
+		query = query.Where("owner_type = ? OR (owner_type = ? AND owner_id = ?)", model.OwnerPartner, model.OwnerCustomer, customerID)
+	} else {
+		query = query.Where("owner_type = ?", model.OwnerPartner)
+	}
+	if err := query.Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// Aynı olay aynı endpoint'e ikinci kez eklenmez
+func (r *WebhookRepository) CreateDeliveries(deliveries []*model.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+func (r *WebhookRepository) CreateDelivery(delivery *model.Delivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// Gönderim zamanı gelen teslimatları SENDING olarak işaretleyip döner.
+// stuckAfter'dan uzun SENDING kalanlar (ör. process çöktüyse) tekrar gönderilir.
+func (r *WebhookRepository) ClaimDue(limit int, stuckAfter time.Duration) ([]*model.Delivery, error) {
+	now := time.Now()
+	if err := r.db.Model(&model.Delivery{}).
+		Where("status = ? AND updated_at < ?", model.DeliverySending, now.Add(-stuckAfter)).
+		Updates(map[string]interface{}{"status": model.DeliveryPending, "updated_at": now}).Error; err != nil {
+		return nil, err
+	}
+
+	// Birden fazla replika aynı satırları almasın diye SKIP LOCKED
+	var batch []*model.Delivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(batch))
+		for i, d := range batch {
+			ids[i] = d.ID
+		}
+		return tx.Model(&model.Delivery{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": model.DeliverySending, "updated_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// Denemeyi loglar ve teslimatın durumunu günceller
+func (r *WebhookRepository) RecordAttempt(attempt *model.DeliveryAttempt, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		updates["updated_at"] = time.Now()
+		return tx.Model(&model.Delivery{}).Where("id = ?", attempt.DeliveryID).Updates(updates).Error
+	})
+}
+
+func (r *WebhookRepository) GetDelivery(id uint64) (*model.Delivery, error) {
+	var delivery model.Delivery
+	err := r.db.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(endpointID uint, status string, limit int) ([]*model.Delivery, error) {
+	var deliveries []*model.Delivery
+	query := r.db.Where("endpoint_id = ?", endpointID).Order("id DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Teslimatı yeniden kuyruğa alır; deneme sayacı sıfırlanır, log korunur
+func (r *WebhookRepository) Requeue(id uint64) error {
+	result := r.db.Model(&model.Delivery{}).
+		Where("id = ? AND status <> ?", id, model.DeliverySending).
+		Updates(map[string]interface{}{
+			"status":          model.DeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"govo/internal/webhook"
+	"govo/internal/webhook/model"
+	"govo/kafka"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// payments ve cards topic'lerinden gelen, dışarıya bildirilebilen olaylar
+var DeliverableEvents = []string{
+	kafka.EventPaymentCreated,
+	kafka.EventPaymentCancelled,
+	kafka.EventPaymentCompleted,
+	kafka.EventPaymentFailed,
+	kafka.EventCardIssued,
+	kafka.EventCardUpdated,
+	kafka.EventCardStatusChanged,
+	kafka.EventCardLimitChanged,
+	kafka.EventCardRemoved,
+}
+
+// Endpoint'in ayarlarını denemek için elle gönderilen olay
+const EventWebhookTest = "WEBHOOK_TEST"
+
+// Endpoint ve teslimat kayıtlarının tutulduğu depo; repository.WebhookRepository uygular
+type WebhookStore interface {
+	CreateEndpoint(endpoint *model.Endpoint) error
+	GetEndpoint(id uint) (*model.Endpoint, error)
+	ListEndpoints(ownerType, ownerID string) ([]*model.Endpoint, error)
+	DeleteEndpoint(id uint) error
+	MatchingEndpoints(eventType, customerID string) ([]*model.Endpoint, error)
+
+	CreateDeliveries(deliveries []*model.Delivery) error
+	CreateDelivery(delivery *model.Delivery) error
+	ClaimDue(limit int, stuckAfter time.Duration) ([]*model.Delivery, error)
+	RecordAttempt(attempt *model.DeliveryAttempt, updates map[string]interface{}) error
+	GetDelivery(id uint64) (*model.Delivery, error)
+	ListDeliveries(endpointID uint, status string, limit int) ([]*model.Delivery, error)
+	Requeue(id uint64) error
+}
+
+// Webhook kayıtları ve olayların endpoint'lere imzalı gönderimi.
+// Olaylar önce teslimat tablosuna yazılır, Start ile çalışan döngü gönderir ve tekrar dener.
+type WebhookService struct {
+	repo        WebhookStore
+	client      *http.Client
+	batchSize   int
+	interval    time.Duration
+	maxAttempts int
+	stuckAfter  time.Duration
+}
+
+func NewWebhookService(repo WebhookStore) *WebhookService {
+	return &WebhookService{
+		repo:        repo,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		batchSize:   50,
+		interval:    time.Second,
+		maxAttempts: 10,
+		stuckAfter:  time.Minute,
+	}
+}
+
+func (s *WebhookService) RegisterEndpoint(ownerType, ownerID, rawURL string, eventTypes []string) (*model.Endpoint, error) {
+	if ownerType != model.OwnerCustomer && ownerType != model.OwnerPartner {
+		return nil, errors.New("owner type must be CUSTOMER or PARTNER")
+	}
+	if ownerID == "" {
+		return nil, errors.New("owner ID is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("url must be an absolute http or https URL")
+	}
+	if len(eventTypes) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	for _, t := range eventTypes {
+		if t != model.AllEvents && !deliverable(t) {
+			return nil, fmt.Errorf("event type %s cannot be subscribed to", t)
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &model.Endpoint{
+		OwnerType:  ownerType,
+		OwnerID:    ownerID,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: model.JoinEventTypes(eventTypes),
+		Active:     true,
+	}
+	if err := s.repo.CreateEndpoint(endpoint); err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %v", err)
+	}
+	return endpoint, nil
+}
+
+func (s *WebhookService) ListEndpoints(ownerType, ownerID string) ([]*model.Endpoint, error) {
+	return s.repo.ListEndpoints(ownerType, ownerID)
+}
+
+func (s *WebhookService) DeleteEndpoint(id uint) error {
+	return s.repo.DeleteEndpoint(id)
+}
+
+func (s *WebhookService) GetDelivery(id uint64) (*model.Delivery, error) {
+	return s.repo.GetDelivery(id)
+}
+
+func (s *WebhookService) ListDeliveries(endpointID uint, status string) ([]*model.Delivery, error) {
+	return s.repo.ListDeliveries(endpointID, status, 100)
+}
+
+// Başarılı ya da vazgeçilmiş teslimatı tekrar gönderir
+func (s *WebhookService) Redeliver(id uint64) error {
+	if err := s.repo.Requeue(id); err != nil {
+		return fmt.Errorf("delivery %d cannot be redelivered: %v", id, err)
+	}
+	return nil
+}
+
+// Endpoint'e WEBHOOK_TEST olayı gönderir
+func (s *WebhookService) SendTest(endpointID uint) (*model.Delivery, error) {
+	if _, err := s.repo.GetEndpoint(endpointID); err != nil {
+		return nil, fmt.Errorf("endpoint not found: %v", err)
+	}
+
+	eventID, err := newEventID()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"id":          eventID,
+		"type":        EventWebhookTest,
+		"occurred_at": time.Now().UTC().Format(time.RFC3339Nano),
+		"data":        map[string]interface{}{"endpoint_id": endpointID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &model.Delivery{
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventType:     EventWebhookTest,
+		Payload:       string(body),
+		Status:        model.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.repo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Kafka olayını abone endpoint'ler için teslimat kuyruğuna ekler
+func (s *WebhookService) HandleEvent(ctx context.Context, msg *kafka.Message) error {
+	payload, err := msg.Envelope.GetPayload().UnmarshalNew()
+	if err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to decode %s payload: %v", msg.EventType(), err))
+	}
+
+	endpoints, err := s.repo.MatchingEndpoints(msg.EventType(), customerID(payload.ProtoReflect()))
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(payload)
+	if err != nil {
+		return kafka.Permanent(err)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"id":          msg.Envelope.GetEventId(),
+		"type":        msg.EventType(),
+		"version":     msg.Envelope.GetVersion(),
+		"occurred_at": msg.Envelope.GetOccurredAt().AsTime().Format(time.RFC3339Nano),
+		"data":        json.RawMessage(data),
+	})
+	if err != nil {
+		return kafka.Permanent(err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*model.Delivery, len(endpoints))
+	for i, e := range endpoints {
+		deliveries[i] = &model.Delivery{
+			EndpointID:    e.ID,
+			EventID:       msg.Envelope.GetEventId(),
+			EventType:     msg.EventType(),
+			Payload:       string(body),
+			Status:        model.DeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+	return s.repo.CreateDeliveries(deliveries)
+}
+
+func (s *WebhookService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		// Dolu batch geldiyse beklemeden devam et
+		n, err := s.Flush(ctx)
+		if err != nil {
+			log.Printf("Failed to send webhooks: %v", err)
+		}
+		if n == s.batchSize {
+			continue
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Zamanı gelen teslimatlardan bir batch gönderir
+func (s *WebhookService) Flush(ctx context.Context) (int, error) {
+	batch, err := s.repo.ClaimDue(s.batchSize, s.stuckAfter)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range batch {
+		s.deliver(ctx, d)
+	}
+	return len(batch), nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, d *model.Delivery) {
+	attempt := &model.DeliveryAttempt{DeliveryID: d.ID, Attempt: d.Attempts + 1}
+	updates := map[string]interface{}{"attempts": attempt.Attempt}
+
+	endpoint, err := s.repo.GetEndpoint(d.EndpointID)
+	if err != nil {
+		// Endpoint silindi, tekrar denenmez
+		attempt.Error = fmt.Sprintf("endpoint not found: %v", err)
+		updates["status"] = model.DeliveryFailed
+		updates["last_error"] = attempt.Error
+		s.record(attempt, updates)
+		return
+	}
+
+	start := time.Now()
+	status, err := s.post(ctx, endpoint, d)
+	attempt.DurationMS = time.Since(start).Milliseconds()
+	attempt.StatusCode = status
+	updates["last_status_code"] = status
+
+	if err == nil {
+		now := time.Now()
+		updates["status"] = model.DeliverySucceeded
+		updates["last_error"] = ""
+		updates["delivered_at"] = now
+		s.record(attempt, updates)
+		return
+	}
+
+	attempt.Error = err.Error()
+	updates["last_error"] = attempt.Error
+	if attempt.Attempt >= s.maxAttempts {
+		updates["status"] = model.DeliveryFailed
+		log.Printf("Webhook delivery %d to endpoint %d failed after %d attempts: %v", d.ID, d.EndpointID, attempt.Attempt, err)
+	} else {
+		updates["status"] = model.DeliveryPending
+		updates["next_attempt_at"] = time.Now().Add(backoff(attempt.Attempt))
+	}
+	s.record(attempt, updates)
+}
+
+// 2xx dışındaki cevaplar başarısız sayılır
+func (s *WebhookService) post(ctx context.Context, endpoint *model.Endpoint, d *model.Delivery) (int, error) {
+	body := []byte(d.Payload)
+	now := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Govo-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderEventType, d.EventType)
+	req.Header.Set(webhook.HeaderEventID, d.EventID)
+	req.Header.Set(webhook.HeaderDeliveryID, strconv.FormatUint(d.ID, 10))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(endpoint.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) record(attempt *model.DeliveryAttempt, updates map[string]interface{}) {
+	if err := s.repo.RecordAttempt(attempt, updates); err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", attempt.DeliveryID, err)
+	}
+}
+
+// 30s, 1m, 2m ... en fazla 2 saat
+func backoff(attempts int) time.Duration {
+	d := 30 * time.Second << (attempts - 1)
+	if d <= 0 || d > 2*time.Hour {
+		return 2 * time.Hour
+	}
+	return d
+}
+
+func deliverable(eventType string) bool {
+	for _, t := range DeliverableEvents {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload'da customer_id alanı varsa değeri, yoksa boş döner
+func customerID(m protoreflect.Message) string {
+	field := m.Descriptor().Fields().ByName("customer_id")
+	if field == nil {
+		return ""
+	}
+	return fmt.Sprint(m.Get(field).Interface())
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"govo/internal/webhook"
+	"govo/internal/webhook/model"
+)
+
+// Teslimat döngüsünü veritabanı olmadan çalıştırmak için
+type memoryStore struct {
+	mu         sync.Mutex
+	endpoints  map[uint]*model.Endpoint
+	deliveries map[uint64]*model.Delivery
+	attempts   []*model.DeliveryAttempt
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{endpoints: map[uint]*model.Endpoint{}, deliveries: map[uint64]*model.Delivery{}}
+}
+
+func (m *memoryStore) CreateEndpoint(endpoint *model.Endpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoint.ID = uint(len(m.endpoints) + 1)
+	m.endpoints[endpoint.ID] = endpoint
+	return nil
+}
+
+func (m *memoryStore) GetEndpoint(id uint) (*model.Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.endpoints[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return e, nil
+}
+
+func (m *memoryStore) ListEndpoints(ownerType, ownerID string) ([]*model.Endpoint, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) DeleteEndpoint(id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.endpoints, id)
+	return nil
+}
+
+func (m *memoryStore) MatchingEndpoints(eventType, customerID string) ([]*model.Endpoint, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) CreateDeliveries(deliveries []*model.Delivery) error {
+	for _, d := range deliveries {
+		if err := m.CreateDelivery(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) CreateDelivery(delivery *model.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery.ID = uint64(len(m.deliveries) + 1)
+	m.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (m *memoryStore) ClaimDue(limit int, stuckAfter time.Duration) ([]*model.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var batch []*model.Delivery
+	for _, d := range m.deliveries {
+		if d.Status == model.DeliveryPending && !d.NextAttemptAt.After(time.Now()) && len(batch) < limit {
+			d.Status = model.DeliverySending
+			copied := *d
+			batch = append(batch, &copied)
+		}
+	}
+	return batch, nil
+}
+
+func (m *memoryStore) RecordAttempt(attempt *model.DeliveryAttempt, updates map[string]interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts = append(m.attempts, attempt)
+	d := m.deliveries[attempt.DeliveryID]
+	for k, v := range updates {
+		switch k {
+		case "attempts":
+			d.Attempts = v.(int)
+		case "status":
+			d.Status = v.(string)
+		case "last_status_code":
+			d.LastStatusCode = v.(int)
+		case "last_error":
+			d.LastError = v.(string)
+		case "next_attempt_at":
+			d.NextAttemptAt = v.(time.Time)
+		case "delivered_at":
+			at := v.(time.Time)
+			d.DeliveredAt = &at
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) GetDelivery(id uint64) (*model.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (m *memoryStore) ListDeliveries(endpointID uint, status string, limit int) ([]*model.Delivery, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) Requeue(id uint64) error {
+	return nil
+}
+
+// Sonraki denemenin zamanını öne çeker
+func (m *memoryStore) due(id uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries[id].NextAttemptAt = time.Now()
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	calls    int
+	errs     []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := io.ReadAll(req.Body)
+	if err := webhook.Verify("whsec_test", req.Header.Get(webhook.HeaderSignature), req.Header.Get(webhook.HeaderTimestamp), body, webhook.DefaultTolerance); err != nil {
+		r.errs = append(r.errs, err)
+	}
+	status := http.StatusOK
+	if r.calls < len(r.statuses) {
+		status = r.statuses[r.calls]
+	}
+	r.calls++
+	w.WriteHeader(status)
+}
+
+func newDelivery(t *testing.T, store *memoryStore, url string) *model.Delivery {
+	t.Helper()
+	endpoint := &model.Endpoint{OwnerType: model.OwnerPartner, OwnerID: "p1", URL: url, Secret: "whsec_test", Active: true}
+	store.CreateEndpoint(endpoint)
+	d := &model.Delivery{
+		EndpointID:    endpoint.ID,
+		EventID:       "evt_1",
+		EventType:     "PAYMENT_CREATED",
+		Payload:       `{"id":"evt_1"}`,
+		Status:        model.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	store.CreateDelivery(d)
+	return d
+}
+
+func TestDeliveryRetriesWithBackoff(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusServiceUnavailable}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	store := newMemoryStore()
+	svc := NewWebhookService(store)
+	d := newDelivery(t, store, server.URL)
+
+	for i, wait := range []time.Duration{30 * time.Second, time.Minute} {
+		before := time.Now()
+		if n, err := svc.Flush(context.Background()); err != nil || n != 1 {
+			t.Fatalf("flush %d = %d, %v", i+1, n, err)
+		}
+		got, _ := store.GetDelivery(d.ID)
+		if got.Status != model.DeliveryPending || got.Attempts != i+1 || got.LastStatusCode != recv.statuses[i] {
+			t.Fatalf("after attempt %d: %s attempts %d status code %d", i+1, got.Status, got.Attempts, got.LastStatusCode)
+		}
+		if delay := got.NextAttemptAt.Sub(before); delay < wait || delay > wait+time.Second {
+			t.Errorf("attempt %d retries after %v, want %v", i+1, delay, wait)
+		}
+
+		// Zamanı gelmeden tekrar gönderilmez
+		if n, _ := svc.Flush(context.Background()); n != 0 {
+			t.Fatalf("delivery retried before its backoff")
+		}
+		store.due(d.ID)
+	}
+
+	if _, err := svc.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := store.GetDelivery(d.ID)
+	if got.Status != model.DeliverySucceeded || got.DeliveredAt == nil || got.LastError != "" {
+		t.Fatalf("after success: %s delivered %v error %q", got.Status, got.DeliveredAt, got.LastError)
+	}
+
+	// Her deneme loglanır
+	wantCodes := []int{500, 503, 200}
+	if len(store.attempts) != len(wantCodes) {
+		t.Fatalf("logged %d attempts, want %d", len(store.attempts), len(wantCodes))
+	}
+	for i, a := range store.attempts {
+		if a.Attempt != i+1 || a.StatusCode != wantCodes[i] || (a.Error == "") != (wantCodes[i] == 200) {
+			t.Errorf("attempt log %d = %+v", i, a)
+		}
+	}
+	if len(recv.errs) > 0 {
+		t.Errorf("receiver rejected signatures: %v", recv.errs)
+	}
+}
+
+func TestDeliveryGivesUp(t *testing.T) {
+	recv := &receiver{statuses: []int{500, 500, 500}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	store := newMemoryStore()
+	svc := NewWebhookService(store)
+	svc.maxAttempts = 2
+	d := newDelivery(t, store, server.URL)
+
+	svc.Flush(context.Background())
+	store.due(d.ID)
+	svc.Flush(context.Background())
+
+	got, _ := store.GetDelivery(d.ID)
+	if got.Status != model.DeliveryFailed || got.Attempts != 2 {
+		t.Fatalf("after max attempts: %s attempts %d", got.Status, got.Attempts)
+	}
+	store.due(d.ID)
+	if n, _ := svc.Flush(context.Background()); n != 0 || recv.calls != 2 {
+		t.Errorf("failed delivery sent again, receiver called %d times", recv.calls)
+	}
+}
+
+// Silinmiş endpoint'e gönderim denenmez
+func TestDeliveryToDeletedEndpoint(t *testing.T) {
+	store := newMemoryStore()
+	svc := NewWebhookService(store)
+	d := newDelivery(t, store, "http://127.0.0.1:0")
+	store.DeleteEndpoint(d.EndpointID)
+
+	svc.Flush(context.Background())
+	got, _ := store.GetDelivery(d.ID)
+	if got.Status != model.DeliveryFailed || len(store.attempts) != 1 {
+		t.Errorf("deleted endpoint: %s with %d attempts", got.Status, len(store.attempts))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{9, 2 * time.Hour},
+		{60, 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
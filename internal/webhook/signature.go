@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Alıcılar imzayı bu header'lardan doğrular:
+//
+//	X-Govo-Signature: sha256=hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+//	X-Govo-Timestamp: Unix saniye
+//
+// Zaman damgası imzaya dahildir, eski bir isteğin tekrar gönderilmesi Tolerance ile reddedilir.
+const (
+	HeaderSignature  = "X-Govo-Signature"
+	HeaderTimestamp  = "X-Govo-Timestamp"
+	HeaderEventType  = "X-Govo-Event"
+	HeaderEventID    = "X-Govo-Event-Id"
+	HeaderDeliveryID = "X-Govo-Delivery"
+
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+)
+
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// İmzayı ve zaman damgasını doğrular
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+	ts := time.Unix(unix, 0)
+	if d := time.Since(ts); d > tolerance || d < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	expected := Sign(secret, ts, body)
+	if !strings.HasPrefix(signature, "sha256=") || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// Alıcı tarafta herhangi bir HMAC-SHA256 uygulamasıyla üretilebilen değer
+	got := Sign("whsec_test", time.Unix(1700000000, 0), []byte(`{"id":"evt_1"}`))
+	want := "sha256=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"
+	if got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+	stamp := func(ts time.Time) string { return strconv.FormatInt(ts.Unix(), 10) }
+	signature := Sign("secret", now, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		want      error
+	}{
+		{name: "valid", secret: "secret", signature: signature, timestamp: stamp(now), body: body},
+		{name: "edge of tolerance", secret: "secret", signature: Sign("secret", now.Add(-4*time.Minute), body), timestamp: stamp(now.Add(-4 * time.Minute)), body: body},
+		{name: "wrong secret", secret: "other", signature: signature, timestamp: stamp(now), body: body, want: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", signature: signature, timestamp: stamp(now), body: []byte(`{"id":"evt_2"}`), want: ErrInvalidSignature},
+		{name: "timestamp not signed", secret: "secret", signature: signature, timestamp: stamp(now.Add(-time.Second)), body: body, want: ErrInvalidSignature},
+		{name: "missing prefix", secret: "secret", signature: strings.TrimPrefix(signature, "sha256="), timestamp: stamp(now), body: body, want: ErrInvalidSignature},
+		{name: "other scheme", secret: "secret", signature: "sha1=" + strings.TrimPrefix(signature, "sha256="), timestamp: stamp(now), body: body, want: ErrInvalidSignature},
+		{name: "stale", secret: "secret", signature: Sign("secret", now.Add(-10*time.Minute), body), timestamp: stamp(now.Add(-10 * time.Minute)), body: body, want: ErrStaleTimestamp},
+		{name: "future", secret: "secret", signature: Sign("secret", now.Add(10*time.Minute), body), timestamp: stamp(now.Add(10 * time.Minute)), body: body, want: ErrStaleTimestamp},
+		{name: "malformed timestamp", secret: "secret", signature: signature, timestamp: "yesterday", body: body, want: ErrStaleTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, DefaultTolerance)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
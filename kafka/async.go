@@ -0,0 +1,171 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+// Teslimat sonucu; err nil ise rec.Partition ve rec.Offset broker'ın verdiği konumdur
+type DeliveryCallback func(rec *Record, err error)
+
+// Gönderim sonucunu callback ile bildiren Publisher
+type AsyncPublisher interface {
+	Publisher
+	OnDelivery(cb DeliveryCallback)
+}
+
+type ProducerStats struct {
+	Sent     int64 `json:"sent"`
+	Failed   int64 `json:"failed"`
+	InFlight int64 `json:"in_flight"`
+}
+
+// Mesajları batch'leyerek gönderen Kafka Publisher. Send mesajı kuyruğa alıp hemen döner,
+// sonuç OnDelivery ile kaydedilen callback'lere bildirilir.
+type AsyncClient struct {
+	conn *connection
+
+	mu       sync.RWMutex
+	producer sarama.AsyncProducer
+	closed   bool
+	done     sync.WaitGroup
+
+	cbMu      sync.Mutex
+	callbacks []DeliveryCallback
+
+	sent     atomic.Int64
+	failed   atomic.Int64
+	inFlight atomic.Int64
+}
+
+func NewAsyncClient(brokers []string, opts ...ClientOption) *AsyncClient {
+	config := newProducerConfig(opts)
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+
+	// Broker'a ulaşılamazsa client bağlanmadan döner ve arka planda bağlanmayı dener
+	c := &AsyncClient{conn: newConnection("async producer")}
+	c.conn.start(func() error {
+		producer, err := sarama.NewAsyncProducer(brokers, config)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.producer = producer
+		c.done.Add(2)
+		go c.successes(producer)
+		go c.errors(producer)
+		return nil
+	})
+	return c
+}
+
+func (c *AsyncClient) ConnectionStatus() ConnectionStatus {
+	return c.conn.status()
+}
+
+// Callback'ler producer'ın goroutine'inde çağrılır, uzun sürmemelidir
+func (c *AsyncClient) OnDelivery(cb DeliveryCallback) {
+	c.cbMu.Lock()
+	defer c.cbMu.Unlock()
+	c.callbacks = append(c.callbacks, cb)
+}
+
+func (c *AsyncClient) Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...PublishOption) error {
+	rec, err := NewEventRecord(ctx, topic, eventType, payload, opts...)
+	if err != nil {
+		return err
+	}
+	return c.Send(ctx, rec)
+}
+
+func (c *AsyncClient) Send(ctx context.Context, rec *Record) error {
+	msg := toProducerMessage(rec)
+	msg.Metadata = rec
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return ErrBusClosed
+	}
+	if c.producer == nil {
+		return ErrNotConnected
+	}
+
+	select {
+	case c.producer.Input() <- msg:
+		c.inFlight.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *AsyncClient) Stats() ProducerStats {
+	return ProducerStats{
+		Sent:     c.sent.Load(),
+		Failed:   c.failed.Load(),
+		InFlight: c.inFlight.Load(),
+	}
+}
+
+// Kuyruktaki mesajlar gönderilip callback'ler çağrılana kadar bekler
+func (c *AsyncClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	c.conn.close()
+	c.mu.RLock()
+	producer := c.producer
+	c.mu.RUnlock()
+	if producer == nil {
+		return nil
+	}
+	producer.AsyncClose()
+	c.done.Wait()
+	return nil
+}
+
+func (c *AsyncClient) successes(producer sarama.AsyncProducer) {
+	defer c.done.Done()
+	for msg := range producer.Successes() {
+		c.inFlight.Add(-1)
+		c.sent.Add(1)
+		c.conn.sent(nil)
+
+		rec := msg.Metadata.(*Record)
+		rec.Partition = msg.Partition
+		rec.Offset = msg.Offset
+		c.notify(rec, nil)
+	}
+}
+
+func (c *AsyncClient) errors(producer sarama.AsyncProducer) {
+	defer c.done.Done()
+	for perr := range producer.Errors() {
+		c.inFlight.Add(-1)
+		c.failed.Add(1)
+		c.conn.sent(perr.Err)
+		c.notify(perr.Msg.Metadata.(*Record), perr.Err)
+	}
+}
+
+func (c *AsyncClient) notify(rec *Record, err error) {
+	c.cbMu.Lock()
+	callbacks := c.callbacks
+	c.cbMu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(rec, err)
+	}
+}
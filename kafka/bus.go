@@ -0,0 +1,229 @@
+package kafka
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/requestctx"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Servisler broker'a bu arayüzler üzerinden bağlanır. Kafka (Client, KafkaSubscriber),
+// bellek içi (MemoryBus) ve dosya tabanlı (FileBus) uygulamaları vardır.
+
+const (
+	contentTypeHeader = "content-type"
+	contentTypeProto  = "application/x-protobuf"
+
+	// İsteğin izini olayı işleyen tüketiciye kadar taşıyan header'lar
+	headerTraceParent = "traceparent"
+	headerRequestID   = "x-request-id"
+	headerTenantID    = "x-tenant-id"
+	headerActorID     = "x-actor-id"
+	// Yönetici araçlarının gönderdiği kayıtlarda "admin"
+	headerSource = "x-source"
+)
+
+// Broker'dan bağımsız ham kayıt; Value olay zarfının protobuf halidir
+type Record struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+
+	// Broker'a gönderilmez; teslimat callback'lerinde kaydı eşleştirmek için (ör. outbox satır id'si)
+	Metadata interface{}
+}
+
+type Publisher interface {
+	// Payload'ı ortak zarfa sarar ve gönderir
+	Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...PublishOption) error
+	// Hazır kaydı olduğu gibi gönderir (retry, DLQ, redrive)
+	Send(ctx context.Context, rec *Record) error
+	Close() error
+}
+
+// false dönerse kayıt commit edilmez, partition'ın işlenmesi durur ve kayıt tekrar teslim edilir.
+// Handler false dönmeden önce PauseRecord çağırdıysa partition o zamana kadar duraklatılır ve
+// kayıt aynı oturumda yeniden teslim edilir.
+type RecordHandler func(ctx context.Context, rec *Record) bool
+
+type pauseKey struct{}
+
+// Subscriber her kayıt için handler'ın partition'ı duraklatabileceği fonksiyonu verir
+func withPause(ctx context.Context, pause func(until time.Time)) context.Context {
+	return context.WithValue(ctx, pauseKey{}, pause)
+}
+
+// Kaydın partition'ını until'e kadar duraklatır; handler ardından false dönmelidir.
+// Subscriber duraklatmayı desteklemiyorsa false döner, kayıt yine de tekrar teslim edilir.
+func PauseRecord(ctx context.Context, until time.Time) bool {
+	pause, ok := ctx.Value(pauseKey{}).(func(time.Time))
+	if ok {
+		pause(until)
+	}
+	return ok
+}
+
+type Subscriber interface {
+	// Grup üyesi olarak topic'leri okur, context kapanana kadar bloklar
+	Subscribe(ctx context.Context, groupID string, topics []string, handle RecordHandler) error
+	Close() error
+}
+
+type Bus interface {
+	Publisher
+	Subscriber
+}
+
+type publishOptions struct {
+	aggregateID string
+	sequence    uint64
+}
+
+type PublishOption func(*publishOptions)
+
+// Olay aggregate id ile anahtarlanır; aynı aggregate'in olayları aynı partition'a gider
+func WithKey(aggregateID string) PublishOption {
+	return func(o *publishOptions) {
+		o.aggregateID = aggregateID
+	}
+}
+
+// Aggregate'in her olayda bir artan sıra numarası, tüketiciler sıra dışı olayları bununla yakalar
+func WithSequence(sequence uint64) PublishOption {
+	return func(o *publishOptions) {
+		o.sequence = sequence
+	}
+}
+
+// Olayı zarfa sarıp gönderilmeye hazır kayda çevirir; outbox gibi kaydı sonra gönderen katmanlar kullanır
+func NewEventRecord(ctx context.Context, topic, eventType string, payload proto.Message, opts ...PublishOption) (*Record, error) {
+	var options publishOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	schema, err := checkPayload(eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Şema açılışta kaydedilmediyse olay yayınlanmaz
+	var schemaID int32
+	if r := registry.Load(); r != nil {
+		if schemaID, err = r.schemaID(eventType); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := anypb.New(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap %s payload: %v", eventType, err)
+	}
+
+	envelope := &events.Envelope{
+		EventId:     newEventID(),
+		Type:        eventType,
+		Version:     schema.Version,
+		OccurredAt:  timestamppb.Now(),
+		TraceId:     requestctx.FromContext(ctx).RequestID,
+		Payload:     body,
+		AggregateId: options.aggregateID,
+		Sequence:    options.sequence,
+		SchemaId:    schemaID,
+	}
+
+	data, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %v", eventType, err)
+	}
+
+	rec := &Record{
+		Topic:     topic,
+		Value:     data,
+		Headers:   traceHeaders(ctx),
+		Timestamp: envelope.OccurredAt.AsTime(),
+	}
+	rec.Headers[contentTypeHeader] = contentTypeProto
+	if options.aggregateID != "" {
+		rec.Key = []byte(options.aggregateID)
+	}
+	return rec, nil
+}
+
+// Olay isteğin trace'i içinde yeni bir span olarak yayınlanır; istek dışı işler yeni trace başlatır
+func traceHeaders(ctx context.Context) map[string]string {
+	md := requestctx.FromContext(ctx)
+	headers := map[string]string{
+		headerTraceParent: requestctx.ChildSpan(md.TraceParent),
+	}
+	if md.RequestID != "" {
+		headers[headerRequestID] = md.RequestID
+	}
+	if md.Tenant != "" {
+		headers[headerTenantID] = md.Tenant
+	}
+	if md.Actor != "" {
+		headers[headerActorID] = md.Actor
+	}
+	return headers
+}
+
+// Header'lardaki trace bilgisini handler'a verilen context'e geri yükler
+func recordContext(ctx context.Context, rec *Record, envelope *events.Envelope) context.Context {
+	md := requestctx.Metadata{
+		Actor:       rec.Header(headerActorID),
+		Source:      requestctx.SourceKafka,
+		RequestID:   rec.Header(headerRequestID),
+		Tenant:      rec.Header(headerTenantID),
+		TraceParent: requestctx.ChildSpan(rec.Header(headerTraceParent)),
+	}
+	if rec.Header(headerSource) == requestctx.SourceAdmin {
+		md.Source = requestctx.SourceAdmin
+		md.Role = requestctx.RoleAdmin
+	}
+	// Header'sız eski olaylarda istek kimliği zarftan alınır
+	if md.RequestID == "" {
+		md.RequestID = envelope.GetTraceId()
+	}
+	return requestctx.WithMetadata(ctx, md)
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	// UUID v4 biçimi
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func (r *Record) Header(key string) string {
+	return r.Headers[key]
+}
+
+// Zarf öncesi JSON kayıtlarda nil, nil döner
+func (r *Record) Envelope() (*events.Envelope, error) {
+	if r.Header(contentTypeHeader) != contentTypeProto {
+		return nil, nil
+	}
+	envelope := &events.Envelope{}
+	if err := proto.Unmarshal(r.Value, envelope); err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
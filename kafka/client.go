@@ -1,77 +1,148 @@
 package kafka
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
 )
 
+// Kafka Publisher uygulaması
 type Client struct {
+	conn *connection
+
+	mu       sync.RWMutex
 	producer sarama.SyncProducer
 }
 
-func NewClient(brokers []string) *Client {
-	config := sarama.NewConfig()
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 5
-	config.Producer.Return.Successes = true
-
-	// Retry/backoff mekanizması
-	maxRetries := 30
-	retryInterval := 2 * time.Second
+type ClientOption func(*sarama.Config)
 
-	var producer sarama.SyncProducer
-	var err error
+func WithPartitioner(p Partitioner) ClientOption {
+	return func(config *sarama.Config) {
+		config.Producer.Partitioner = p.constructor()
+	}
+}
 
-	for i := 0; i < maxRetries; i++ {
-		producer, err = sarama.NewSyncProducer(brokers, config)
-		if err == nil {
-			break
-		}
+// Broker'a ulaşılamazsa client bağlanmadan döner ve arka planda bağlanmayı dener
+func NewClient(brokers []string, opts ...ClientOption) *Client {
+	config := newProducerConfig(opts)
+	// SyncProducer teslimat sonucunu Successes kanalından bekler
+	config.Producer.Return.Successes = true
 
-		log.Printf("Kafka'ya bağlanılamadı (deneme %d/%d): %v", i+1, maxRetries, err)
-		if i < maxRetries-1 {
-			log.Printf("%v sonra tekrar denenecek...", retryInterval)
-			time.Sleep(retryInterval)
+	c := &Client{conn: newConnection("producer")}
+	c.conn.start(func() error {
+		producer, err := sarama.NewSyncProducer(brokers, config)
+		if err != nil {
+			return err
 		}
-	}
+		c.mu.Lock()
+		c.producer = producer
+		c.mu.Unlock()
+		return nil
+	})
+	return c
+}
 
-	if err != nil {
-		panic(fmt.Sprintf("Kafka'ya bağlanılamadı (%d deneme sonrası): %v", maxRetries, err))
-	}
+// Hazır bir producer'ı bağlı durumdaki client olarak sarar
+func newConnectedClient(name string, producer sarama.SyncProducer) *Client {
+	conn := newConnection(name)
+	conn.connect()
+	close(conn.done)
+	return &Client{conn: conn, producer: producer}
+}
 
-	log.Println("Kafka'ya başarıyla bağlanıldı!")
-	return &Client{
-		producer: producer,
+func newProducerConfig(opts []ClientOption) *sarama.Config {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+	config.Producer.Partitioner = DefaultPartitioner.constructor()
+	for _, opt := range opts {
+		opt(config)
 	}
+	return config
 }
 
 func (c *Client) Close() error {
+	c.conn.close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.producer == nil {
+		return nil
+	}
 	return c.producer.Close()
 }
 
-func (c *Client) SendMessage(topic string, message interface{}) error {
-	// Mesajı JSON'a çevir
-	jsonData, err := json.Marshal(message)
+func (c *Client) ConnectionStatus() ConnectionStatus {
+	return c.conn.status()
+}
+
+// Bağlıysa fn hemen, değilse bağlantı kurulduğunda arka planda çağrılır
+func (c *Client) OnConnect(fn func()) {
+	c.conn.whenConnected(fn)
+}
+
+func (c *Client) Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...PublishOption) error {
+	rec, err := NewEventRecord(ctx, topic, eventType, payload, opts...)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message: %v", err)
+		return err
 	}
+	return c.Send(ctx, rec)
+}
 
-	// Kafka mesajını oluştur
-	msg := &sarama.ProducerMessage{
-		Topic:     topic,
-		Value:     sarama.StringEncoder(jsonData),
-		Timestamp: time.Now(),
+func (c *Client) Send(ctx context.Context, rec *Record) error {
+	c.mu.RLock()
+	producer := c.producer
+	c.mu.RUnlock()
+	if producer == nil {
+		return ErrNotConnected
 	}
 
-	// Mesajı gönder
-	_, _, err = c.producer.SendMessage(msg)
+	partition, offset, err := producer.SendMessage(toProducerMessage(rec))
+	c.conn.sent(err)
 	if err != nil {
-		return fmt.Errorf("failed to send message: %v", err)
+		return fmt.Errorf("failed to send message: %w", err)
 	}
-
+	rec.Partition = partition
+	rec.Offset = offset
 	return nil
 }
+
+func toProducerMessage(rec *Record) *sarama.ProducerMessage {
+	msg := &sarama.ProducerMessage{
+		Topic:     rec.Topic,
+		Timestamp: rec.Timestamp,
+	}
+	// nil değer compacted topic'te tombstone'dur, boş mesaj olarak gönderilmemeli
+	if rec.Value != nil {
+		msg.Value = sarama.ByteEncoder(rec.Value)
+	}
+	if len(rec.Key) > 0 {
+		msg.Key = sarama.ByteEncoder(rec.Key)
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	for k, v := range rec.Headers {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	return msg
+}
+
+func fromConsumerMessage(msg *sarama.ConsumerMessage) *Record {
+	rec := &Record{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   make(map[string]string, len(msg.Headers)),
+		Timestamp: msg.Timestamp,
+	}
+	for _, h := range msg.Headers {
+		rec.Headers[string(h.Key)] = string(h.Value)
+	}
+	return rec
+}
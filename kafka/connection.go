@@ -0,0 +1,232 @@
+package kafka
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Producer'lar broker'a ulaşılamasa da oluşturulur ve arka planda bağlanmayı dener. Bağlantı
+// kurulana kadar Send ErrNotConnected döner; olaylar outbox'ta bekler, servis degraded çalışır.
+
+var ErrNotConnected = errors.New("kafka is not connected")
+
+const (
+	reconnectMin = time.Second
+	reconnectMax = 30 * time.Second
+)
+
+type ConnectionStatus struct {
+	Name      string `json:"name"`
+	Connected bool   `json:"connected"`
+	// Bağlıyken bağlantının kurulduğu, değilse kesildiği an
+	Since time.Time `json:"since"`
+	// İlk bağlantıdan önceki başarısız deneme sayısı
+	Attempts  int       `json:"attempts,omitempty"`
+	LastError string    `json:"last_error,omitempty"`
+	LastErrAt time.Time `json:"last_error_at,omitempty"`
+	// Bağlantı kurulduktan sonra broker'a ulaşılamadığı için başarısız olan son gönderim
+	FailingSince time.Time `json:"failing_since,omitempty"`
+}
+
+// Bağlı ve son gönderimler broker hatasıyla başarısız olmuyorsa true
+func (s ConnectionStatus) Available() bool {
+	return s.Connected && s.FailingSince.IsZero()
+}
+
+// Bağlantı durumunu bildiren producer'lar; /ready bunları kontrol eder
+type ConnectionReporter interface {
+	ConnectionStatus() ConnectionStatus
+}
+
+type connection struct {
+	name string
+
+	mu           sync.Mutex
+	connected    bool
+	since        time.Time
+	attempts     int
+	lastError    string
+	lastErrAt    time.Time
+	failingSince time.Time
+	onConnect    []func()
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newConnection(name string) *connection {
+	return &connection{
+		name:  name,
+		since: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// İlk denemede bağlanamazsa open başarılı olana kadar arka planda artan ve rastgele
+// saptırılmış aralıklarla dener
+func (c *connection) start(open func() error) {
+	err := open()
+	if err == nil {
+		c.connect()
+		close(c.done)
+		return
+	}
+	c.failed(err)
+	log.Printf("Kafka'ya bağlanılamadı (%s), arka planda tekrar denenecek: %v", c.name, err)
+
+	go func() {
+		defer close(c.done)
+		for attempt := 1; ; attempt++ {
+			select {
+			case <-time.After(reconnectDelay(attempt)):
+			case <-c.stop:
+				return
+			}
+			err := open()
+			if err == nil {
+				c.connect()
+				return
+			}
+			c.failed(err)
+			log.Printf("Kafka'ya bağlanılamadı (%s, deneme %d): %v", c.name, attempt+1, err)
+		}
+	}()
+}
+
+// 1s, 2s, 4s ... en fazla 30s; aynı anda açılan servisler broker'a birlikte yüklenmesin
+// diye sürenin yarısı rastgele
+func reconnectDelay(attempt int) time.Duration {
+	d := reconnectMin << (attempt - 1)
+	if d <= 0 || d > reconnectMax {
+		d = reconnectMax
+	}
+	return d/2 + rand.N(d/2)
+}
+
+func (c *connection) connect() {
+	c.mu.Lock()
+	c.connected = true
+	c.since = time.Now()
+	callbacks := c.onConnect
+	c.onConnect = nil
+	c.mu.Unlock()
+
+	log.Printf("Kafka'ya başarıyla bağlanıldı! (%s)", c.name)
+	for _, fn := range callbacks {
+		fn()
+	}
+}
+
+// Kurulmuş bağlantı koptu; yeniden bağlanılana kadar bağlı değil görünür
+func (c *connection) disconnect(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected {
+		c.connected = false
+		c.since = time.Now()
+	}
+	c.lastError = err.Error()
+	c.lastErrAt = time.Now()
+}
+
+func (c *connection) failed(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	c.lastError = err.Error()
+	c.lastErrAt = time.Now()
+}
+
+// Bağlıysa fn hemen, değilse bağlantı kurulduğunda çağrılır
+func (c *connection) whenConnected(fn func()) {
+	c.mu.Lock()
+	if !c.connected {
+		c.onConnect = append(c.onConnect, fn)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	fn()
+}
+
+// Gönderim sonucu; broker'a ulaşılamadığı hatalar bağlantıyı degraded gösterir
+func (c *connection) sent(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		if !c.failingSince.IsZero() {
+			log.Printf("Kafka'ya gönderim tekrar başarılı (%s)", c.name)
+		}
+		c.failingSince = time.Time{}
+		return
+	}
+	if !IsUnavailable(err) {
+		return
+	}
+	c.lastError = err.Error()
+	c.lastErrAt = time.Now()
+	if c.failingSince.IsZero() {
+		c.failingSince = c.lastErrAt
+	}
+}
+
+// Arka plandaki denemeyi durdurur ve bitmesini bekler
+func (c *connection) close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+func (c *connection) status() ConnectionStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ConnectionStatus{
+		Name:         c.name,
+		Connected:    c.connected,
+		Since:        c.since,
+		Attempts:     c.attempts,
+		LastError:    c.lastError,
+		LastErrAt:    c.lastErrAt,
+		FailingSince: c.failingSince,
+	}
+}
+
+// Hata broker'a ulaşılamamasından kaynaklanıyorsa true; bu hatalar mesajın kendisiyle ilgili
+// değildir, broker döndüğünde gönderim başarılı olur
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	for _, target := range []error{
+		ErrNotConnected,
+		sarama.ErrOutOfBrokers,
+		sarama.ErrNotConnected,
+		sarama.ErrClosedClient,
+		sarama.ErrBrokerNotAvailable,
+		sarama.ErrLeaderNotAvailable,
+		sarama.ErrNotLeaderForPartition,
+		sarama.ErrRequestTimedOut,
+		sarama.ErrNetworkException,
+		sarama.ErrNotEnoughReplicas,
+		sarama.ErrNotEnoughReplicasAfterAppend,
+		io.EOF,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
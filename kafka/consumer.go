@@ -2,163 +2,169 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
-	"sync"
+	"strconv"
 	"time"
 
-	"github.com/IBM/sarama"
+	"govo/api/proto/events"
+	"govo/internal/requestctx"
 )
 
+// Consumer group ile çalışır, offset'ler mesaj işlendikten sonra commit edilir.
+// Servis kapalıyken yayınlanan olaylar yeniden başlatıldığında kaldığı yerden okunur.
 type Consumer struct {
-	consumer sarama.Consumer
-	topics   []string
+	*Router
+	subscriber Subscriber
+	groupID    string
+	topics     []string
+	publisher  Publisher
+	retryTiers []time.Duration
+	stats      *consumerStats
+	health     HealthThresholds
+	// Retry publisher'ı yokken geçici hatalı mesajın en fazla kaç kez yeniden deneneceği
+	maxRedeliveries int
 }
 
-func NewConsumer(brokers []string) *Consumer {
-	config := sarama.NewConfig()
-	config.Consumer.Return.Errors = true
+// Başarısız mesaj retry topic'ine taşınamazsa tekrar denemeden önce beklenen süre
+var redeliveryDelay = 5 * time.Second
 
-	// Retry/backoff mekanizması
-	maxRetries := 30
-	retryInterval := 2 * time.Second
+// Sınır aşılınca mesaj commit edilir ve hata olarak sayılır; tek mesaj partition'ı süresiz tıkamaz
+const DefaultMaxRedeliveries = 10
 
-	var consumer sarama.Consumer
-	var err error
-
-	for i := 0; i < maxRetries; i++ {
-		consumer, err = sarama.NewConsumer(brokers, config)
-		if err == nil {
-			break
-		}
-
-		log.Printf("Kafka consumer oluşturulamadı (deneme %d/%d): %v", i+1, maxRetries, err)
-		if i < maxRetries-1 {
-			log.Printf("%v sonra tekrar denenecek...", retryInterval)
-			time.Sleep(retryInterval)
-		}
+func NewConsumer(subscriber Subscriber, groupID string, topics ...string) *Consumer {
+	return &Consumer{
+		Router:     NewRouter(),
+		subscriber: subscriber,
+		groupID:    groupID,
+		topics:     topics,
+		stats:      newConsumerStats(),
+		health:     DefaultHealthThresholds,
+
+		maxRedeliveries: DefaultMaxRedeliveries,
 	}
+}
 
-	if err != nil {
-		panic(fmt.Sprintf("Kafka consumer oluşturulamadı (%d deneme sonrası): %v", maxRetries, err))
-	}
+// Retry publisher'ı olmayan consumer'da geçici hatalar için yeniden deneme sınırı
+func (c *Consumer) WithMaxRedeliveries(n int) *Consumer {
+	c.maxRedeliveries = n
+	return c
+}
 
-	log.Println("Kafka consumer başarıyla oluşturuldu!")
-	return &Consumer{
-		consumer: consumer,
-		topics:   []string{"payments"},
-	}
+// Status ve /ready'nin kullandığı eşikler
+func (c *Consumer) WithHealth(thresholds HealthThresholds) *Consumer {
+	c.health = thresholds
+	return c
 }
 
-func (c *Consumer) Close() error {
-	return c.consumer.Close()
+// Partition başına gecikme, son işlenen offset ve hata oranı
+func (c *Consumer) Status() ConsumerStatus {
+	status := c.stats.status(c.groupID, c.subscriptions(), c.health)
+	if r := registry.Load(); r != nil {
+		status.UnvalidatedPublished = r.UnvalidatedPublished()
+	}
+	return status
 }
 
 func (c *Consumer) Start(ctx context.Context) {
-	var wg sync.WaitGroup
+	if r, ok := c.subscriber.(sessionReporter); ok && r.reportsSessions() {
+		c.stats.mu.Lock()
+		c.stats.sessions = true
+		c.stats.mu.Unlock()
+	}
+	ctx = withAssignmentListener(ctx, c.stats.assign)
 
-	for _, topic := range c.topics {
-		partitions, err := c.consumer.Partitions(topic)
-		if err != nil {
-			log.Printf("Failed to get partitions for topic %s: %v", topic, err)
-			continue
+	for {
+		if err := c.subscriber.Subscribe(ctx, c.groupID, c.subscriptions(), c.process); err != nil {
+			log.Printf("Consumer group %s failed: %v", c.groupID, err)
+			c.stats.subscribeFailed(err)
 		}
-
-		for _, partition := range partitions {
-			pc, err := c.consumer.ConsumePartition(topic, partition, sarama.OffsetNewest)
-			if err != nil {
-				log.Printf("Failed to start consumer for partition %d: %v", partition, err)
-				continue
-			}
-
-			wg.Add(1)
-			go func(pc sarama.PartitionConsumer) {
-				defer wg.Done()
-				defer pc.Close()
-
-				for {
-					select {
-					case msg := <-pc.Messages():
-						var event map[string]interface{}
-						if err := json.Unmarshal(msg.Value, &event); err != nil {
-							log.Printf("Failed to unmarshal message: %v", err)
-							continue
-						}
-
-						// Event tipine göre işlem yap
-						switch event["event_type"] {
-						case "PAYMENT_CREATED":
-							handlePaymentCreated(event)
-						case "PAYMENT_CANCELLED":
-							handlePaymentCancelled(event)
-						default:
-							log.Printf("Unknown event type: %s", event["event_type"])
-						}
-
-					case err := <-pc.Errors():
-						log.Printf("Error: %v", err)
-
-					case <-ctx.Done():
-						return
-					}
-				}
-			}(pc)
+		if ctx.Err() != nil {
+			return
 		}
+		time.Sleep(2 * time.Second)
 	}
-
-	wg.Wait()
 }
 
-func handlePaymentCreated(event map[string]interface{}) {
-	// Ödeme oluşturulduğunda yapılacak işlemler
-	paymentType := event["payment_type"].(string)
-	amount := event["amount"].(float64)
-	customerID := uint(event["customer_id"].(float64))
+// Mesaj işlendiğinde veya retry/DLQ topic'ine taşındığında true döner; false dönerse mesaj
+// commit edilmez ve tekrar teslim edilir
+func (c *Consumer) process(ctx context.Context, rec *Record) bool {
+	if target := rec.Header(headerTargetGroup); target != "" && target != c.groupID {
+		return true
+	}
 
-	if paymentType == "CARD" {
-		// Kart bakiyesini güncelle
-		cardID := uint(event["card_id"].(float64))
-		updateCardBalance(cardID, amount)
-	} else {
-		// Kişisel bakiyeyi güncelle
-		updateCustomerBalance(customerID, amount)
+	// Retry topic'lerindeki mesajlar bekleme süresi dolana kadar işlenmez; handler beklemez,
+	// partition duraklatılır ve mesaj süre dolunca yeniden teslim edilir
+	if retryAt, err := time.Parse(time.RFC3339Nano, rec.Header(headerRetryAt)); err == nil && time.Now().Before(retryAt) {
+		PauseRecord(ctx, retryAt)
+		return false
 	}
-}
 
-func handlePaymentCancelled(event map[string]interface{}) {
-	// Ödeme iptal edildiğinde yapılacak işlemler
-	paymentType := event["payment_type"].(string)
-	amount := event["amount"].(float64)
-	customerID := uint(event["customer_id"].(float64))
+	c.stats.begin(rec, highWatermark(ctx))
 
-	if paymentType == "CARD" {
-		// Kart bakiyesini geri al
-		cardID := uint(event["card_id"].(float64))
-		refundCardBalance(cardID, amount)
+	envelope, err := rec.Envelope()
+	ctx = recordContext(ctx, rec, envelope)
+	if err != nil {
+		err = Permanent(fmt.Errorf("failed to unmarshal envelope: %v", err))
 	} else {
-		// Kişisel bakiyeyi geri al
-		refundCustomerBalance(customerID, amount)
+		err = c.dispatch(ctx, rec, envelope)
+	}
+	if err == nil {
+		c.stats.done(rec, nil)
+		return true
+	}
+	requestctx.Logf(ctx, "Failed to handle message at %s/%d/%d: %v", rec.Topic, rec.Partition, rec.Offset, err)
+	if c.publisher == nil {
+		// Kalıcı hata tekrar denemekle düzelmez; deneme sınırı dolan mesaj da atlanır
+		if IsPermanent(err) || c.stats.redeliveries(rec) >= c.maxRedeliveries {
+			requestctx.Logf(ctx, "Dropping message at %s/%d/%d: %v", rec.Topic, rec.Partition, rec.Offset, err)
+			c.stats.done(rec, err)
+			return true
+		}
+		// Taşınacak retry topic'i yok; mesaj commit edilmez, bir süre sonra yeniden denenir
+		c.stats.retry(rec, err)
+		PauseRecord(ctx, time.Now().Add(redeliveryDelay))
+		return false
 	}
-}
 
-// TODO: Bu fonksiyonlar gRPC çağrıları ile implement edilecek
-func updateCardBalance(cardID uint, amount float64) {
-	// Kart bakiyesini güncelle
-	log.Printf("Updating card balance for card %d: -%.2f", cardID, amount)
-}
+	for {
+		rerr := c.reroute(ctx, rec, err)
+		if rerr == nil {
+			c.stats.done(rec, err)
+			return true
+		}
+		log.Printf("%v", rerr)
 
-func updateCustomerBalance(customerID uint, amount float64) {
-	// Kişisel bakiyeyi güncelle
-	log.Printf("Updating customer balance for customer %d: -%.2f", customerID, amount)
+		select {
+		case <-time.After(redeliveryDelay):
+		case <-ctx.Done():
+			c.stats.abandon(rec)
+			return false
+		}
+	}
 }
 
-func refundCardBalance(cardID uint, amount float64) {
-	// Kart bakiyesini geri al
-	log.Printf("Refunding card balance for card %d: +%.2f", cardID, amount)
-}
+func (c *Consumer) dispatch(ctx context.Context, rec *Record, envelope *events.Envelope) error {
+	// Zarf öncesi JSON olaylar atlanır
+	if envelope == nil {
+		log.Printf("Skipping non-protobuf message at %s/%d/%d", rec.Topic, rec.Partition, rec.Offset)
+		return nil
+	}
 
-func refundCustomerBalance(customerID uint, amount float64) {
-	// Kişisel bakiyeyi geri al
-	log.Printf("Refunding customer balance for customer %d: +%.2f", customerID, amount)
+	attempt, _ := strconv.Atoi(rec.Header(headerAttempt))
+	msg := &Message{
+		Topic:     rec.Topic,
+		Partition: rec.Partition,
+		Offset:    rec.Offset,
+		Key:       rec.Key,
+		Envelope:  envelope,
+		Timestamp: rec.Timestamp,
+		Attempt:   attempt,
+		Group:     c.groupID,
+	}
+	err := c.Dispatch(ctx, msg)
+	if msg.unvalidated {
+		c.stats.skipValidation()
+	}
+	return err
 }
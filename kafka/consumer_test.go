@@ -0,0 +1,181 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"govo/api/proto/events"
+)
+
+func newPaymentRecord(t *testing.T, topic string) *Record {
+	t.Helper()
+	rec, err := NewEventRecord(context.Background(), topic, EventPaymentCompleted, &events.PaymentEvent{PaymentId: 7}, WithKey("7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+// Retry publisher'ı olmayan consumer hatalı mesajı commit etmez, yeniden dener
+func TestFailedMessageNotCommittedWithoutRetry(t *testing.T) {
+	defer func(d time.Duration) { redeliveryDelay = d }(redeliveryDelay)
+	redeliveryDelay = 10 * time.Millisecond
+
+	bus := NewMemoryBus(1)
+	defer bus.Close()
+
+	var mu sync.Mutex
+	calls := 0
+	consumer := NewConsumer(bus, "test-group", PaymentsTopic)
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls < 3 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	if err := bus.Send(context.Background(), newPaymentRecord(t, PaymentsTopic)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go consumer.Start(ctx)
+	if err := bus.Drain(ctx, "test-group", PaymentsTopic); err != nil {
+		t.Fatalf("message not committed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 3 {
+		t.Errorf("handler calls = %d, want 3", calls)
+	}
+	if status := consumer.Status(); status.Failed != 2 {
+		t.Errorf("failed = %d, want 2", status.Failed)
+	}
+}
+
+// Süresi dolmamış retry mesajı handler'ı bekletmez, partition duraklatılır
+func TestRetryAtPausesPartition(t *testing.T) {
+	consumer := NewConsumer(NewMemoryBus(1), "test-group", PaymentsTopic).WithRetry(NewMemoryBus(1), time.Minute)
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		t.Error("handler called before retry time")
+		return nil
+	})
+
+	rec := newPaymentRecord(t, RetryTopic(PaymentsTopic, time.Minute))
+	retryAt := time.Now().Add(time.Minute).UTC()
+	rec.Headers[headerRetryAt] = retryAt.Format(time.RFC3339Nano)
+
+	var paused time.Time
+	ctx := withPause(context.Background(), func(until time.Time) { paused = until })
+	start := time.Now()
+	if consumer.process(ctx, rec) {
+		t.Fatal("pending retry was committed")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("process blocked for %v", elapsed)
+	}
+	if !paused.Equal(retryAt) {
+		t.Errorf("paused until %v, want %v", paused, retryAt)
+	}
+}
+
+// MemoryBus duraklatılan kaydı süre dolunca aynı offset'ten yeniden verir
+func TestMemoryBusRedeliversPausedRecord(t *testing.T) {
+	bus := NewMemoryBus(1)
+	defer bus.Close()
+
+	retryAt := time.Now().Add(50 * time.Millisecond)
+	rec := newPaymentRecord(t, RetryTopic(PaymentsTopic, time.Minute))
+	rec.Headers[headerRetryAt] = retryAt.UTC().Format(time.RFC3339Nano)
+	if err := bus.Send(context.Background(), rec); err != nil {
+		t.Fatal(err)
+	}
+
+	handled := make(chan time.Time, 1)
+	consumer := NewConsumer(bus, "test-group", PaymentsTopic).WithRetry(bus, time.Minute)
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		handled <- time.Now()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go consumer.Start(ctx)
+
+	select {
+	case at := <-handled:
+		if at.Before(retryAt) {
+			t.Errorf("handled at %v, before retry time %v", at, retryAt)
+		}
+	case <-ctx.Done():
+		t.Fatal("paused record was not redelivered")
+	}
+	if err := bus.Drain(ctx, "test-group", RetryTopic(PaymentsTopic, time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Retry publisher'ı yokken kalıcı hata partition'ı duraklatmaz, mesaj commit edilir
+func TestPermanentErrorCommittedWithoutRetry(t *testing.T) {
+	consumer := NewConsumer(NewMemoryBus(1), "test-group", PaymentsTopic)
+	calls := 0
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		calls++
+		return Permanent(errors.New("invalid payment"))
+	})
+
+	ctx := withPause(context.Background(), func(time.Time) { t.Error("partition paused for a permanent error") })
+	if !consumer.process(ctx, newPaymentRecord(t, PaymentsTopic)) {
+		t.Fatal("permanent failure was not committed")
+	}
+
+	// Zarfı çözülemeyen mesaj da kalıcı hatadır
+	broken := &Record{Topic: PaymentsTopic, Offset: 1, Value: []byte{0xff, 0xff}, Headers: map[string]string{contentTypeHeader: contentTypeProto}}
+	if !consumer.process(ctx, broken) {
+		t.Fatal("undecodable message was not committed")
+	}
+
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+	if status := consumer.Status(); status.Failed != 2 {
+		t.Errorf("failed = %d, want 2", status.Failed)
+	}
+}
+
+// Geçici hata deneme sınırına kadar yeniden denenir, sonra mesaj atlanır
+func TestRedeliveryLimitWithoutRetry(t *testing.T) {
+	consumer := NewConsumer(NewMemoryBus(1), "test-group", PaymentsTopic).WithMaxRedeliveries(2)
+	calls := 0
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		calls++
+		return errors.New("database unavailable")
+	})
+
+	pauses := 0
+	ctx := withPause(context.Background(), func(time.Time) { pauses++ })
+	rec := newPaymentRecord(t, PaymentsTopic)
+	for i := 0; i < 2; i++ {
+		if consumer.process(ctx, rec) {
+			t.Fatalf("attempt %d committed before the limit", i+1)
+		}
+	}
+	if !consumer.process(ctx, rec) {
+		t.Fatal("message not committed after the redelivery limit")
+	}
+
+	if calls != 3 || pauses != 2 {
+		t.Errorf("handler calls = %d, pauses = %d, want 3 and 2", calls, pauses)
+	}
+	if status := consumer.Status(); status.Failed != 3 {
+		t.Errorf("failed = %d, want 3", status.Failed)
+	}
+}
@@ -0,0 +1,213 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/requestctx"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+type DLQMessage struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Envelope  *events.Envelope
+}
+
+func (m *DLQMessage) OriginalTopic() string {
+	if topic := m.Headers[headerOriginalTopic]; topic != "" {
+		return topic
+	}
+	return strings.TrimSuffix(m.Topic, ".dlq")
+}
+
+// DLQ topic'lerini okumak ve mesajları ana topic'e geri göndermek için yönetim istemcisi
+type DLQ struct {
+	client   sarama.Client
+	consumer sarama.Consumer
+	producer *Client
+	// Yeniden gönderilen kayıtlar bu aktörle ve admin kaynağıyla işlenir
+	actor string
+}
+
+func NewDLQ(brokers []string) (*DLQ, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %v", err)
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to create consumer: %v", err)
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		consumer.Close()
+		client.Close()
+		return nil, fmt.Errorf("failed to create producer: %v", err)
+	}
+
+	return &DLQ{
+		client:   client,
+		consumer: consumer,
+		producer: newConnectedClient("dlq", producer),
+	}, nil
+}
+
+func (d *DLQ) WithActor(actor string) *DLQ {
+	d.actor = actor
+	return d
+}
+
+func (d *DLQ) Close() error {
+	d.producer.Close()
+	d.consumer.Close()
+	return d.client.Close()
+}
+
+// Topic'teki mesajları partition sırasıyla, en fazla limit kadar döner (0 = hepsi)
+func (d *DLQ) List(topic string, limit int) ([]*DLQMessage, error) {
+	partitions, err := d.client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions for %s: %v", topic, err)
+	}
+
+	var messages []*DLQMessage
+	for _, partition := range partitions {
+		oldest, err := d.client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oldest offset for %s/%d: %v", topic, partition, err)
+		}
+		newest, err := d.client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get newest offset for %s/%d: %v", topic, partition, err)
+		}
+
+		if oldest >= newest {
+			continue
+		}
+
+		pc, err := d.consumer.ConsumePartition(topic, partition, oldest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s/%d: %v", topic, partition, err)
+		}
+		for offset := oldest; offset < newest; {
+			if limit > 0 && len(messages) >= limit {
+				pc.Close()
+				return messages, nil
+			}
+
+			select {
+			case msg := <-pc.Messages():
+				messages = append(messages, toDLQMessage(msg))
+				offset = msg.Offset + 1
+			case err := <-pc.Errors():
+				pc.Close()
+				return nil, fmt.Errorf("failed to read %s/%d: %v", topic, partition, err)
+			case <-time.After(10 * time.Second):
+				pc.Close()
+				return nil, fmt.Errorf("timed out reading %s/%d at offset %d", topic, partition, offset)
+			}
+		}
+		pc.Close()
+	}
+	return messages, nil
+}
+
+func (d *DLQ) Get(topic string, partition int32, offset int64) (*DLQMessage, error) {
+	pc, err := d.consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s/%d/%d: %v", topic, partition, offset, err)
+	}
+	defer pc.Close()
+
+	select {
+	case msg := <-pc.Messages():
+		return toDLQMessage(msg), nil
+	case err := <-pc.Errors():
+		return nil, fmt.Errorf("failed to read %s/%d/%d: %v", topic, partition, offset, err)
+	case <-time.After(10 * time.Second):
+		return nil, fmt.Errorf("timed out reading %s/%d/%d", topic, partition, offset)
+	}
+}
+
+// Mesajı hata header'ları temizlenmiş olarak ana topic'e gönderir, sadece hata alan grup işler
+func (d *DLQ) Redrive(msg *DLQMessage) error {
+	out := &Record{
+		Topic: msg.OriginalTopic(),
+		Key:   msg.Key,
+		Value: msg.Value,
+		Headers: map[string]string{
+			"x-redriven-from": fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset),
+		},
+	}
+	for key, value := range msg.Headers {
+		switch key {
+		case headerAttempt, headerError, headerFailedAt, headerRetryAt,
+			headerOriginalTopic, headerOriginalPartition, headerOriginalOffset:
+			continue
+		}
+		out.Headers[key] = value
+	}
+	out.Headers[headerSource] = requestctx.SourceAdmin
+	if d.actor != "" {
+		out.Headers[headerActorID] = d.actor
+	}
+
+	return d.producer.Send(context.Background(), out)
+}
+
+func toDLQMessage(msg *sarama.ConsumerMessage) *DLQMessage {
+	m := &DLQMessage{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Timestamp: msg.Timestamp,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   make(map[string]string, len(msg.Headers)),
+	}
+	for _, h := range msg.Headers {
+		m.Headers[string(h.Key)] = string(h.Value)
+	}
+
+	envelope := &events.Envelope{}
+	if m.Headers[contentTypeHeader] == contentTypeProto && proto.Unmarshal(msg.Value, envelope) == nil {
+		m.Envelope = envelope
+	}
+	return m
+}
+
+func (m *DLQMessage) Attempts() int {
+	n, _ := strconv.Atoi(m.Headers[headerAttempt])
+	return n
+}
+
+func (m *DLQMessage) Error() string {
+	return m.Headers[headerError]
+}
+
+func (m *DLQMessage) TargetGroup() string {
+	return m.Headers[headerTargetGroup]
+}
+
+func (m *DLQMessage) FailedAt() string {
+	return m.Headers[headerFailedAt]
+}
@@ -0,0 +1,79 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"govo/internal/requestctx"
+
+	"github.com/IBM/sarama"
+)
+
+func newMockDLQBroker(t *testing.T) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(PaymentsTopic, 0, broker.BrokerID()).
+			SetLeader(PaymentsTopic+".dlq", 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t),
+	})
+	return broker
+}
+
+func TestDLQOpenClose(t *testing.T) {
+	broker := newMockDLQBroker(t)
+	defer broker.Close()
+
+	dlq, err := NewDLQ([]string{broker.Addr()})
+	if err != nil {
+		t.Fatalf("NewDLQ: %v", err)
+	}
+	if status := dlq.producer.ConnectionStatus(); !status.Available() {
+		t.Errorf("DLQ producer status = %+v, want connected", status)
+	}
+	if err := dlq.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestDLQRedrive(t *testing.T) {
+	broker := newMockDLQBroker(t)
+	defer broker.Close()
+
+	dlq, err := NewDLQ([]string{broker.Addr()})
+	if err != nil {
+		t.Fatalf("NewDLQ: %v", err)
+	}
+	defer dlq.Close()
+
+	msg := &DLQMessage{
+		Topic:     PaymentsTopic + ".dlq",
+		Partition: 0,
+		Offset:    12,
+		Key:       []byte("42"),
+		Value:     []byte("payload"),
+		Headers: map[string]string{
+			headerOriginalTopic: PaymentsTopic,
+			headerError:         "boom",
+		},
+	}
+	if err := dlq.Redrive(msg); err != nil {
+		t.Fatalf("Redrive: %v", err)
+	}
+}
+
+// Yeniden gönderilen kayıtları işleyen handler yönetici kaynağını ve aktörünü görür
+func TestRedrivenRecordContext(t *testing.T) {
+	rec := &Record{Headers: map[string]string{headerSource: requestctx.SourceAdmin, headerActorID: "govoctl:ops"}}
+	md := requestctx.FromContext(recordContext(context.Background(), rec, nil))
+	if md.Source != requestctx.SourceAdmin || md.Actor != "govoctl:ops" || !md.IsAdmin() {
+		t.Errorf("metadata = %+v, want admin source", md)
+	}
+
+	md = requestctx.FromContext(recordContext(context.Background(), &Record{}, nil))
+	if md.Source != requestctx.SourceKafka {
+		t.Errorf("source = %q, want %q", md.Source, requestctx.SourceKafka)
+	}
+}
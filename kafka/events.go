@@ -0,0 +1,107 @@
+package kafka
+
+import (
+	"fmt"
+
+	"govo/api/proto/events"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	PaymentsTopic      = "payments"
+	CardsTopic         = "cards"
+	CustomersTopic     = "customers"
+	NotificationsTopic = "notifications"
+
+	// Log-compacted, anahtar başına güncel durum; silinen kayıtlar için tombstone
+	CardStateTopic = "cards.state"
+	// Kişisel veri taşımaz; eski sürümlerin yazdığı kayıtlarda ad, e-posta, telefon ve adres olabilir.
+	// Silinen ya da anonimleştirilen müşterinin kaydı tombstone ile kapatılır; önceki değerler en geç
+	// max.compaction.lag.ms (1 gün) içinde compaction ile, tombstone da delete.retention.ms (1 gün)
+	// sonra silinir (topics.json).
+	CustomerStateTopic = "customers.state"
+)
+
+const (
+	EventPaymentCreated           = "PAYMENT_CREATED"
+	EventPaymentCancelled         = "PAYMENT_CANCELLED"
+	EventPaymentCompleted         = "PAYMENT_COMPLETED"
+	EventPaymentFailed            = "PAYMENT_FAILED"
+	EventCardIssued               = "CARD_ISSUED"
+	EventCardUpdated              = "CARD_UPDATED"
+	EventCardRemoved              = "CARD_REMOVED"
+	EventCardStatusChanged        = "CARD_STATUS_CHANGED"
+	EventCardLimitChanged         = "CARD_LIMIT_CHANGED"
+	EventCustomerCreated          = "CUSTOMER_CREATED"
+	EventCustomerUpdated          = "CUSTOMER_UPDATED"
+	EventCustomerDeleted          = "CUSTOMER_DELETED"
+	EventCustomerErasureRequested = "CUSTOMER_ERASURE_REQUESTED"
+	EventCustomerErasureCompleted = "CUSTOMER_ERASURE_COMPLETED"
+	EventStepUpCodeIssued         = "STEP_UP_CODE_ISSUED"
+	EventCardState                = "CARD_STATE"
+	EventCustomerState            = "CUSTOMER_STATE"
+)
+
+// Her olay tipinin payload mesajı ve güncel şema sürümü.
+// Payload mesajı değiştiğinde sürüm artırılır, cmd/eventcompat bunu kontrol eder.
+type Schema struct {
+	Type    string
+	Version int32
+	Payload protoreflect.FullName
+}
+
+var schemas = map[string]Schema{}
+
+func registerSchema(eventType string, version int32, payload proto.Message) {
+	schemas[eventType] = Schema{
+		Type:    eventType,
+		Version: version,
+		Payload: payload.ProtoReflect().Descriptor().FullName(),
+	}
+}
+
+func init() {
+	registerSchema(EventPaymentCreated, 2, &events.PaymentEvent{})
+	registerSchema(EventPaymentCancelled, 2, &events.PaymentEvent{})
+	registerSchema(EventPaymentCompleted, 1, &events.PaymentEvent{})
+	registerSchema(EventPaymentFailed, 1, &events.PaymentEvent{})
+	registerSchema(EventCardIssued, 2, &events.CardEvent{})
+	registerSchema(EventCardUpdated, 1, &events.CardEvent{})
+	registerSchema(EventCardRemoved, 2, &events.CardEvent{})
+	registerSchema(EventCardStatusChanged, 2, &events.CardEvent{})
+	registerSchema(EventCardLimitChanged, 1, &events.CardEvent{})
+	registerSchema(EventCustomerCreated, 1, &events.CustomerEvent{})
+	registerSchema(EventCustomerUpdated, 1, &events.CustomerEvent{})
+	registerSchema(EventCustomerDeleted, 1, &events.CustomerEvent{})
+	registerSchema(EventCustomerErasureRequested, 1, &events.CustomerErasureRequested{})
+	registerSchema(EventCustomerErasureCompleted, 1, &events.CustomerErasureCompleted{})
+	registerSchema(EventStepUpCodeIssued, 2, &events.StepUpCodeIssued{})
+	registerSchema(EventCardState, 1, &events.CardState{})
+	registerSchema(EventCustomerState, 2, &events.CustomerState{})
+}
+
+func LookupSchema(eventType string) (Schema, bool) {
+	schema, ok := schemas[eventType]
+	return schema, ok
+}
+
+func Schemas() []Schema {
+	list := make([]Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		list = append(list, schema)
+	}
+	return list
+}
+
+func checkPayload(eventType string, payload proto.Message) (Schema, error) {
+	schema, ok := schemas[eventType]
+	if !ok {
+		return Schema{}, fmt.Errorf("unknown event type %s", eventType)
+	}
+	if name := payload.ProtoReflect().Descriptor().FullName(); name != schema.Payload {
+		return Schema{}, fmt.Errorf("event %s expects %s payload, got %s", eventType, schema.Payload, name)
+	}
+	return schema, nil
+}
@@ -0,0 +1,447 @@
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Feed bir topic'i consumer group olmadan en yeni offset'ten okur ve son kayıtları partition başına
+// bellekte tutar. Abonelikler (ör. gRPC stream'leri) kendi konumlarından okur; yavaş bir abone
+// diğerlerini bekletmez. Tampondan düşmüş kayıtlar gerektiğinde Kafka'dan tekrar okunur.
+
+// Konum: partition -> okunacak sonraki offset
+type Position map[int32]int64
+
+var (
+	// Retention'ı dolmuş resume token
+	ErrPositionExpired = errors.New("resume position is no longer available")
+	ErrFeedStopped     = errors.New("feed is not running")
+)
+
+// Resume token istemciye opak döner: topic|partition:offset,...
+func (p Position) Token(topic string) string {
+	partitions := make([]int, 0, len(p))
+	for partition := range p {
+		partitions = append(partitions, int(partition))
+	}
+	sort.Ints(partitions)
+
+	parts := make([]string, len(partitions))
+	for i, partition := range partitions {
+		parts[i] = fmt.Sprintf("%d:%d", partition, p[int32(partition)])
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(topic + "|" + strings.Join(parts, ",")))
+}
+
+func ParsePosition(topic, token string) (Position, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resume token: %v", err)
+	}
+	name, offsets, ok := strings.Cut(string(raw), "|")
+	if !ok || name != topic {
+		return nil, errors.New("invalid resume token")
+	}
+
+	pos := make(Position)
+	if offsets == "" {
+		return pos, nil
+	}
+	for _, part := range strings.Split(offsets, ",") {
+		p, o, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, errors.New("invalid resume token")
+		}
+		partition, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid resume token")
+		}
+		offset, err := strconv.ParseInt(o, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, errors.New("invalid resume token")
+		}
+		pos[int32(partition)] = offset
+	}
+	return pos, nil
+}
+
+func (p Position) clone() Position {
+	c := make(Position, len(p))
+	for k, v := range p {
+		c[k] = v
+	}
+	return c
+}
+
+// Aboneye giden kayıt; Position bu kayıttan sonra devam edilecek konumdur.
+// Okuma hatasında Err dolu son olay gönderilip kanal kapanır.
+type FeedEvent struct {
+	Record   *Record
+	Position Position
+	Err      error
+}
+
+type Feed struct {
+	brokers    []string
+	topic      string
+	bufferSize int
+	// Kafka'dan geriye dönük okumada yeni kayıt gelmezse okuma bu süre sonunda biter
+	fetchIdle time.Duration
+
+	mu      sync.Mutex
+	client  sarama.Client
+	buffers map[int32][]*Record
+	next    Position
+	// Yeni kayıt gelince kapatılıp yenilenir
+	changed chan struct{}
+
+	conn      *connection
+	ready     chan struct{}
+	readyOnce sync.Once
+	// Start dönünce kapanır
+	done chan struct{}
+}
+
+func NewFeed(brokers []string, topic string) *Feed {
+	return &Feed{
+		brokers:    brokers,
+		topic:      topic,
+		bufferSize: 1000,
+		fetchIdle:  2 * time.Second,
+		buffers:    make(map[int32][]*Record),
+		next:       make(Position),
+		changed:    make(chan struct{}),
+		conn:       newConnection("feed " + topic),
+		ready:      make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Partition başına bellekte tutulan kayıt sayısı
+func (f *Feed) WithBufferSize(n int) *Feed {
+	f.bufferSize = n
+	return f
+}
+
+func (f *Feed) Topic() string {
+	return f.topic
+}
+
+// Topic'i context kapanana kadar okur. Broker'a ulaşılamazsa ya da bağlantı koparsa artan ve
+// rastgele saptırılmış aralıklarla yeniden bağlanır; okuma kaldığı offset'ten devam eder.
+func (f *Feed) Start(ctx context.Context) {
+	defer close(f.done)
+
+	for attempt := 1; ; attempt++ {
+		connected, err := f.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			attempt = 1
+			f.conn.disconnect(err)
+		} else {
+			f.conn.failed(err)
+		}
+
+		delay := reconnectDelay(attempt)
+		log.Printf("Feed %s Kafka'dan okuyamıyor: %v, %v sonra tekrar denenecek...", f.topic, err, delay.Truncate(time.Millisecond))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Bağlanır ve partition'ları okur; bağlantı kurulduysa connected true döner
+func (f *Feed) run(ctx context.Context) (connected bool, err error) {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+
+	client, err := sarama.NewClient(f.brokers, config)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return false, fmt.Errorf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(f.topic)
+	if err != nil {
+		return false, fmt.Errorf("failed to get partitions for %s: %v", f.topic, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+		f.mu.Lock()
+		f.client = nil
+		f.mu.Unlock()
+	}()
+
+	for _, partition := range partitions {
+		pc, err := f.consumePartition(client, consumer, partition)
+		if err != nil {
+			return false, err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Partition okuması biterse diğerleri de durdurulur, feed yeniden bağlanır
+			defer cancel()
+			defer pc.Close()
+			errs := pc.Errors()
+			for {
+				select {
+				case msg, ok := <-pc.Messages():
+					if !ok {
+						return
+					}
+					f.append(fromConsumerMessage(msg))
+					f.conn.sent(nil)
+				case perr, ok := <-errs:
+					if !ok {
+						errs = nil
+						continue
+					}
+					f.conn.sent(perr.Err)
+					log.Printf("Feed %s partition %d error: %v", f.topic, perr.Partition, perr.Err)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	f.mu.Lock()
+	f.client = client
+	f.mu.Unlock()
+	f.conn.connect()
+	f.readyOnce.Do(func() { close(f.ready) })
+
+	<-ctx.Done()
+	return true, errors.New("feed stopped reading")
+}
+
+// Yeniden bağlanınca kalınan offset'ten devam edilir; o offset retention'dan düştüyse tampon
+// temizlenir ve en yeniden okunur, geride kalan aboneler ErrPositionExpired alır
+func (f *Feed) consumePartition(client sarama.Client, consumer sarama.Consumer, partition int32) (sarama.PartitionConsumer, error) {
+	newest, err := client.GetOffset(f.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get newest offset for %s/%d: %v", f.topic, partition, err)
+	}
+
+	f.mu.Lock()
+	offset, known := f.next[partition]
+	f.mu.Unlock()
+	if !known {
+		offset = newest
+	}
+
+	pc, err := consumer.ConsumePartition(f.topic, partition, offset)
+	if errors.Is(err, sarama.ErrOffsetOutOfRange) && offset != newest {
+		log.Printf("Feed %s partition %d offset %d is no longer available, reading from %d", f.topic, partition, offset, newest)
+		offset = newest
+		f.mu.Lock()
+		delete(f.buffers, partition)
+		f.mu.Unlock()
+		pc, err = consumer.ConsumePartition(f.topic, partition, offset)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume %s/%d: %v", f.topic, partition, err)
+	}
+
+	f.mu.Lock()
+	f.next[partition] = offset
+	f.mu.Unlock()
+	return pc, nil
+}
+
+// Bağlantı durumu; /ready feed'leri WithFeeds ile kontrol eder
+func (f *Feed) ConnectionStatus() ConnectionStatus {
+	return f.conn.status()
+}
+
+func (f *Feed) append(rec *Record) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buf := append(f.buffers[rec.Partition], rec)
+	if len(buf) > f.bufferSize {
+		buf = buf[len(buf)-f.bufferSize:]
+	}
+	f.buffers[rec.Partition] = buf
+	f.next[rec.Partition] = rec.Offset + 1
+
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// Feed'in şu anki konumu; bu konumdan abone olan sadece bundan sonraki kayıtları alır.
+// Feed henüz Kafka'ya bağlanmadıysa beklemeden ErrNotConnected döner.
+func (f *Feed) Position(ctx context.Context) (Position, error) {
+	select {
+	case <-f.done:
+		return nil, ErrFeedStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	select {
+	case <-f.ready:
+	default:
+		return nil, ErrNotConnected
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.next.clone(), nil
+}
+
+// from konumundan itibaren kayıtları döner; kanal ctx kapanınca ya da okuma hatasından sonra kapanır.
+// from'da olmayan partition'lar şu anki konumdan okunur.
+func (f *Feed) Subscribe(ctx context.Context, from Position) (<-chan *FeedEvent, error) {
+	current, err := f.Position(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cursor := current
+	for partition, offset := range from {
+		if _, ok := cursor[partition]; !ok {
+			return nil, ErrPositionExpired
+		}
+		cursor[partition] = offset
+	}
+
+	out := make(chan *FeedEvent)
+	go func() {
+		defer close(out)
+		for {
+			f.mu.Lock()
+			changed := f.changed
+			f.mu.Unlock()
+
+			for partition := range cursor {
+				records, err := f.read(ctx, partition, cursor[partition])
+				if err != nil {
+					select {
+					case out <- &FeedEvent{Err: err}:
+					case <-ctx.Done():
+					}
+					return
+				}
+				for _, rec := range records {
+					cursor[partition] = rec.Offset + 1
+					select {
+					case out <- &FeedEvent{Record: rec, Position: cursor.clone()}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-changed:
+			case <-f.done:
+				select {
+				case out <- &FeedEvent{Err: ErrFeedStopped}:
+				case <-ctx.Done():
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// offset'ten itibaren partition'daki kayıtlar; tamponda olmayan kısım Kafka'dan okunur
+func (f *Feed) read(ctx context.Context, partition int32, offset int64) ([]*Record, error) {
+	f.mu.Lock()
+	buf := f.buffers[partition]
+	next := f.next[partition]
+	client := f.client
+	f.mu.Unlock()
+
+	if offset >= next {
+		return nil, nil
+	}
+
+	// Tampondaki ilk kayıttan önceki kısım
+	until := next
+	if len(buf) > 0 {
+		until = buf[0].Offset
+	}
+	var records []*Record
+	if offset < until {
+		if client == nil {
+			return nil, ErrNotConnected
+		}
+		fetched, err := f.fetch(ctx, client, partition, offset, until)
+		if err != nil {
+			return nil, err
+		}
+		records = fetched
+	}
+
+	i := sort.Search(len(buf), func(i int) bool { return buf[i].Offset >= offset })
+	return append(records, buf[i:]...), nil
+}
+
+// [from, until) aralığını Kafka'dan okur. Compacted topic'lerde aralıkta boşluklar olabilir,
+// bu yüzden until'e ulaşılamazsa fetchIdle sonunda okunanlar döner.
+func (f *Feed) fetch(ctx context.Context, client sarama.Client, partition int32, from, until int64) ([]*Record, error) {
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+
+	pc, err := consumer.ConsumePartition(f.topic, partition, from)
+	if errors.Is(err, sarama.ErrOffsetOutOfRange) {
+		return nil, ErrPositionExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s/%d from %d: %v", f.topic, partition, from, err)
+	}
+	defer pc.Close()
+
+	var records []*Record
+	idle := time.NewTimer(f.fetchIdle)
+	defer idle.Stop()
+	for {
+		select {
+		case msg, ok := <-pc.Messages():
+			if !ok || msg.Offset >= until {
+				return records, nil
+			}
+			records = append(records, fromConsumerMessage(msg))
+			if msg.Offset >= until-1 {
+				return records, nil
+			}
+			idle.Reset(f.fetchIdle)
+		case <-idle.C:
+			return records, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Kafka'ya ulaşılamazken feed beklemeden hata döner, /ready degraded gösterir ve bağlanmayı dener
+func TestFeedUnavailable(t *testing.T) {
+	feed := NewFeed([]string{"127.0.0.1:1"}, PaymentsTopic)
+	ctx, cancel := context.WithCancel(context.Background())
+	go feed.Start(ctx)
+
+	if _, err := feed.Position(context.Background()); !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("Position = %v, want %v", err, ErrNotConnected)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for feed.ConnectionStatus().Attempts == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("feed did not try to connect")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	response := ReadinessHandler().WithFeeds(feed).Status()
+	if response.Status != StatusDegraded || len(response.Feeds) != 1 || response.Feeds[0].Connected {
+		t.Errorf("readiness = %+v", response)
+	}
+
+	cancel()
+	<-feed.done
+	if _, err := feed.Position(context.Background()); !errors.Is(err, ErrFeedStopped) {
+		t.Errorf("Position after stop = %v, want %v", err, ErrFeedStopped)
+	}
+}
+
+func TestFeedConnects(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(PaymentsTopic, 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(PaymentsTopic, 0, sarama.OffsetNewest, 5).
+			SetOffset(PaymentsTopic, 0, sarama.OffsetOldest, 0),
+		"FetchRequest": sarama.NewMockFetchResponse(t, 1),
+	})
+
+	feed := NewFeed([]string{broker.Addr()}, PaymentsTopic)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-feed.done
+	}()
+	go feed.Start(ctx)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !feed.ConnectionStatus().Connected {
+		if time.Now().After(deadline) {
+			t.Fatalf("feed not connected: %+v", feed.ConnectionStatus())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	pos, err := feed.Position(context.Background())
+	if err != nil || pos[0] != 5 {
+		t.Errorf("Position = %v, %v, want partition 0 at 5", pos, err)
+	}
+	if response := ReadinessHandler().WithFeeds(feed).Status(); response.Status != StatusReady {
+		t.Errorf("readiness = %+v", response)
+	}
+}
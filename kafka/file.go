@@ -0,0 +1,221 @@
+package kafka
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kayıtları dosyaya yazan MemoryBus; broker olmadan yerel geliştirme için.
+// Her partition dir/<topic>/<partition>.jsonl dosyasına eklenir, grup offset'leri
+// dir/offsets.json içinde tutulur. Aynı dizini tek bir process kullanmalıdır.
+type FileBus struct {
+	*MemoryBus
+	dir string
+
+	mu      sync.Mutex
+	files   map[topicPartition]*os.File
+	offsets map[string]map[string]int64
+}
+
+type fileRecord struct {
+	Key       []byte            `json:"key,omitempty"`
+	Value     []byte            `json:"value"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+func NewFileBus(dir string, partitions int) (*FileBus, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create bus directory: %v", err)
+	}
+
+	b := &FileBus{
+		MemoryBus: NewMemoryBus(partitions),
+		dir:       dir,
+		files:     make(map[topicPartition]*os.File),
+		offsets:   make(map[string]map[string]int64),
+	}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	b.MemoryBus.store = b
+	return b, nil
+}
+
+// Önceki çalıştırmadan kalan kayıtları ve offset'leri belleğe alır
+func (b *FileBus) load() error {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read bus directory: %v", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		topic := entry.Name()
+		files, err := filepath.Glob(filepath.Join(b.dir, topic, "*.jsonl"))
+		if err != nil {
+			return err
+		}
+
+		t := b.MemoryBus.topic(topic)
+		for _, path := range files {
+			partition, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".jsonl"))
+			if err != nil {
+				continue
+			}
+			// Dizin daha fazla partition ile oluşturulmuşsa hepsini koru
+			for len(t.partitions) <= partition {
+				t.partitions = append(t.partitions, nil)
+			}
+			records, err := readPartitionFile(path, topic, int32(partition))
+			if err != nil {
+				return err
+			}
+			t.partitions[partition] = records
+		}
+	}
+
+	data, err := os.ReadFile(b.offsetsPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read offsets: %v", err)
+	}
+	if err := json.Unmarshal(data, &b.offsets); err != nil {
+		return fmt.Errorf("failed to parse offsets: %v", err)
+	}
+
+	for groupID, offsets := range b.offsets {
+		group := b.MemoryBus.group(groupID)
+		for key, offset := range offsets {
+			i := strings.LastIndex(key, "/")
+			if i < 0 {
+				continue
+			}
+			partition, err := strconv.Atoi(key[i+1:])
+			if err != nil {
+				continue
+			}
+			group.offsets[topicPartition{Topic: key[:i], Partition: int32(partition)}] = offset
+		}
+	}
+	return nil
+}
+
+func readPartitionFile(path, topic string, partition int32) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, len(records)+1, err)
+		}
+		records = append(records, &Record{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    int64(len(records)),
+			Key:       line.Key,
+			Value:     line.Value,
+			Headers:   line.Headers,
+			Timestamp: time.UnixMilli(line.Timestamp),
+		})
+	}
+	return records, scanner.Err()
+}
+
+func (b *FileBus) append(rec *Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tp := topicPartition{Topic: rec.Topic, Partition: rec.Partition}
+	f, ok := b.files[tp]
+	if !ok {
+		if err := os.MkdirAll(filepath.Join(b.dir, rec.Topic), 0o755); err != nil {
+			return fmt.Errorf("failed to create topic directory: %v", err)
+		}
+		path := filepath.Join(b.dir, rec.Topic, fmt.Sprintf("%d.jsonl", rec.Partition))
+		var err error
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open partition file: %v", err)
+		}
+		b.files[tp] = f
+	}
+
+	data, err := json.Marshal(fileRecord{
+		Key:       rec.Key,
+		Value:     rec.Value,
+		Headers:   rec.Headers,
+		Timestamp: rec.Timestamp.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append record: %v", err)
+	}
+	return nil
+}
+
+func (b *FileBus) commit(groupID string, tp topicPartition, offset int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offsets, ok := b.offsets[groupID]
+	if !ok {
+		offsets = make(map[string]int64)
+		b.offsets[groupID] = offsets
+	}
+	offsets[fmt.Sprintf("%s/%d", tp.Topic, tp.Partition)] = offset
+
+	data, err := json.MarshalIndent(b.offsets, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Yarım yazılmış dosya kalmasın diye önce geçici dosyaya yaz
+	tmp := b.offsetsPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write offsets: %v", err)
+	}
+	return os.Rename(tmp, b.offsetsPath())
+}
+
+func (b *FileBus) Close() error {
+	b.MemoryBus.Close()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var firstErr error
+	for _, f := range b.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	b.files = make(map[topicPartition]*os.File)
+	return firstErr
+}
+
+func (b *FileBus) offsetsPath() string {
+	return filepath.Join(b.dir, "offsets.json")
+}
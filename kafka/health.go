@@ -0,0 +1,547 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Consumer'ın partition başına ilerlemesi ve handler hata oranı. Servisler /ready ile
+// eşikler aşıldığında hazır değil döner, /consumers ve govoctl consumers status aynı veriyi gösterir.
+
+// Sağlık eşikleri; sıfır olan eşik kontrol edilmez
+type HealthThresholds struct {
+	// Partition başına izin verilen en fazla gecikme (mesaj)
+	MaxLag int64 `json:"max_lag"`
+	// Pencere içindeki başarısız mesaj oranı, 0-1
+	MaxErrorRate float64 `json:"max_error_rate"`
+	// Oran bu kadar mesajdan azsa değerlendirilmez
+	MinSamples int `json:"min_samples"`
+	// Tek bir mesajın işlenmesi ya da broker'a bağlanmak bu süreyi aşarsa consumer takılmış sayılır
+	StallTimeout time.Duration `json:"stall_timeout"`
+}
+
+var DefaultHealthThresholds = HealthThresholds{
+	MaxLag:       10000,
+	MaxErrorRate: 0.5,
+	MinSamples:   20,
+	StallTimeout: 2 * time.Minute,
+}
+
+// CONSUMER_MAX_LAG, CONSUMER_MAX_ERROR_RATE, CONSUMER_MIN_SAMPLES ve CONSUMER_STALL_TIMEOUT
+// değişkenlerini okur, boş olanlar varsayılan kalır
+func HealthThresholdsFromEnv() (HealthThresholds, error) {
+	t := DefaultHealthThresholds
+
+	if v := os.Getenv("CONSUMER_MAX_LAG"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return t, fmt.Errorf("invalid CONSUMER_MAX_LAG %q", v)
+		}
+		t.MaxLag = n
+	}
+	if v := os.Getenv("CONSUMER_MAX_ERROR_RATE"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return t, fmt.Errorf("invalid CONSUMER_MAX_ERROR_RATE %q", v)
+		}
+		t.MaxErrorRate = f
+	}
+	if v := os.Getenv("CONSUMER_MIN_SAMPLES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return t, fmt.Errorf("invalid CONSUMER_MIN_SAMPLES %q", v)
+		}
+		t.MinSamples = n
+	}
+	if v := os.Getenv("CONSUMER_STALL_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return t, fmt.Errorf("invalid CONSUMER_STALL_TIMEOUT %q", v)
+		}
+		t.StallTimeout = d
+	}
+	return t, nil
+}
+
+const (
+	// Hata oranı son errorWindow içindeki dakikalık sayaçlardan hesaplanır
+	errorWindow   = 5 * time.Minute
+	bucketSize    = time.Minute
+	bucketsPerWin = int(errorWindow / bucketSize)
+)
+
+type PartitionStatus struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Son işlenen mesajın offset'i, henüz işlenmediyse -1
+	LastOffset      int64     `json:"last_offset"`
+	LastProcessedAt time.Time `json:"last_processed_at,omitempty"`
+	// Partition'a yazılacak sonraki offset, bilinmiyorsa -1
+	HighWatermark int64 `json:"high_watermark"`
+	Lag           int64 `json:"lag"`
+	// İşlenmekte olan mesajın başladığı an
+	ProcessingSince time.Time `json:"processing_since,omitempty"`
+}
+
+type ConsumerStatus struct {
+	Group      string            `json:"group"`
+	Topics     []string          `json:"topics"`
+	Partitions []PartitionStatus `json:"partitions"`
+	Lag        int64             `json:"lag"`
+	// Başlangıçtan beri işlenen ve başarısız olan mesajlar
+	Processed uint64 `json:"processed"`
+	Failed    uint64 `json:"failed"`
+	// Son 5 dakikadaki başarısız mesaj oranı
+	ErrorRate   float64   `json:"error_rate"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitempty"`
+	// Şema kayıt defterine ulaşılamadığı için şeması kontrol edilmeden işlenen mesajlar ve
+	// bu süreçte şema ID'si olmadan yayınlanan olaylar (fail-open)
+	Unvalidated          uint64 `json:"unvalidated,omitempty"`
+	UnvalidatedPublished uint64 `json:"unvalidated_published,omitempty"`
+	// Broker oturumu yoksa oturumun kapandığı an
+	DisconnectedSince time.Time `json:"disconnected_since,omitempty"`
+
+	Healthy bool `json:"healthy"`
+	// Broker oturumu uzun süredir yok; Kafka kapalıyken servis degraded çalışır
+	Degraded bool     `json:"degraded,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+type partitionState struct {
+	lastOffset      int64
+	lastProcessedAt time.Time
+	highWatermark   int64
+	processingSince time.Time
+	// Commit edilmeden yeniden teslim edilen offset ve deneme sayısı
+	redelivering int64
+	redeliveries int
+}
+
+type rateBucket struct {
+	start     time.Time
+	processed int
+	failed    int
+}
+
+type consumerStats struct {
+	mu         sync.Mutex
+	partitions map[topicPartition]*partitionState
+	processed  uint64
+	failed     uint64
+	buckets    [bucketsPerWin]rateBucket
+	lastError  string
+	lastErrAt  time.Time
+	// Şeması kontrol edilmeden işlenen mesajlar
+	unvalidated uint64
+	// Subscriber oturumlarını bildiriyorsa oturum yokken disconnected dolu
+	sessions     bool
+	disconnected time.Time
+}
+
+func newConsumerStats() *consumerStats {
+	return &consumerStats{
+		partitions:   make(map[topicPartition]*partitionState),
+		disconnected: time.Now(),
+	}
+}
+
+// Oturumları bildiren subscriber'lar (KafkaSubscriber); bellek içi ve dosya tabanlı bus'lar bildirmez
+type sessionReporter interface {
+	reportsSessions() bool
+}
+
+func (s *consumerStats) partition(tp topicPartition) *partitionState {
+	p, ok := s.partitions[tp]
+	if !ok {
+		p = &partitionState{lastOffset: -1, highWatermark: -1}
+		s.partitions[tp] = p
+	}
+	return p
+}
+
+func (s *consumerStats) begin(rec *Record, highWatermark int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.partition(topicPartition{Topic: rec.Topic, Partition: rec.Partition})
+	p.processingSince = time.Now()
+	// Oturumun ilk mesajından önceki offset'ler daha önce işlenmiştir
+	if p.lastOffset < 0 {
+		p.lastOffset = rec.Offset - 1
+	}
+	if highWatermark >= 0 {
+		p.highWatermark = highWatermark
+	}
+}
+
+// err handler hatasıdır; mesaj retry/DLQ'ya taşınmış olsa da başarısız sayılır
+func (s *consumerStats) done(rec *Record, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	p := s.partition(topicPartition{Topic: rec.Topic, Partition: rec.Partition})
+	p.processingSince = time.Time{}
+	p.lastOffset = rec.Offset
+	p.lastProcessedAt = now
+	p.redeliveries = 0
+
+	b := s.bucket(now)
+	if err != nil {
+		s.failed++
+		b.failed++
+		s.lastError = err.Error()
+		s.lastErrAt = now
+	} else {
+		s.processed++
+		b.processed++
+	}
+}
+
+// Başarısız olup commit edilmeyen mesaj; hata sayılır ama offset ilerlemez, mesaj tekrar işlenecek
+func (s *consumerStats) retry(rec *Record, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	p := s.partition(topicPartition{Topic: rec.Topic, Partition: rec.Partition})
+	p.processingSince = time.Time{}
+	if p.redelivering != rec.Offset {
+		p.redelivering, p.redeliveries = rec.Offset, 0
+	}
+	p.redeliveries++
+	s.failed++
+	s.bucket(now).failed++
+	s.lastError = err.Error()
+	s.lastErrAt = now
+}
+
+// Mesajın şimdiye kadar kaç kez commit edilmeden yeniden teslim edildiği
+func (s *consumerStats) redeliveries(rec *Record) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.partition(topicPartition{Topic: rec.Topic, Partition: rec.Partition})
+	if p.redelivering != rec.Offset {
+		return 0
+	}
+	return p.redeliveries
+}
+
+// İşlenmeden bırakılan mesaj (oturum kapandı) takılmış sayılmaz
+func (s *consumerStats) abandon(rec *Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partition(topicPartition{Topic: rec.Topic, Partition: rec.Partition}).processingSince = time.Time{}
+}
+
+func (s *consumerStats) skipValidation() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unvalidated++
+}
+
+// Subscribe hatası; mesaj sayılmaz ama son hata olarak gösterilir
+func (s *consumerStats) subscribeFailed(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastError = err.Error()
+	s.lastErrAt = time.Now()
+}
+
+func (s *consumerStats) bucket(now time.Time) *rateBucket {
+	start := now.Truncate(bucketSize)
+	b := &s.buckets[int(start.Unix()/int64(bucketSize.Seconds()))%bucketsPerWin]
+	if !b.start.Equal(start) {
+		*b = rateBucket{start: start}
+	}
+	return b
+}
+
+// Yeni oturumda atanan partition'lar; oturum kapanınca claims nil gelir
+func (s *consumerStats) assign(claims map[string][]int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if claims == nil {
+		s.disconnected = time.Now()
+		return
+	}
+	s.disconnected = time.Time{}
+
+	// Başka üyeye geçen partition'lar raporlanmaz
+	assigned := make(map[topicPartition]bool)
+	for topic, partitions := range claims {
+		for _, partition := range partitions {
+			tp := topicPartition{Topic: topic, Partition: partition}
+			assigned[tp] = true
+			s.partition(tp)
+		}
+	}
+	for tp := range s.partitions {
+		if !assigned[tp] {
+			delete(s.partitions, tp)
+		}
+	}
+}
+
+func (s *consumerStats) status(group string, topics []string, limits HealthThresholds) ConsumerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	status := ConsumerStatus{
+		Group:       group,
+		Topics:      topics,
+		Processed:   s.processed,
+		Failed:      s.failed,
+		LastError:   s.lastError,
+		LastErrorAt: s.lastErrAt,
+		Unvalidated: s.unvalidated,
+		Healthy:     true,
+	}
+	if s.sessions {
+		status.DisconnectedSince = s.disconnected
+	}
+
+	for tp, p := range s.partitions {
+		ps := PartitionStatus{
+			Topic:           tp.Topic,
+			Partition:       tp.Partition,
+			LastOffset:      p.lastOffset,
+			LastProcessedAt: p.lastProcessedAt,
+			HighWatermark:   p.highWatermark,
+			ProcessingSince: p.processingSince,
+		}
+		// Gecikme son görülen high watermark'a göre; henüz mesaj gelmediyse bilinmez
+		if p.highWatermark >= 0 {
+			ps.Lag = p.highWatermark - p.lastOffset - 1
+			if ps.Lag < 0 {
+				ps.Lag = 0
+			}
+		}
+		status.Lag += ps.Lag
+		status.Partitions = append(status.Partitions, ps)
+
+		if limits.MaxLag > 0 && ps.Lag > limits.MaxLag {
+			status.Problems = append(status.Problems, fmt.Sprintf("%s/%d lag %d exceeds %d", tp.Topic, tp.Partition, ps.Lag, limits.MaxLag))
+		}
+		if limits.StallTimeout > 0 && !p.processingSince.IsZero() && now.Sub(p.processingSince) > limits.StallTimeout {
+			status.Problems = append(status.Problems, fmt.Sprintf("%s/%d stuck on offset %d for %v", tp.Topic, tp.Partition, p.lastOffset+1, now.Sub(p.processingSince).Truncate(time.Second)))
+		}
+	}
+	sort.Slice(status.Partitions, func(i, j int) bool {
+		a, b := status.Partitions[i], status.Partitions[j]
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Partition < b.Partition
+	})
+
+	var processed, failed int
+	for _, b := range s.buckets {
+		if now.Sub(b.start) < errorWindow {
+			processed += b.processed
+			failed += b.failed
+		}
+	}
+	if total := processed + failed; total > 0 {
+		status.ErrorRate = float64(failed) / float64(total)
+		if limits.MaxErrorRate > 0 && total >= limits.MinSamples && status.ErrorRate > limits.MaxErrorRate {
+			status.Problems = append(status.Problems, fmt.Sprintf("error rate %.0f%% over %d messages exceeds %.0f%%", status.ErrorRate*100, total, limits.MaxErrorRate*100))
+		}
+	}
+
+	// Oturumun olmaması broker kaynaklıdır, consumer sağlıksız sayılmaz
+	status.Healthy = len(status.Problems) == 0
+	if limits.StallTimeout > 0 && !status.DisconnectedSince.IsZero() && now.Sub(status.DisconnectedSince) > limits.StallTimeout {
+		status.Degraded = true
+		status.Problems = append(status.Problems, fmt.Sprintf("no broker session for %v", now.Sub(status.DisconnectedSince).Truncate(time.Second)))
+	}
+
+	sort.Strings(status.Problems)
+	return status
+}
+
+// Subscriber mesajı işleyen handler'a partition'ın high watermark'ını ve oturumdaki
+// partition atamalarını context üzerinden bildirir
+type highWatermarkKey struct{}
+type assignmentKey struct{}
+
+func withHighWatermark(ctx context.Context, offset int64) context.Context {
+	return context.WithValue(ctx, highWatermarkKey{}, offset)
+}
+
+func highWatermark(ctx context.Context) int64 {
+	if offset, ok := ctx.Value(highWatermarkKey{}).(int64); ok {
+		return offset
+	}
+	return -1
+}
+
+func withAssignmentListener(ctx context.Context, listener func(claims map[string][]int32)) context.Context {
+	return context.WithValue(ctx, assignmentKey{}, listener)
+}
+
+func notifyAssignment(ctx context.Context, claims map[string][]int32) {
+	if listener, ok := ctx.Value(assignmentKey{}).(func(map[string][]int32)); ok {
+		listener(claims)
+	}
+}
+
+// Consumer'ların durumları
+func ConsumerStatuses(consumers ...*Consumer) []ConsumerStatus {
+	statuses := make([]ConsumerStatus, len(consumers))
+	for i, c := range consumers {
+		statuses[i] = c.Status()
+	}
+	return statuses
+}
+
+// GET /consumers
+func ConsumersHandler(consumers ...*Consumer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ConsumerStatuses(consumers...))
+	})
+}
+
+const (
+	StatusReady       = "ready"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+type ReadinessResponse struct {
+	Status    string             `json:"status"`
+	Producers []ConnectionStatus `json:"producers,omitempty"`
+	Consumers []ConsumerStatus   `json:"consumers"`
+	// Canlı akışları besleyen feed'ler; bağlı değilken izleme çağrıları Unavailable döner
+	Feeds []ConnectionStatus `json:"feeds,omitempty"`
+}
+
+// GET /ready; consumer'lardan biri sağlıksızsa 503 döner. Kafka'ya ulaşılamıyorsa servis
+// istekleri kabul etmeye devam eder, 200 ile degraded döner.
+type Readiness struct {
+	producers []ConnectionReporter
+	consumers []*Consumer
+	feeds     []ConnectionReporter
+}
+
+func ReadinessHandler(consumers ...*Consumer) *Readiness {
+	return &Readiness{consumers: consumers}
+}
+
+func (h *Readiness) WithProducers(producers ...ConnectionReporter) *Readiness {
+	h.producers = append(h.producers, producers...)
+	return h
+}
+
+// Feed'ler de producer'lar gibi ulaşılamadığında servisi degraded gösterir
+func (h *Readiness) WithFeeds(feeds ...ConnectionReporter) *Readiness {
+	h.feeds = append(h.feeds, feeds...)
+	return h
+}
+
+func (h *Readiness) Status() ReadinessResponse {
+	response := ReadinessResponse{Status: StatusReady, Consumers: ConsumerStatuses(h.consumers...)}
+	for _, p := range h.producers {
+		status := p.ConnectionStatus()
+		response.Producers = append(response.Producers, status)
+		if !status.Available() {
+			response.Status = StatusDegraded
+		}
+	}
+	for _, f := range h.feeds {
+		status := f.ConnectionStatus()
+		response.Feeds = append(response.Feeds, status)
+		if !status.Available() {
+			response.Status = StatusDegraded
+		}
+	}
+	for _, status := range response.Consumers {
+		if status.Degraded && response.Status == StatusReady {
+			response.Status = StatusDegraded
+		}
+	}
+	for _, status := range response.Consumers {
+		if !status.Healthy {
+			response.Status = StatusUnavailable
+		}
+	}
+	return response
+}
+
+func (h *Readiness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := h.Status()
+	code := http.StatusOK
+	if response.Status == StatusUnavailable {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Grubun Kafka'ya commit ettiği offset'lerden partition başına lag; servise ulaşılamadığında
+// govoctl bunu kullanır. İşlenme zamanı ve hata oranı yalnızca servisin kendisinde bilinir.
+func GroupLag(brokers []string, group string) ([]PartitionStatus, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_3_0_0
+
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %v", err)
+	}
+	defer client.Close()
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster admin: %v", err)
+	}
+
+	offsets, err := admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets of %s: %v", group, err)
+	}
+	if offsets.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to list offsets of %s: %v", group, offsets.Err)
+	}
+
+	var partitions []PartitionStatus
+	for topic, blocks := range offsets.Blocks {
+		for partition, block := range blocks {
+			if block.Offset < 0 {
+				continue
+			}
+			newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get high watermark of %s/%d: %v", topic, partition, err)
+			}
+			partitions = append(partitions, PartitionStatus{
+				Topic:     topic,
+				Partition: partition,
+				// Commit edilen offset sonraki okunacak mesajdır
+				LastOffset:    block.Offset - 1,
+				HighWatermark: newest,
+				Lag:           max(newest-block.Offset, 0),
+			})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+	return partitions, nil
+}
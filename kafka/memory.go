@@ -0,0 +1,371 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrBusClosed = errors.New("message bus is closed")
+
+// Testler ve broker'sız geliştirme için bellek içi Bus.
+// Topic'ler ilk kullanımda oluşur; anahtarlı kayıtlar hash ile, anahtarsızlar sırayla
+// partition'lara dağıtılır. Bir grupta her partition'ı aynı anda tek üye işler,
+// commit edilen offset'ler grup bazında tutulur ve yeni gruplar baştan okur.
+type MemoryBus struct {
+	mu          sync.Mutex
+	partitions  int
+	partitioner Partitioner
+	topics      map[string]*memoryTopic
+	groups      map[string]*memoryGroup
+	changed     chan struct{}
+	closed      bool
+	store       recordStore
+}
+
+type memoryTopic struct {
+	partitions  [][]*Record
+	partitioner sarama.Partitioner
+	next        int
+}
+
+type memoryGroup struct {
+	offsets map[topicPartition]int64
+	claimed map[topicPartition]bool
+}
+
+type topicPartition struct {
+	Topic     string
+	Partition int32
+}
+
+// Kayıtları kalıcı hale getiren katman, FileBus tarafından kullanılır
+type recordStore interface {
+	append(rec *Record) error
+	commit(groupID string, tp topicPartition, offset int64) error
+}
+
+func NewMemoryBus(partitions int) *MemoryBus {
+	if partitions < 1 {
+		partitions = 1
+	}
+	return &MemoryBus{
+		partitions:  partitions,
+		partitioner: DefaultPartitioner,
+		topics:      make(map[string]*memoryTopic),
+		groups:      make(map[string]*memoryGroup),
+		changed:     make(chan struct{}),
+	}
+}
+
+// Anahtarlı kayıtlar Kafka ile aynı algoritmayla partition'lara dağıtılır
+func (b *MemoryBus) WithPartitioner(p Partitioner) *MemoryBus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partitioner = p
+	for name, t := range b.topics {
+		t.partitioner = p.constructor()(name)
+	}
+	return b
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, topic, eventType string, payload proto.Message, opts ...PublishOption) error {
+	rec, err := NewEventRecord(ctx, topic, eventType, payload, opts...)
+	if err != nil {
+		return err
+	}
+	return b.Send(ctx, rec)
+}
+
+func (b *MemoryBus) Send(ctx context.Context, rec *Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	t := b.topic(rec.Topic)
+	var partition int
+	if len(rec.Key) > 0 {
+		p, err := t.partitioner.Partition(&sarama.ProducerMessage{Topic: rec.Topic, Key: sarama.ByteEncoder(rec.Key)}, int32(len(t.partitions)))
+		if err != nil {
+			return fmt.Errorf("failed to choose partition: %v", err)
+		}
+		partition = int(p)
+	} else {
+		partition = t.next % len(t.partitions)
+		t.next++
+	}
+
+	stored := &Record{
+		Topic:     rec.Topic,
+		Partition: int32(partition),
+		Offset:    int64(len(t.partitions[partition])),
+		Key:       rec.Key,
+		Value:     rec.Value,
+		Headers:   make(map[string]string, len(rec.Headers)),
+		Timestamp: rec.Timestamp,
+	}
+	for k, v := range rec.Headers {
+		stored.Headers[k] = v
+	}
+	if stored.Timestamp.IsZero() {
+		stored.Timestamp = time.Now()
+	}
+
+	if b.store != nil {
+		if err := b.store.append(stored); err != nil {
+			return err
+		}
+	}
+	t.partitions[partition] = append(t.partitions[partition], stored)
+	rec.Partition = stored.Partition
+	rec.Offset = stored.Offset
+	b.broadcast()
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, groupID string, topics []string, handle RecordHandler) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBusClosed
+	}
+	var claims []topicPartition
+	for _, topic := range topics {
+		t := b.topic(topic)
+		for p := range t.partitions {
+			claims = append(claims, topicPartition{Topic: topic, Partition: int32(p)})
+		}
+	}
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, tp := range claims {
+		wg.Add(1)
+		go func(tp topicPartition) {
+			defer wg.Done()
+			b.consume(ctx, groupID, tp, handle)
+		}(tp)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (b *MemoryBus) consume(ctx context.Context, groupID string, tp topicPartition, handle RecordHandler) {
+	if !b.claim(ctx, groupID, tp) {
+		return
+	}
+	defer b.release(groupID, tp)
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return
+		}
+		group := b.group(groupID)
+		records := b.topics[tp.Topic].partitions[tp.Partition]
+		offset := group.offsets[tp]
+		if offset >= int64(len(records)) {
+			changed := b.changed
+			b.mu.Unlock()
+
+			select {
+			case <-changed:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		rec := records[offset]
+		b.mu.Unlock()
+
+		var until time.Time
+		if !handle(withPause(ctx, func(t time.Time) { until = t }), rec) {
+			if until.IsZero() {
+				return
+			}
+			// Duraklatılan kayıt süre dolunca aynı offset'ten yeniden verilir
+			if !b.pause(ctx, until) {
+				return
+			}
+			continue
+		}
+
+		b.mu.Lock()
+		group.offsets[tp] = offset + 1
+		if b.store != nil {
+			b.store.commit(groupID, tp, offset+1)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// until'e kadar bekler; bus kapanırsa ya da context biterse false döner
+func (b *MemoryBus) pause(ctx context.Context, until time.Time) bool {
+	timer := time.NewTimer(time.Until(until))
+	defer timer.Stop()
+	for {
+		b.mu.Lock()
+		closed, changed := b.closed, b.changed
+		b.mu.Unlock()
+		if closed {
+			return false
+		}
+
+		select {
+		case <-timer.C:
+			return true
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Partition başka bir üyedeyse serbest kalana kadar bekler
+func (b *MemoryBus) claim(ctx context.Context, groupID string, tp topicPartition) bool {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return false
+		}
+		group := b.group(groupID)
+		if !group.claimed[tp] {
+			group.claimed[tp] = true
+			b.mu.Unlock()
+			return true
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (b *MemoryBus) release(groupID string, tp topicPartition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.group(groupID).claimed, tp)
+	b.broadcast()
+}
+
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		b.broadcast()
+	}
+	return nil
+}
+
+// Topic'teki tüm kayıtlar, partition ve offset sırasıyla
+func (b *MemoryBus) Records(topic string) []*Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []*Record
+	if t, ok := b.topics[topic]; ok {
+		for _, p := range t.partitions {
+			records = append(records, p...)
+		}
+	}
+	return records
+}
+
+func (b *MemoryBus) Topics() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topics := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		topics = append(topics, name)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Grubun topic'te henüz commit etmediği kayıt sayısı
+func (b *MemoryBus) Lag(groupID, topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.topics[topic]
+	if !ok {
+		return 0
+	}
+	group := b.group(groupID)
+	var lag int64
+	for p, records := range t.partitions {
+		lag += int64(len(records)) - group.offsets[topicPartition{Topic: topic, Partition: int32(p)}]
+	}
+	return lag
+}
+
+// Grup verilen topic'lerdeki tüm kayıtları işleyene kadar bekler
+func (b *MemoryBus) Drain(ctx context.Context, groupID string, topics ...string) error {
+	for {
+		var lag int64
+		for _, topic := range topics {
+			lag += b.Lag(groupID, topic)
+		}
+		if lag == 0 {
+			return nil
+		}
+
+		b.mu.Lock()
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (b *MemoryBus) topic(name string) *memoryTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memoryTopic{
+			partitions:  make([][]*Record, b.partitions),
+			partitioner: b.partitioner.constructor()(name),
+		}
+		b.topics[name] = t
+	}
+	return t
+}
+
+func (b *MemoryBus) group(id string) *memoryGroup {
+	g, ok := b.groups[id]
+	if !ok {
+		g = &memoryGroup{
+			offsets: make(map[topicPartition]int64),
+			claimed: make(map[topicPartition]bool),
+		}
+		b.groups[id] = g
+	}
+	return g
+}
+
+// Bekleyen tüm okuyucuları uyandırır, b.mu tutulurken çağrılır
+func (b *MemoryBus) broadcast() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
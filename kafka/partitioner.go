@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+// Anahtarlı mesajların hangi partition'a gideceğini belirler.
+// Aynı anahtar her zaman aynı partition'a düşer, bu sayede bir ödemenin
+// ya da müşterinin olayları yazıldığı sırayla işlenir.
+type Partitioner string
+
+const (
+	// FNV-1a hash, sarama varsayılanı
+	HashPartitioner Partitioner = "hash"
+	// Java istemcisinin varsayılanı: toPositive(murmur2(key)) % n. Java üreticileriyle aynı topic
+	// paylaşılıyorsa aynı anahtar aynı partition'a düşer
+	Murmur2Partitioner Partitioner = "murmur2"
+	// CRC32 hash, librdkafka "consistent" ile uyumlu
+	CRC32Partitioner Partitioner = "crc32"
+	// Anahtarı yok sayar; sıralama garantisi yoktur, sadece yük dağıtımı için
+	RoundRobinPartitioner Partitioner = "roundrobin"
+)
+
+const DefaultPartitioner = HashPartitioner
+
+func ParsePartitioner(name string) (Partitioner, error) {
+	switch p := Partitioner(name); p {
+	case "":
+		return DefaultPartitioner, nil
+	case HashPartitioner, Murmur2Partitioner, CRC32Partitioner, RoundRobinPartitioner:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown partitioner %q", name)
+}
+
+func (p Partitioner) constructor() sarama.PartitionerConstructor {
+	switch p {
+	case Murmur2Partitioner:
+		return newMurmur2Partitioner
+	case CRC32Partitioner:
+		return sarama.NewConsistentCRCHashPartitioner
+	case RoundRobinPartitioner:
+		return sarama.NewRoundRobinPartitioner
+	default:
+		return sarama.NewHashPartitioner
+	}
+}
+
+type murmur2Partitioner struct {
+	random sarama.Partitioner
+}
+
+// Anahtarsız mesajlar rastgele dağıtılır
+func newMurmur2Partitioner(topic string) sarama.Partitioner {
+	return &murmur2Partitioner{random: sarama.NewRandomPartitioner(topic)}
+}
+
+func (p *murmur2Partitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if message.Key == nil {
+		return p.random.Partition(message, numPartitions)
+	}
+	key, err := message.Key.Encode()
+	if err != nil {
+		return -1, err
+	}
+	return int32(murmur2(key)&0x7fffffff) % numPartitions, nil
+}
+
+func (p *murmur2Partitioner) RequiresConsistency() bool {
+	return true
+}
+
+// org.apache.kafka.common.utils.Utils.murmur2 ile aynı
+func murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	length := len(data)
+	h := seed ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16 | uint32(data[i+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+// Beklenen değerler Kafka'nın Java istemcisinden (UtilsTest.testMurmur2)
+func TestMurmur2MatchesJava(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int32
+	}{
+		{"21", -973932308},
+		{"foobar", -790332482},
+		{"a-little-bit-long-string", -985981536},
+		{"a-little-bit-longer-string", -1486304829},
+		{"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", -58897971},
+		{"abc", 479470107},
+	}
+	for _, tt := range tests {
+		if got := murmur2([]byte(tt.key)); got != tt.want {
+			t.Errorf("murmur2(%q) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
+
+// Java: Utils.toPositive(Utils.murmur2(key)) % numPartitions
+func TestMurmur2PartitionerMatchesJava(t *testing.T) {
+	partitioner := Murmur2Partitioner.constructor()(PaymentsTopic)
+	tests := []struct {
+		key        string
+		partitions int32
+		want       int32
+	}{
+		{"21", 6, (-973932308 & 0x7fffffff) % 6},
+		{"foobar", 12, (-790332482 & 0x7fffffff) % 12},
+		{"abc", 3, 479470107 % 3},
+	}
+	for _, tt := range tests {
+		got, err := partitioner.Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder(tt.key)}, tt.partitions)
+		if err != nil {
+			t.Fatalf("Partition(%q): %v", tt.key, err)
+		}
+		if got != tt.want {
+			t.Errorf("Partition(%q, %d) = %d, want %d", tt.key, tt.partitions, got, tt.want)
+		}
+	}
+
+	got, err := partitioner.Partition(&sarama.ProducerMessage{}, 6)
+	if err != nil || got < 0 || got >= 6 {
+		t.Errorf("Partition(nil key) = %d, %v", got, err)
+	}
+}
@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Producer ayarları, servisler ortam değişkenlerinden okur
+type ProducerConfig struct {
+	// true ise AsyncClient kullanılır, gönderim broker cevabını beklemez
+	Async bool
+	// "all", "leader" ya da "none"
+	Acks string
+	// Batch dolmasa bile bu süre sonunda gönderilir
+	Linger time.Duration
+	// Batch'in byte cinsinden üst sınırı
+	BatchSize int
+	// "none", "gzip", "snappy", "lz4" ya da "zstd"
+	Compression string
+	// Broker tekrar gönderilen mesajları ayıklar; acks=all gerektirir
+	Idempotent  bool
+	Partitioner Partitioner
+}
+
+func DefaultProducerConfig() ProducerConfig {
+	return ProducerConfig{
+		Acks:        "all",
+		Linger:      5 * time.Millisecond,
+		BatchSize:   64 * 1024,
+		Compression: "none",
+		Partitioner: DefaultPartitioner,
+	}
+}
+
+// KAFKA_PRODUCER_MODE, KAFKA_ACKS, KAFKA_LINGER, KAFKA_BATCH_SIZE, KAFKA_COMPRESSION,
+// KAFKA_IDEMPOTENT ve KAFKA_PARTITIONER değişkenlerini okur, boş olanlar varsayılan kalır
+func ProducerConfigFromEnv() (ProducerConfig, error) {
+	cfg := DefaultProducerConfig()
+
+	switch mode := os.Getenv("KAFKA_PRODUCER_MODE"); mode {
+	case "", "sync":
+	case "async":
+		cfg.Async = true
+	default:
+		return cfg, fmt.Errorf("invalid KAFKA_PRODUCER_MODE %q", mode)
+	}
+
+	if v := os.Getenv("KAFKA_ACKS"); v != "" {
+		cfg.Acks = v
+	}
+	if v := os.Getenv("KAFKA_LINGER"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid KAFKA_LINGER: %v", err)
+		}
+		cfg.Linger = d
+	}
+	if v := os.Getenv("KAFKA_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid KAFKA_BATCH_SIZE: %v", err)
+		}
+		cfg.BatchSize = n
+	}
+	if v := os.Getenv("KAFKA_COMPRESSION"); v != "" {
+		cfg.Compression = v
+	}
+	if v := os.Getenv("KAFKA_IDEMPOTENT"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid KAFKA_IDEMPOTENT: %v", err)
+		}
+		cfg.Idempotent = b
+	}
+	partitioner, err := ParsePartitioner(os.Getenv("KAFKA_PARTITIONER"))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Partitioner = partitioner
+
+	return cfg, cfg.Validate()
+}
+
+func (cfg ProducerConfig) Validate() error {
+	if _, err := cfg.acks(); err != nil {
+		return err
+	}
+	if _, err := cfg.codec(); err != nil {
+		return err
+	}
+	if cfg.Linger < 0 {
+		return fmt.Errorf("linger must not be negative")
+	}
+	if cfg.BatchSize < 0 {
+		return fmt.Errorf("batch size must not be negative")
+	}
+	if cfg.Idempotent && cfg.Acks != "all" {
+		return fmt.Errorf("idempotent producer requires acks=all")
+	}
+	if _, err := ParsePartitioner(string(cfg.Partitioner)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Ayarları sarama config'ine uygular, NewClient ve NewAsyncClient ile kullanılır
+func WithProducerConfig(cfg ProducerConfig) ClientOption {
+	return func(config *sarama.Config) {
+		acks, _ := cfg.acks()
+		codec, _ := cfg.codec()
+
+		config.Producer.RequiredAcks = acks
+		config.Producer.Compression = codec
+		config.Producer.Flush.Frequency = cfg.Linger
+		config.Producer.Flush.Bytes = cfg.BatchSize
+		config.Producer.Partitioner = cfg.Partitioner.constructor()
+
+		// zstd ve idempotent producer Kafka 2.1+ protokolü gerektirir
+		if codec == sarama.CompressionZSTD || cfg.Idempotent {
+			config.Version = sarama.V2_1_0_0
+		}
+		if cfg.Idempotent {
+			config.Producer.Idempotent = true
+			config.Net.MaxOpenRequests = 1
+		}
+	}
+}
+
+func (cfg ProducerConfig) acks() (sarama.RequiredAcks, error) {
+	switch cfg.Acks {
+	case "all", "":
+		return sarama.WaitForAll, nil
+	case "leader":
+		return sarama.WaitForLocal, nil
+	case "none":
+		return sarama.NoResponse, nil
+	}
+	return 0, fmt.Errorf("invalid acks %q", cfg.Acks)
+}
+
+func (cfg ProducerConfig) codec() (sarama.CompressionCodec, error) {
+	switch cfg.Compression {
+	case "none", "":
+		return sarama.CompressionNone, nil
+	case "gzip":
+		return sarama.CompressionGZIP, nil
+	case "snappy":
+		return sarama.CompressionSnappy, nil
+	case "lz4":
+		return sarama.CompressionLZ4, nil
+	case "zstd":
+		return sarama.CompressionZSTD, nil
+	}
+	return 0, fmt.Errorf("invalid compression %q", cfg.Compression)
+}
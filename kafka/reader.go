@@ -0,0 +1,193 @@
+package kafka
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"govo/api/proto/events"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Başlangıç noktası: From verilirse o zamandan, yoksa Offset'ten (OffsetOldest varsayılan)
+type ReadOptions struct {
+	From      time.Time
+	Offset    int64
+	Partition int32
+}
+
+func DefaultReadOptions() ReadOptions {
+	return ReadOptions{Offset: sarama.OffsetOldest, Partition: -1}
+}
+
+// Topic'i okumanın başladığı andaki son offset'e kadar okur, olaylar zamana göre sıralanır
+func ReadTopic(brokers []string, topic string, opts ReadOptions) ([]*Message, error) {
+	client, err := sarama.NewClient(brokers, sarama.NewConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %v", err)
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions for %s: %v", topic, err)
+	}
+
+	var messages []*Message
+	for _, partition := range partitions {
+		if opts.Partition >= 0 && partition != opts.Partition {
+			continue
+		}
+
+		oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oldest offset for %s/%d: %v", topic, partition, err)
+		}
+		end, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get newest offset for %s/%d: %v", topic, partition, err)
+		}
+
+		start := opts.Offset
+		if !opts.From.IsZero() {
+			// Verilen zamandan sonra olay yoksa -1 döner
+			if start, err = client.GetOffset(topic, partition, opts.From.UnixMilli()); err != nil {
+				return nil, fmt.Errorf("failed to resolve start offset for %s/%d: %v", topic, partition, err)
+			}
+			if start < 0 {
+				start = end
+			}
+		} else if start == sarama.OffsetNewest {
+			start = end
+		}
+		if start < oldest {
+			start = oldest
+		}
+		if start >= end {
+			continue
+		}
+
+		pc, err := consumer.ConsumePartition(topic, partition, start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s/%d: %v", topic, partition, err)
+		}
+		for offset := start; offset < end; {
+			select {
+			case msg := <-pc.Messages():
+				offset = msg.Offset + 1
+				if header(msg, contentTypeHeader) != contentTypeProto {
+					log.Printf("Skipping non-protobuf message at %s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+					continue
+				}
+				envelope := &events.Envelope{}
+				if err := proto.Unmarshal(msg.Value, envelope); err != nil {
+					log.Printf("Skipping unreadable envelope at %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+					continue
+				}
+				messages = append(messages, &Message{
+					Topic:     msg.Topic,
+					Partition: msg.Partition,
+					Offset:    msg.Offset,
+					Key:       msg.Key,
+					Envelope:  envelope,
+					Timestamp: msg.Timestamp,
+				})
+			case err := <-pc.Errors():
+				pc.Close()
+				return nil, fmt.Errorf("failed to read %s/%d: %v", topic, partition, err)
+			case <-time.After(10 * time.Second):
+				pc.Close()
+				return nil, fmt.Errorf("timed out reading %s/%d at offset %d", topic, partition, offset)
+			}
+		}
+		pc.Close()
+	}
+
+	SortMessages(messages)
+	return messages, nil
+}
+
+// Partition'lar arası sıra olay zamanına göre belirlenir
+func SortMessages(messages []*Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Envelope.GetOccurredAt().AsTime().Before(messages[j].Envelope.GetOccurredAt().AsTime())
+	})
+}
+
+// JSONL dışa aktarımında her satır bir olaydır, zarf protojson olarak yazılır
+type jsonLine struct {
+	Topic     string          `json:"topic"`
+	Partition int32           `json:"partition"`
+	Offset    int64           `json:"offset"`
+	Key       string          `json:"key,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+	Envelope  json.RawMessage `json:"envelope"`
+}
+
+func WriteJSONL(w io.Writer, messages []*Message) error {
+	enc := json.NewEncoder(w)
+	for _, m := range messages {
+		envelope, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m.Envelope)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %v", m.Envelope.GetEventId(), err)
+		}
+		if err := enc.Encode(jsonLine{
+			Topic:     m.Topic,
+			Partition: m.Partition,
+			Offset:    m.Offset,
+			Key:       string(m.Key),
+			Timestamp: m.Timestamp,
+			Envelope:  envelope,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ReadJSONL(r io.Reader) ([]*Message, error) {
+	var messages []*Message
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var l jsonLine
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		envelope := &events.Envelope{}
+		if err := protojson.Unmarshal(l.Envelope, envelope); err != nil {
+			return nil, fmt.Errorf("line %d: invalid envelope: %v", line, err)
+		}
+
+		messages = append(messages, &Message{
+			Topic:     l.Topic,
+			Partition: l.Partition,
+			Offset:    l.Offset,
+			Key:       []byte(l.Key),
+			Envelope:  envelope,
+			Timestamp: l.Timestamp,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
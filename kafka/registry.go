@@ -0,0 +1,295 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/schema"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Servisler açılışta bildikleri tüm olay şemalarını kayıt defterine kaydeder; kayıt defteri bir şemayı
+// subject'in uyumluluk kuralına göre reddederse servis açılmaz. Yayın sırasında kayıt defterine gidilmez,
+// açılışta alınan ID zarfa yazılır. Tüketiciler zarftaki ID ile yazanın şemasını alır ve kendi şemalarıyla
+// okunabildiğini kontrol eder. Kayıt defterine ulaşılamazsa olaylar yayınlanmaz ve tüketilmez; fail-open
+// açıksa şema ID'si olmadan yayınlanır, kontrol edilmeden işlenir ve consumer durumunda sayılır.
+
+var ErrIncompatibleSchema = errors.New("event schema is not compatible")
+
+type SchemaRegistry struct {
+	url    string
+	client *http.Client
+	// Kaydedilemeyen şemalar bu aralıkla tekrar denenir
+	retryAfter time.Duration
+	failOpen   bool
+
+	mu sync.Mutex
+	// Olay tipi -> kaydedilen şema ID'si
+	ids map[string]int32
+	// Şema ID'si -> bu servisin şemasıyla okunabilirlik sonucu; ID'ler değişmez
+	validated map[int32]error
+
+	// Fail-open'da şema ID'si olmadan yayınlanan olaylar
+	unvalidated atomic.Uint64
+}
+
+var registry atomic.Pointer[SchemaRegistry]
+
+func NewSchemaRegistry(registryURL string) *SchemaRegistry {
+	return &SchemaRegistry{
+		url:        strings.TrimRight(registryURL, "/"),
+		client:     &http.Client{Timeout: 3 * time.Second},
+		retryAfter: 30 * time.Second,
+		ids:        make(map[string]int32),
+		validated:  make(map[int32]error),
+	}
+}
+
+// Kayıt defterine ulaşılamadığında olaylar kontrol edilmeden yayınlanır ve işlenir
+func (r *SchemaRegistry) WithFailOpen() *SchemaRegistry {
+	r.failOpen = true
+	return r
+}
+
+// SCHEMA_REGISTRY_URL boşsa nil döner, şemalar kontrol edilmez.
+// SCHEMA_REGISTRY_FAIL_OPEN=true kayıt defteri kapalıyken olayların kontrolsüz akmasına izin verir.
+func SchemaRegistryFromEnv() (*SchemaRegistry, error) {
+	u := os.Getenv("SCHEMA_REGISTRY_URL")
+	if u == "" {
+		return nil, nil
+	}
+	r := NewSchemaRegistry(u)
+	if v := os.Getenv("SCHEMA_REGISTRY_FAIL_OPEN"); v != "" {
+		failOpen, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEMA_REGISTRY_FAIL_OPEN %q: %v", v, err)
+		}
+		if failOpen {
+			r.WithFailOpen()
+		}
+	}
+	return r, nil
+}
+
+// Süreçteki tüm üretici ve tüketiciler bu kayıt defterini kullanır; nil kontrolü kapatır
+func UseSchemaRegistry(r *SchemaRegistry) {
+	registry.Store(r)
+}
+
+// Olay tipinin şemasını kaydeder ve ID'sini döner. Uyumsuz şemada ErrIncompatibleSchema döner.
+func (r *SchemaRegistry) Register(ctx context.Context, eventType string, payload protoreflect.MessageDescriptor) (int32, error) {
+	r.mu.Lock()
+	if id, ok := r.ids[eventType]; ok {
+		r.mu.Unlock()
+		return id, nil
+	}
+	r.mu.Unlock()
+
+	body, err := json.Marshal(map[string]string{
+		"schema":     schema.Describe(payload).String(),
+		"schemaType": schema.TypeProtobuf,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		ID int32 `json:"id"`
+	}
+	if err := r.do(ctx, http.MethodPost, "/subjects/"+url.PathEscape(eventType)+"/versions", body, &result); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	r.ids[eventType] = result.ID
+	r.mu.Unlock()
+	log.Printf("Schema for %s registered with id %d", eventType, result.ID)
+	return result.ID, nil
+}
+
+// Servisin bildiği tüm olay şemalarını kaydeder; yayınlar transaction içinde kayıt defterini beklemez.
+// Uyumsuz şemada hata döner. Kayıt defterine ulaşılamazsa ulaşılana kadar tekrar dener; fail-open'da
+// beklemeden döner ve kalan şemaları ctx kapanana kadar arka planda kaydeder.
+func (r *SchemaRegistry) RegisterAll(ctx context.Context) error {
+	pending, err := r.registerPending(ctx, Schemas())
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	if r.failOpen {
+		go func() {
+			if err := r.retryPending(ctx, pending); err != nil {
+				log.Printf("Schema registration stopped: %v", err)
+			}
+		}()
+		return nil
+	}
+	return r.retryPending(ctx, pending)
+}
+
+func (r *SchemaRegistry) retryPending(ctx context.Context, pending []Schema) error {
+	for len(pending) > 0 {
+		select {
+		case <-time.After(r.retryAfter):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		var err error
+		if pending, err = r.registerPending(ctx, pending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Kaydedilemeyen şemaları döner; sadece uyumsuzluk hata sayılır
+func (r *SchemaRegistry) registerPending(ctx context.Context, schemas []Schema) ([]Schema, error) {
+	var pending []Schema
+	for _, local := range schemas {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(local.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("payload %s for %s: %v", local.Payload, local.Type, err)
+		}
+		_, err = r.Register(ctx, local.Type, desc.(protoreflect.MessageDescriptor))
+		if errors.Is(err, ErrIncompatibleSchema) {
+			return nil, err
+		}
+		if err != nil {
+			log.Printf("Schema registry is unavailable, %s is not registered yet: %v", local.Type, err)
+			pending = append(pending, local)
+		}
+	}
+	return pending, nil
+}
+
+// Yayın sırasında zarfa yazılacak şema ID'si; kayıt defterine gidilmez. Şema henüz kaydedilmediyse
+// olay yayınlanmaz, fail-open'da ID'siz yayınlanır.
+func (r *SchemaRegistry) schemaID(eventType string) (int32, error) {
+	r.mu.Lock()
+	id, ok := r.ids[eventType]
+	r.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	if !r.failOpen {
+		return 0, fmt.Errorf("schema for %s is not registered with the schema registry", eventType)
+	}
+	r.unvalidated.Add(1)
+	return 0, nil
+}
+
+// Şema ID'si olmadan yayınlanan olay sayısı
+func (r *SchemaRegistry) UnvalidatedPublished() uint64 {
+	return r.unvalidated.Load()
+}
+
+// Zarftaki payload'ın bu servisin şemasıyla okunabildiğini kontrol eder; kontrol yapılamadıysa false döner.
+// Okunamıyorsa kalıcı hata döner ve mesaj DLQ'ya gider. Kayıt defterine ulaşılamazsa geçici hata döner,
+// fail-open'da mesaj kontrolsüz işlenir.
+func (r *SchemaRegistry) Validate(ctx context.Context, envelope *events.Envelope) (bool, error) {
+	local, ok := LookupSchema(envelope.GetType())
+	if !ok {
+		return false, nil
+	}
+	id := envelope.GetSchemaId()
+	if id == 0 {
+		if r.failOpen {
+			return false, nil
+		}
+		return false, Permanent(fmt.Errorf("%w: %s event %s has no schema id", ErrIncompatibleSchema, envelope.GetType(), envelope.GetEventId()))
+	}
+
+	r.mu.Lock()
+	result, done := r.validated[id]
+	r.mu.Unlock()
+	if !done {
+		writer, err := r.schemaByID(ctx, id)
+		if err != nil {
+			if r.failOpen {
+				log.Printf("Schema %d of %s event %s could not be fetched, skipping validation: %v", id, envelope.GetType(), envelope.GetEventId(), err)
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to fetch schema %d: %v", id, err)
+		}
+		result = canRead(local, writer)
+		r.mu.Lock()
+		r.validated[id] = result
+		r.mu.Unlock()
+	}
+	if result != nil {
+		return true, Permanent(result)
+	}
+
+	// Zarftaki payload kayıtlı şemanın mesajı olmalıdır
+	if name := string(envelope.GetPayload().MessageName()); name != string(local.Payload) {
+		return true, Permanent(fmt.Errorf("%w: %s event carries %s payload", ErrIncompatibleSchema, envelope.GetType(), name))
+	}
+	return true, nil
+}
+
+func canRead(local Schema, writer *schema.Proto) error {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(local.Payload)
+	if err != nil {
+		return fmt.Errorf("payload %s for %s: %v", local.Payload, local.Type, err)
+	}
+	reader := schema.Describe(desc.(protoreflect.MessageDescriptor))
+	if problems := schema.CanReadProto(reader, writer); len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %s", ErrIncompatibleSchema, local.Type, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (r *SchemaRegistry) schemaByID(ctx context.Context, id int32) (*schema.Proto, error) {
+	var result struct {
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
+	}
+	if err := r.do(ctx, http.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, &result); err != nil {
+		return nil, err
+	}
+	if result.SchemaType != "" && result.SchemaType != schema.TypeProtobuf {
+		return nil, fmt.Errorf("schema %d is %s, not protobuf", id, result.SchemaType)
+	}
+	return schema.ParseProto(result.Schema)
+}
+
+func (r *SchemaRegistry) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, r.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			ErrorCode int    `json:"error_code"`
+			Message   string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&failure)
+		if resp.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%w: %s", ErrIncompatibleSchema, failure.Message)
+		}
+		return fmt.Errorf("schema registry returned %d: %s", resp.StatusCode, failure.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,186 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"govo/api/proto/events"
+	"govo/internal/schema"
+	"govo/internal/schemaregistry"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+)
+
+func newRegistryServer(t *testing.T) (*httptest.Server, *schemaregistry.Store) {
+	t.Helper()
+	store, err := schemaregistry.NewStore("", schema.Backward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	schemaregistry.NewHandler(store).Routes(router)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, store
+}
+
+func useRegistry(t *testing.T, r *SchemaRegistry) {
+	t.Helper()
+	UseSchemaRegistry(r)
+	t.Cleanup(func() { UseSchemaRegistry(nil) })
+}
+
+// PaymentEvent şeması, amount alanının tipi değiştirilmiş olarak
+func changedPaymentSchema() string {
+	p := schema.Describe((&events.PaymentEvent{}).ProtoReflect().Descriptor())
+	msg := p.Messages[p.Payload]
+	for i, f := range msg.Fields {
+		if f.Name == "amount" {
+			msg.Fields[i].Type = "string"
+		}
+	}
+	p.Messages[p.Payload] = msg
+	return p.String()
+}
+
+func decodeEnvelope(t *testing.T, rec *Record) *events.Envelope {
+	t.Helper()
+	envelope := &events.Envelope{}
+	if err := proto.Unmarshal(rec.Value, envelope); err != nil {
+		t.Fatal(err)
+	}
+	return envelope
+}
+
+// Şemalar açılışta kaydedilir; yayın kayıt defterine gitmeden ID'yi zarfa yazar, tüketici ID ile doğrular
+func TestSchemaRegistryRoundTrip(t *testing.T) {
+	srv, store := newRegistryServer(t)
+	r := NewSchemaRegistry(srv.URL)
+	if err := r.RegisterAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if subjects := store.Subjects(); len(subjects) != len(Schemas()) {
+		t.Errorf("registered %d subjects, want %d", len(subjects), len(Schemas()))
+	}
+	useRegistry(t, r)
+
+	// Kayıt defteri kapalıyken de yayın ve kayıtlı ID'lerin doğrulaması çalışmalı
+	rec := newPaymentRecord(t, PaymentsTopic)
+	envelope := decodeEnvelope(t, rec)
+	if envelope.SchemaId == 0 {
+		t.Fatal("published without a schema id")
+	}
+	checked, err := r.Validate(context.Background(), envelope)
+	if err != nil || !checked {
+		t.Fatalf("validate = %v, %v, want checked", checked, err)
+	}
+
+	srv.Close()
+	if _, err := NewEventRecord(context.Background(), PaymentsTopic, EventPaymentCompleted, &events.PaymentEvent{PaymentId: 8}); err != nil {
+		t.Errorf("publish needed the registry: %v", err)
+	}
+	if checked, err := r.Validate(context.Background(), envelope); err != nil || !checked {
+		t.Errorf("cached validation = %v, %v", checked, err)
+	}
+}
+
+// Kayıt defteri 409 dönerse ErrIncompatibleSchema; servis açılmaz
+func TestSchemaRegistryRejectsIncompatibleSchema(t *testing.T) {
+	srv, store := newRegistryServer(t)
+	if _, err := store.Register(EventPaymentCompleted, schema.TypeProtobuf, changedPaymentSchema()); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewSchemaRegistry(srv.URL)
+	desc := (&events.PaymentEvent{}).ProtoReflect().Descriptor()
+	if _, err := r.Register(context.Background(), EventPaymentCompleted, desc); !errors.Is(err, ErrIncompatibleSchema) {
+		t.Errorf("register = %v, want ErrIncompatibleSchema", err)
+	}
+	if err := r.RegisterAll(context.Background()); !errors.Is(err, ErrIncompatibleSchema) {
+		t.Errorf("register all = %v, want ErrIncompatibleSchema", err)
+	}
+}
+
+// Yazanın şeması bu servisin şemasıyla okunamıyorsa mesaj DLQ'ya gider
+func TestSchemaRegistryValidateRejectsUnreadableWriter(t *testing.T) {
+	srv, store := newRegistryServer(t)
+	writer, err := store.Register("PAYMENT_COMPLETED_V2", schema.TypeProtobuf, changedPaymentSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewSchemaRegistry(srv.URL)
+	envelope := decodeEnvelope(t, newPaymentRecord(t, PaymentsTopic))
+	envelope.SchemaId = int32(writer.ID)
+
+	_, err = r.Validate(context.Background(), envelope)
+	if !errors.Is(err, ErrIncompatibleSchema) || !IsPermanent(err) {
+		t.Errorf("validate = %v, want a permanent ErrIncompatibleSchema", err)
+	}
+}
+
+// Fail-open kapalıyken kayıt defteri olmadan olay yayınlanmaz ve doğrulanamayan mesaj işlenmez
+func TestSchemaRegistryUnavailable(t *testing.T) {
+	srv, _ := newRegistryServer(t)
+	srv.Close()
+
+	r := NewSchemaRegistry(srv.URL)
+	r.retryAfter = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := r.RegisterAll(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("register all = %v, want to wait for the registry", err)
+	}
+
+	envelope := decodeEnvelope(t, newPaymentRecord(t, PaymentsTopic))
+	useRegistry(t, r)
+	if _, err := NewEventRecord(context.Background(), PaymentsTopic, EventPaymentCompleted, &events.PaymentEvent{PaymentId: 7}); err == nil {
+		t.Error("published without a registered schema")
+	}
+
+	if _, err := r.Validate(context.Background(), envelope); err == nil || !IsPermanent(err) {
+		t.Errorf("validate without schema id = %v, want a permanent error", err)
+	}
+	envelope.SchemaId = 1
+	if _, err := r.Validate(context.Background(), envelope); err == nil || IsPermanent(err) {
+		t.Errorf("validate with unreachable registry = %v, want a transient error", err)
+	}
+}
+
+// Fail-open'da olaylar kontrolsüz akar ve consumer durumunda sayılır
+func TestSchemaRegistryFailOpen(t *testing.T) {
+	srv, _ := newRegistryServer(t)
+	srv.Close()
+
+	r := NewSchemaRegistry(srv.URL).WithFailOpen()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := r.RegisterAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	useRegistry(t, r)
+
+	rec := newPaymentRecord(t, PaymentsTopic)
+	if envelope := decodeEnvelope(t, rec); envelope.SchemaId != 0 {
+		t.Errorf("schema id = %d, want 0", envelope.SchemaId)
+	}
+
+	consumer := NewConsumer(NewMemoryBus(1), "test-group", PaymentsTopic)
+	handled := false
+	consumer.Handle(EventPaymentCompleted, func(ctx context.Context, msg *Message) error {
+		handled = true
+		return nil
+	})
+	if !consumer.process(context.Background(), rec) || !handled {
+		t.Fatal("unvalidated message was not handled")
+	}
+
+	status := consumer.Status()
+	if status.Unvalidated != 1 || status.UnvalidatedPublished != 1 {
+		t.Errorf("unvalidated = %d consumed, %d published, want 1 and 1", status.Unvalidated, status.UnvalidatedPublished)
+	}
+}
@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Hata meta verisi header olarak taşınır, gövde (zarf) değiştirilmez
+const (
+	headerTargetGroup       = "x-target-group"
+	headerOriginalTopic     = "x-original-topic"
+	headerOriginalPartition = "x-original-partition"
+	headerOriginalOffset    = "x-original-offset"
+	headerAttempt           = "x-attempt"
+	headerError             = "x-error"
+	headerFailedAt          = "x-failed-at"
+	headerRetryAt           = "x-retry-at"
+)
+
+// payments -> payments.retry.1m -> payments.retry.10m -> payments.dlq
+var DefaultRetryTiers = []time.Duration{time.Minute, 10 * time.Minute}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Tekrar denemekle düzelmeyecek hatalar retry topic'lerine uğramadan DLQ'ya gider
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+func RetryTopic(topic string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", topic, shortDuration(delay))
+}
+
+func DLQTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
+
+// Başarısız mesajları gecikmeli retry topic'lerine, denemeler bitince DLQ'ya yönlendirir.
+// Aynı topic'i birden fazla grup dinlediği için mesaj sadece hata alan gruba hedeflenir.
+func (c *Consumer) WithRetry(publisher Publisher, tiers ...time.Duration) *Consumer {
+	c.publisher = publisher
+	c.retryTiers = tiers
+	return c
+}
+
+func (c *Consumer) subscriptions() []string {
+	topics := append([]string{}, c.topics...)
+	if c.publisher == nil {
+		return topics
+	}
+	for _, topic := range c.topics {
+		for _, tier := range c.retryTiers {
+			topics = append(topics, RetryTopic(topic, tier))
+		}
+	}
+	return topics
+}
+
+func (c *Consumer) reroute(ctx context.Context, rec *Record, cause error) error {
+	attempt, _ := strconv.Atoi(rec.Header(headerAttempt))
+	originalTopic := rec.Header(headerOriginalTopic)
+	if originalTopic == "" {
+		originalTopic = rec.Topic
+	}
+
+	now := time.Now()
+	out := &Record{
+		Key:     rec.Key,
+		Value:   rec.Value,
+		Headers: make(map[string]string, len(rec.Headers)+8),
+	}
+
+	// Önceki hata header'ları yenileriyle değiştirilir, orijinal konum ilk hatadan korunur
+	for k, v := range rec.Headers {
+		switch k {
+		case headerTargetGroup, headerAttempt, headerError, headerFailedAt, headerRetryAt:
+			continue
+		}
+		out.Headers[k] = v
+	}
+	if rec.Header(headerOriginalTopic) == "" {
+		out.Headers[headerOriginalTopic] = rec.Topic
+		out.Headers[headerOriginalPartition] = strconv.Itoa(int(rec.Partition))
+		out.Headers[headerOriginalOffset] = strconv.FormatInt(rec.Offset, 10)
+	}
+	out.Headers[headerTargetGroup] = c.groupID
+	out.Headers[headerAttempt] = strconv.Itoa(attempt + 1)
+	out.Headers[headerError] = cause.Error()
+	out.Headers[headerFailedAt] = now.UTC().Format(time.RFC3339Nano)
+
+	if IsPermanent(cause) || attempt >= len(c.retryTiers) {
+		out.Topic = DLQTopic(originalTopic)
+	} else {
+		delay := c.retryTiers[attempt]
+		out.Topic = RetryTopic(originalTopic, delay)
+		out.Headers[headerRetryAt] = now.Add(delay).UTC().Format(time.RFC3339Nano)
+	}
+
+	if err := c.publisher.Send(ctx, out); err != nil {
+		return fmt.Errorf("failed to move message to %s: %v", out.Topic, err)
+	}
+	return nil
+}
+
+func header(msg *sarama.ConsumerMessage, key string) string {
+	for _, h := range msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"govo/api/proto/events"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Handler'a teslim edilen mesaj; zarf çözülmüş, payload handler'da çözülür
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Envelope  *events.Envelope
+	Timestamp time.Time
+	Attempt   int
+	Group     string
+
+	// Şema kayıt defteri açıkken payload şeması kontrol edilemedi
+	unvalidated bool
+}
+
+type messageKey struct{}
+
+// Handler'lar işledikleri mesaja (event_id, consumer group) context üzerinden erişir
+func MessageFromContext(ctx context.Context) (*Message, bool) {
+	msg, ok := ctx.Value(messageKey{}).(*Message)
+	return msg, ok
+}
+
+func (m *Message) EventType() string {
+	return m.Envelope.GetType()
+}
+
+// Payload'ı verilen mesaja çözer, tip zarftaki type URL ile uyuşmalıdır
+func (m *Message) Decode(v proto.Message) error {
+	if _, err := checkPayload(m.EventType(), v); err != nil {
+		return err
+	}
+	return m.Envelope.GetPayload().UnmarshalTo(v)
+}
+
+type Handler func(ctx context.Context, msg *Message) error
+
+type Registrar interface {
+	Handle(eventType string, handler Handler)
+}
+
+// Olay tipine göre handler seçer; Consumer ve replay aracı aynı handler'ları kullanır
+type Router struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewRouter() *Router {
+	return &Router{handlers: make(map[string]Handler)}
+}
+
+// Event tipi için handler kaydeder, aynı tip için ikinci kayıt öncekinin yerine geçer
+func (r *Router) Handle(eventType string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = handler
+}
+
+func (r *Router) Handles(eventType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.handlers[eventType]
+	return ok
+}
+
+// Payload'ı T tipine çözüp handler'a verir
+func HandleEvent[T proto.Message](r Registrar, eventType string, handler func(ctx context.Context, event T) error) {
+	r.Handle(eventType, func(ctx context.Context, msg *Message) error {
+		payload, err := msg.Envelope.GetPayload().UnmarshalNew()
+		if err != nil {
+			return Permanent(fmt.Errorf("failed to decode %s event: %v", eventType, err))
+		}
+		event, ok := payload.(T)
+		if !ok {
+			return Permanent(fmt.Errorf("unexpected %s payload for %s event", payload.ProtoReflect().Descriptor().FullName(), eventType))
+		}
+		return handler(ctx, event)
+	})
+}
+
+// Handler'ı olmayan olaylar atlanır
+func (r *Router) Dispatch(ctx context.Context, m *Message) (err error) {
+	r.mu.RLock()
+	handler, ok := r.handlers[m.EventType()]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	// Şemalar geriye uyumlu değiştiği için yeni sürümler de okunur, bilinmeyen alanlar yok sayılır
+	if schema, ok := LookupSchema(m.EventType()); ok && m.Envelope.Version > schema.Version {
+		log.Printf("Event %s has schema version %d, this service knows %d", m.Envelope.EventId, m.Envelope.Version, schema.Version)
+	}
+
+	if r := registry.Load(); r != nil {
+		checked, err := r.Validate(ctx, m.Envelope)
+		if err != nil {
+			return fmt.Errorf("%s event %s: %w", m.EventType(), m.Envelope.EventId, err)
+		}
+		m.unvalidated = !checked
+	}
+
+	ctx = context.WithValue(ctx, messageKey{}, m)
+
+	// Hatalı payload'da panikleyen handler consumer'ı düşürmez, aynı payload tekrar panikleyeceği için DLQ'ya gider
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = Permanent(fmt.Errorf("panic in %s handler: %v", m.EventType(), rec))
+		}
+	}()
+
+	if err := handler(ctx, m); err != nil {
+		return fmt.Errorf("%s event %s: %w", m.EventType(), m.Envelope.EventId, err)
+	}
+	return nil
+}
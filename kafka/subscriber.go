@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Kafka consumer group'ları üzerinden Subscriber uygulaması
+type KafkaSubscriber struct {
+	brokers []string
+	config  *sarama.Config
+}
+
+func NewSubscriber(brokers []string) *KafkaSubscriber {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	// Grubun commit edilmiş offset'i yoksa baştan oku
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Offsets.AutoCommit.Enable = true
+	config.Consumer.Offsets.AutoCommit.Interval = time.Second
+
+	return &KafkaSubscriber{brokers: brokers, config: config}
+}
+
+func (s *KafkaSubscriber) Subscribe(ctx context.Context, groupID string, topics []string, handle RecordHandler) error {
+	// Broker hazır olana kadar artan ve rastgele saptırılmış aralıklarla bekle
+	retryInterval := 2 * time.Second
+
+	var group sarama.ConsumerGroup
+	for attempt := 1; ; attempt++ {
+		var err error
+		group, err = sarama.NewConsumerGroup(s.brokers, groupID, s.config)
+		if err == nil {
+			break
+		}
+
+		delay := reconnectDelay(attempt)
+		log.Printf("Kafka consumer oluşturulamadı (group: %s): %v, %v sonra tekrar denenecek...", groupID, err, delay.Truncate(time.Millisecond))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	defer group.Close()
+
+	log.Printf("Kafka consumer başarıyla oluşturuldu! (group: %s, topics: %v)", groupID, topics)
+
+	go func() {
+		for err := range group.Errors() {
+			log.Printf("Consumer group %s error: %v", groupID, err)
+		}
+	}()
+
+	// Rebalance sonrası Consume döner, context kapanana kadar tekrar katıl
+	handler := &groupHandler{groupID: groupID, handle: handle, group: group}
+	for {
+		if err := group.Consume(ctx, topics, handler); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			log.Printf("Consumer group %s failed: %v", groupID, err)
+			time.Sleep(retryInterval)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *KafkaSubscriber) Close() error {
+	return nil
+}
+
+func (s *KafkaSubscriber) reportsSessions() bool {
+	return true
+}
+
+type groupHandler struct {
+	groupID string
+	handle  RecordHandler
+	group   sarama.ConsumerGroup
+}
+
+func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	log.Printf("Consumer group %s assigned partitions: %v", h.groupID, session.Claims())
+	notifyAssignment(session.Context(), session.Claims())
+	return nil
+}
+
+func (h *groupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	notifyAssignment(session.Context(), nil)
+	return nil
+}
+
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if !h.deliver(session, claim, msg) {
+				// Oturum kapandı, mesaj commit edilmeden bırakılır ve tekrar teslim edilir
+				return nil
+			}
+			session.MarkMessage(msg, "")
+
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Handler kaydı duraklattıysa partition'dan okuma süre dolana kadar durdurulur, heartbeat ve
+// diğer partition'lar devam eder; ardından aynı mesaj yeniden verilir
+func (h *groupHandler) deliver(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, msg *sarama.ConsumerMessage) bool {
+	for {
+		var until time.Time
+		ctx := withHighWatermark(session.Context(), claim.HighWaterMarkOffset())
+		ctx = withPause(ctx, func(t time.Time) { until = t })
+		if h.handle(ctx, fromConsumerMessage(msg)) {
+			return true
+		}
+		if until.IsZero() {
+			return false
+		}
+
+		partitions := map[string][]int32{msg.Topic: {msg.Partition}}
+		h.group.Pause(partitions)
+		timer := time.NewTimer(time.Until(until))
+		select {
+		case <-timer.C:
+			h.group.Resume(partitions)
+		case <-session.Context().Done():
+			timer.Stop()
+			h.group.Resume(partitions)
+			return false
+		}
+	}
+}
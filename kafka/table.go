@@ -0,0 +1,283 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+// Anahtarın silindiğini bildiren, değeri nil kayıt. Compaction sonrası anahtar topic'ten kalkar.
+func NewTombstone(topic, key string) *Record {
+	return &Record{
+		Topic:     topic,
+		Key:       []byte(key),
+		Headers:   map[string]string{},
+		Timestamp: time.Now(),
+	}
+}
+
+func (r *Record) IsTombstone() bool {
+	return r.Value == nil
+}
+
+// Table'ın anahtar başına son kaydı tuttuğu yer; eşzamanlı kullanıma uygun olmalıdır
+type TableStore interface {
+	Put(key string, rec *Record) error
+	Delete(key string) error
+	Get(key string) (*Record, bool)
+	Len() int
+}
+
+type MemoryTableStore struct {
+	mu      sync.RWMutex
+	records map[string]*Record
+}
+
+func NewMemoryTableStore() *MemoryTableStore {
+	return &MemoryTableStore{records: make(map[string]*Record)}
+}
+
+func (s *MemoryTableStore) Put(key string, rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = rec
+	return nil
+}
+
+func (s *MemoryTableStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryTableStore) Get(key string) (*Record, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[key]
+	return rec, ok
+}
+
+func (s *MemoryTableStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// Değişiklik bildirimi; silmede rec nil'dir
+type TableListener func(key string, rec *Record)
+
+// Compacted bir topic'i baştan okuyup yerel store'da anahtar -> son kayıt olarak tutar ve
+// yeni kayıtlarla güncel kalır. Consumer group kullanmaz, her instance topic'in tamamını okur.
+// Okuma başladıktan sonra eklenen partition'lar yeniden başlatmaya kadar okunmaz.
+type Table struct {
+	brokers   []string
+	topic     string
+	store     TableStore
+	listeners []TableListener
+
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+func NewTable(brokers []string, topic string) *Table {
+	return &Table{
+		brokers: brokers,
+		topic:   topic,
+		store:   NewMemoryTableStore(),
+		ready:   make(chan struct{}),
+	}
+}
+
+func (t *Table) WithStore(store TableStore) *Table {
+	t.store = store
+	return t
+}
+
+// Start'tan önce kaydedilmelidir; ilk yükleme sırasında da çağrılır
+func (t *Table) OnChange(listener TableListener) *Table {
+	t.listeners = append(t.listeners, listener)
+	return t
+}
+
+// Topic'i context kapanana kadar okur
+func (t *Table) Start(ctx context.Context) error {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+
+	// Broker hazır olana kadar bekle
+	var client sarama.Client
+	for {
+		var err error
+		client, err = sarama.NewClient(t.brokers, config)
+		if err == nil {
+			break
+		}
+		log.Printf("Table %s için Kafka'ya bağlanılamadı: %v, tekrar denenecek...", t.topic, err)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(t.topic)
+	if err != nil {
+		return fmt.Errorf("failed to get partitions for %s: %v", t.topic, err)
+	}
+
+	// Başlangıçtaki son offset'lere ulaşılınca tablo hazır sayılır
+	var pending atomic.Int32
+	var wg sync.WaitGroup
+	for _, partition := range partitions {
+		end, err := client.GetOffset(t.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return fmt.Errorf("failed to get newest offset for %s/%d: %v", t.topic, partition, err)
+		}
+		oldest, err := client.GetOffset(t.topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return fmt.Errorf("failed to get oldest offset for %s/%d: %v", t.topic, partition, err)
+		}
+
+		pc, err := consumer.ConsumePartition(t.topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return fmt.Errorf("failed to consume %s/%d: %v", t.topic, partition, err)
+		}
+
+		caughtUp := end <= oldest
+		if !caughtUp {
+			pending.Add(1)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer pc.Close()
+			errs := pc.Errors()
+			for {
+				select {
+				case msg, ok := <-pc.Messages():
+					if !ok {
+						return
+					}
+					t.apply(fromConsumerMessage(msg))
+					if !caughtUp && msg.Offset >= end-1 {
+						caughtUp = true
+						if pending.Add(-1) == 0 {
+							t.markReady()
+						}
+					}
+				case perr, ok := <-errs:
+					if !ok {
+						errs = nil
+						continue
+					}
+					log.Printf("Table %s partition %d error: %v", t.topic, perr.Partition, perr.Err)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	if pending.Load() == 0 {
+		t.markReady()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Kayıtları doğrudan uygular; Kafka dışı kaynaklardan (ör. MemoryBus.Records) doldurmak için
+func (t *Table) Load(records []*Record) {
+	for _, rec := range records {
+		t.apply(rec)
+	}
+	t.markReady()
+}
+
+func (t *Table) apply(rec *Record) {
+	if len(rec.Key) == 0 {
+		return
+	}
+	key := string(rec.Key)
+
+	var err error
+	if rec.IsTombstone() {
+		err = t.store.Delete(key)
+	} else {
+		err = t.store.Put(key, rec)
+	}
+	if err != nil {
+		log.Printf("Table %s failed to store key %s: %v", t.topic, key, err)
+		return
+	}
+
+	for _, listener := range t.listeners {
+		if rec.IsTombstone() {
+			listener(key, nil)
+		} else {
+			listener(key, rec)
+		}
+	}
+}
+
+func (t *Table) markReady() {
+	t.readyOnce.Do(func() { close(t.ready) })
+}
+
+// Başlangıçtaki içerik yüklendiğinde kapanır
+func (t *Table) Ready() <-chan struct{} {
+	return t.ready
+}
+
+func (t *Table) WaitReady(ctx context.Context) error {
+	select {
+	case <-t.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Table) Get(key string) (*Record, bool) {
+	return t.store.Get(key)
+}
+
+func (t *Table) Len() int {
+	return t.store.Len()
+}
+
+// Anahtarın güncel durumunu v'ye çözer; anahtar yoksa false döner
+func (t *Table) Lookup(key string, v proto.Message) (bool, error) {
+	rec, ok := t.store.Get(key)
+	if !ok {
+		return false, nil
+	}
+	envelope, err := rec.Envelope()
+	if err != nil {
+		return false, fmt.Errorf("invalid record for key %s: %v", key, err)
+	}
+	if envelope == nil {
+		return false, fmt.Errorf("record for key %s has no envelope", key)
+	}
+	if _, err := checkPayload(envelope.Type, v); err != nil {
+		return false, err
+	}
+	if err := envelope.GetPayload().UnmarshalTo(v); err != nil {
+		return false, fmt.Errorf("failed to decode key %s: %v", key, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,47 @@
+package kafka
+
+import "testing"
+
+func TestTombstoneProducerMessage(t *testing.T) {
+	msg := toProducerMessage(NewTombstone(CustomerStateTopic, "42"))
+	if msg.Value != nil {
+		t.Errorf("tombstone value = %v, want nil", msg.Value)
+	}
+	if key, _ := msg.Key.Encode(); string(key) != "42" {
+		t.Errorf("key = %q, want 42", key)
+	}
+}
+
+// Anahtar başına son kayıt tutulur, tombstone anahtarı siler
+func TestTableLoad(t *testing.T) {
+	changes := make(map[string]*Record)
+	table := NewTable(nil, CustomerStateTopic).OnChange(func(key string, rec *Record) {
+		changes[key] = rec
+	})
+
+	table.Load([]*Record{
+		{Topic: CustomerStateTopic, Key: []byte("1"), Value: []byte("v1")},
+		{Topic: CustomerStateTopic, Key: []byte("2"), Value: []byte("v1")},
+		{Topic: CustomerStateTopic, Key: []byte("1"), Value: []byte("v2")},
+		NewTombstone(CustomerStateTopic, "2"),
+		{Topic: CustomerStateTopic, Value: []byte("no key")},
+	})
+
+	select {
+	case <-table.Ready():
+	default:
+		t.Fatal("table not ready after Load")
+	}
+	if rec, ok := table.store.Get("1"); !ok || string(rec.Value) != "v2" {
+		t.Errorf("key 1 = %v, want v2", rec)
+	}
+	if _, ok := table.store.Get("2"); ok {
+		t.Error("tombstoned key 2 still in the table")
+	}
+	if n := table.store.Len(); n != 1 {
+		t.Errorf("table holds %d keys, want 1", n)
+	}
+	if rec, ok := changes["2"]; !ok || rec != nil {
+		t.Errorf("listener got %v for deleted key 2, want nil", rec)
+	}
+}
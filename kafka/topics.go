@@ -0,0 +1,354 @@
+package kafka
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Servislerin kullandığı topic'ler topics.json'da tanımlanır. Servisler açılışta eksik
+// topic'leri oluşturur, govoctl topics ile aynı tanım elle uygulanır ve farklar raporlanır.
+
+//go:embed topics.json
+var defaultTopicConfig []byte
+
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+type TopicSpec struct {
+	Name              string   `json:"name"`
+	Partitions        int32    `json:"partitions,omitempty"`
+	ReplicationFactor int16    `json:"replication_factor,omitempty"`
+	Retention         Duration `json:"retention,omitempty"`
+	// cleanup.policy=compact; anahtar başına son değer saklanır
+	Compacted bool `json:"compacted,omitempty"`
+	// Topic'in retry ve DLQ topic'leri de oluşturulur
+	Retry bool `json:"retry,omitempty"`
+	// Diğer topic ayarları, ör. min.insync.replicas
+	Config map[string]string `json:"config,omitempty"`
+}
+
+type TopicConfig struct {
+	Defaults TopicSpec `json:"defaults"`
+	// Boşsa DefaultRetryTiers kullanılır
+	RetryTiers   []Duration  `json:"retry_tiers,omitempty"`
+	DLQRetention Duration    `json:"dlq_retention,omitempty"`
+	Topics       []TopicSpec `json:"topics"`
+}
+
+// path boşsa paketle gelen topics.json kullanılır
+func LoadTopicConfig(path string) (*TopicConfig, error) {
+	data := defaultTopicConfig
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read topic config: %v", err)
+		}
+	}
+
+	var cfg TopicConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid topic config: %v", err)
+	}
+	return &cfg, nil
+}
+
+// Varsayılanları uygular ve retry/DLQ topic'lerini ekleyerek oluşturulacak topic listesini döner
+func (c *TopicConfig) Resolve() ([]TopicSpec, error) {
+	tiers := DefaultRetryTiers
+	if len(c.RetryTiers) > 0 {
+		tiers = make([]time.Duration, len(c.RetryTiers))
+		for i, t := range c.RetryTiers {
+			tiers[i] = t.Duration
+		}
+	}
+
+	var specs []TopicSpec
+	seen := make(map[string]bool)
+	add := func(spec TopicSpec) error {
+		if seen[spec.Name] {
+			return fmt.Errorf("topic %s is declared more than once", spec.Name)
+		}
+		seen[spec.Name] = true
+		specs = append(specs, spec)
+		return nil
+	}
+
+	for _, t := range c.Topics {
+		spec := c.withDefaults(t)
+		if spec.Name == "" {
+			return nil, errors.New("topic without a name")
+		}
+		if spec.Partitions <= 0 || spec.ReplicationFactor <= 0 {
+			return nil, fmt.Errorf("topic %s needs positive partitions and replication factor", spec.Name)
+		}
+		if err := add(spec); err != nil {
+			return nil, err
+		}
+		if !spec.Retry {
+			continue
+		}
+
+		// Retry topic'leri aynı anahtarla yazılır, partition sayısı ana topic ile aynı kalır
+		for _, tier := range tiers {
+			retry := spec
+			retry.Name = RetryTopic(spec.Name, tier)
+			retry.Retry = false
+			retry.Compacted = false
+			if err := add(retry); err != nil {
+				return nil, err
+			}
+		}
+		dlq := spec
+		dlq.Name = DLQTopic(spec.Name)
+		dlq.Retry = false
+		dlq.Compacted = false
+		if c.DLQRetention.Duration > 0 {
+			dlq.Retention = c.DLQRetention
+		}
+		if err := add(dlq); err != nil {
+			return nil, err
+		}
+	}
+	return specs, nil
+}
+
+func (c *TopicConfig) withDefaults(t TopicSpec) TopicSpec {
+	if t.Partitions == 0 {
+		t.Partitions = c.Defaults.Partitions
+	}
+	if t.ReplicationFactor == 0 {
+		t.ReplicationFactor = c.Defaults.ReplicationFactor
+	}
+	if t.Retention.Duration == 0 {
+		t.Retention = c.Defaults.Retention
+	}
+	config := make(map[string]string)
+	for k, v := range c.Defaults.Config {
+		config[k] = v
+	}
+	for k, v := range t.Config {
+		config[k] = v
+	}
+	t.Config = config
+	return t
+}
+
+// Broker'da karşılaştırılan topic ayarları
+func (t TopicSpec) configEntries() map[string]string {
+	entries := make(map[string]string, len(t.Config)+2)
+	for k, v := range t.Config {
+		entries[k] = v
+	}
+	entries["cleanup.policy"] = "delete"
+	if t.Compacted {
+		entries["cleanup.policy"] = "compact"
+	}
+	if t.Retention.Duration > 0 {
+		entries["retention.ms"] = strconv.FormatInt(t.Retention.Milliseconds(), 10)
+	}
+	return entries
+}
+
+const (
+	TopicCreate = "create"
+	TopicAlter  = "alter"
+	// Otomatik düzeltilmeyen fark; replikasyon değişikliği ya da partition azaltma gibi
+	TopicDrift = "drift"
+)
+
+type TopicChange struct {
+	Topic  string
+	Action string
+	Field  string
+	Want   string
+	Have   string
+	spec   TopicSpec
+}
+
+func (c TopicChange) String() string {
+	if c.Action == TopicCreate {
+		return fmt.Sprintf("%s %s (partitions=%s)", c.Action, c.Topic, c.Want)
+	}
+	return fmt.Sprintf("%s %s %s: %s -> %s", c.Action, c.Topic, c.Field, c.Have, c.Want)
+}
+
+type TopicAdmin struct {
+	admin sarama.ClusterAdmin
+	// Partition artırmak anahtarların partition eşleşmesini değiştirir, açıkça istenmelidir
+	AllowPartitionIncrease bool
+}
+
+func NewTopicAdmin(brokers []string) (*TopicAdmin, error) {
+	config := sarama.NewConfig()
+	// IncrementalAlterConfigs Kafka 2.3+ gerektirir
+	config.Version = sarama.V2_3_0_0
+
+	admin, err := sarama.NewClusterAdmin(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %v", err)
+	}
+	return &TopicAdmin{admin: admin}, nil
+}
+
+func (a *TopicAdmin) Close() error {
+	return a.admin.Close()
+}
+
+// Tanımla broker'daki durumu karşılaştırır, yapılacak değişiklikleri ve düzeltilemeyen farkları döner
+func (a *TopicAdmin) Plan(cfg *TopicConfig) ([]TopicChange, error) {
+	specs, err := cfg.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := a.admin.ListTopics()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %v", err)
+	}
+
+	var changes []TopicChange
+	for _, spec := range specs {
+		detail, ok := existing[spec.Name]
+		if !ok {
+			changes = append(changes, TopicChange{
+				Topic:  spec.Name,
+				Action: TopicCreate,
+				Want:   strconv.Itoa(int(spec.Partitions)),
+				spec:   spec,
+			})
+			continue
+		}
+		changes = append(changes, a.diff(spec, detail)...)
+	}
+	return changes, nil
+}
+
+func (a *TopicAdmin) diff(spec TopicSpec, detail sarama.TopicDetail) []TopicChange {
+	var changes []TopicChange
+	change := func(action, field, want, have string) {
+		changes = append(changes, TopicChange{Topic: spec.Name, Action: action, Field: field, Want: want, Have: have, spec: spec})
+	}
+
+	switch {
+	case detail.NumPartitions < spec.Partitions && a.AllowPartitionIncrease:
+		change(TopicAlter, "partitions", fmt.Sprint(spec.Partitions), fmt.Sprint(detail.NumPartitions))
+	case detail.NumPartitions != spec.Partitions:
+		change(TopicDrift, "partitions", fmt.Sprint(spec.Partitions), fmt.Sprint(detail.NumPartitions))
+	}
+	if detail.ReplicationFactor != spec.ReplicationFactor {
+		change(TopicDrift, "replication_factor", fmt.Sprint(spec.ReplicationFactor), fmt.Sprint(detail.ReplicationFactor))
+	}
+
+	want := spec.configEntries()
+	keys := make([]string, 0, len(want))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// ListTopics sadece varsayılan olmayan ayarları döner; cleanup.policy'nin varsayılanı delete
+		have := "<default>"
+		if v := detail.ConfigEntries[k]; v != nil {
+			have = *v
+		} else if k == "cleanup.policy" {
+			have = "delete"
+		}
+		if have != want[k] {
+			change(TopicAlter, k, want[k], have)
+		}
+	}
+	return changes
+}
+
+// create ve alter değişikliklerini uygular, drift'leri olduğu gibi bırakır
+func (a *TopicAdmin) Apply(changes []TopicChange) error {
+	for _, c := range changes {
+		var err error
+		switch {
+		case c.Action == TopicCreate:
+			err = a.create(c.spec)
+		case c.Action == TopicAlter && c.Field == "partitions":
+			err = a.admin.CreatePartitions(c.Topic, c.spec.Partitions, nil, false)
+		case c.Action == TopicAlter:
+			value := c.Want
+			err = a.admin.IncrementalAlterConfig(sarama.TopicResource, c.Topic, map[string]sarama.IncrementalAlterConfigsEntry{
+				c.Field: {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value},
+			}, false)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s: %v", c, err)
+		}
+	}
+	return nil
+}
+
+func (a *TopicAdmin) create(spec TopicSpec) error {
+	entries := spec.configEntries()
+	detail := &sarama.TopicDetail{
+		NumPartitions:     spec.Partitions,
+		ReplicationFactor: spec.ReplicationFactor,
+		ConfigEntries:     make(map[string]*string, len(entries)),
+	}
+	for k, v := range entries {
+		v := v
+		detail.ConfigEntries[k] = &v
+	}
+
+	err := a.admin.CreateTopic(spec.Name, detail, false)
+	// Başka bir servis aynı anda oluşturmuş olabilir
+	if errors.Is(err, sarama.ErrTopicAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// Servis açılışında çağrılır: eksik topic'leri oluşturur, ayarları düzeltir, kalan farkları loglar
+func EnsureTopics(brokers []string, cfg *TopicConfig) error {
+	admin, err := NewTopicAdmin(brokers)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	changes, err := admin.Plan(cfg)
+	if err != nil {
+		return err
+	}
+	if err := admin.Apply(changes); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if c.Action == TopicDrift {
+			log.Printf("Kafka topic drift: %s", c)
+		} else {
+			log.Printf("Kafka topic: %s", c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+func TestResolveAddsRetryAndDLQTopics(t *testing.T) {
+	cfg := &TopicConfig{
+		Defaults:     TopicSpec{Partitions: 6, ReplicationFactor: 1},
+		RetryTiers:   []Duration{{time.Minute}},
+		DLQRetention: Duration{720 * time.Hour},
+		Topics: []TopicSpec{
+			{Name: "payments", Retry: true},
+			{Name: "notifications", Partitions: 3},
+		},
+	}
+	specs, err := cfg.Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"payments", RetryTopic("payments", time.Minute), DLQTopic("payments"), "notifications"}
+	if len(specs) != len(want) {
+		t.Fatalf("resolved %d topics, want %d", len(specs), len(want))
+	}
+	for i, name := range want {
+		if specs[i].Name != name {
+			t.Errorf("topic %d = %s, want %s", i, specs[i].Name, name)
+		}
+	}
+	if specs[1].Partitions != 6 {
+		t.Errorf("retry topic partitions = %d, want the main topic's 6", specs[1].Partitions)
+	}
+	if specs[2].Retention.Duration != 720*time.Hour {
+		t.Errorf("dlq retention = %v, want 720h", specs[2].Retention)
+	}
+	if specs[3].Partitions != 3 {
+		t.Errorf("notifications partitions = %d, want 3", specs[3].Partitions)
+	}
+}
+
+func TestResolveRejectsDuplicates(t *testing.T) {
+	cfg := &TopicConfig{
+		Defaults: TopicSpec{Partitions: 1, ReplicationFactor: 1},
+		Topics:   []TopicSpec{{Name: "payments"}, {Name: "payments"}},
+	}
+	if _, err := cfg.Resolve(); err == nil {
+		t.Fatal("duplicate topic accepted")
+	}
+}
+
+func TestTopicDiff(t *testing.T) {
+	str := func(s string) *string { return &s }
+	spec := TopicSpec{Name: "payments", Partitions: 6, ReplicationFactor: 3, Retention: Duration{168 * time.Hour}}
+	inSync := map[string]*string{"retention.ms": str("604800000")}
+
+	tests := []struct {
+		name          string
+		allowIncrease bool
+		detail        sarama.TopicDetail
+		want          []TopicChange
+	}{
+		{
+			name:   "in sync",
+			detail: sarama.TopicDetail{NumPartitions: 6, ReplicationFactor: 3, ConfigEntries: inSync},
+		},
+		{
+			name:   "fewer partitions",
+			detail: sarama.TopicDetail{NumPartitions: 3, ReplicationFactor: 3, ConfigEntries: inSync},
+			want:   []TopicChange{{Action: TopicDrift, Field: "partitions", Want: "6", Have: "3"}},
+		},
+		{
+			name:          "fewer partitions with increase allowed",
+			allowIncrease: true,
+			detail:        sarama.TopicDetail{NumPartitions: 3, ReplicationFactor: 3, ConfigEntries: inSync},
+			want:          []TopicChange{{Action: TopicAlter, Field: "partitions", Want: "6", Have: "3"}},
+		},
+		{
+			name:          "more partitions",
+			allowIncrease: true,
+			detail:        sarama.TopicDetail{NumPartitions: 12, ReplicationFactor: 3, ConfigEntries: inSync},
+			want:          []TopicChange{{Action: TopicDrift, Field: "partitions", Want: "6", Have: "12"}},
+		},
+		{
+			name:   "replication factor",
+			detail: sarama.TopicDetail{NumPartitions: 6, ReplicationFactor: 1, ConfigEntries: inSync},
+			want:   []TopicChange{{Action: TopicDrift, Field: "replication_factor", Want: "3", Have: "1"}},
+		},
+		{
+			name:   "retention left at broker default",
+			detail: sarama.TopicDetail{NumPartitions: 6, ReplicationFactor: 3},
+			want:   []TopicChange{{Action: TopicAlter, Field: "retention.ms", Want: "604800000", Have: "<default>"}},
+		},
+		{
+			name: "compacted on the broker",
+			detail: sarama.TopicDetail{NumPartitions: 6, ReplicationFactor: 3, ConfigEntries: map[string]*string{
+				"retention.ms":   str("604800000"),
+				"cleanup.policy": str("compact"),
+			}},
+			want: []TopicChange{{Action: TopicAlter, Field: "cleanup.policy", Want: "delete", Have: "compact"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := &TopicAdmin{AllowPartitionIncrease: tt.allowIncrease}
+			got := admin.diff(spec, tt.detail)
+			if len(got) != len(tt.want) {
+				t.Fatalf("changes = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				c := got[i]
+				if c.Topic != spec.Name || c.Action != want.Action || c.Field != want.Field || c.Want != want.Want || c.Have != want.Have {
+					t.Errorf("change %d = %s, want %s %s %s: %s -> %s", i, c, want.Action, spec.Name, want.Field, want.Have, want.Want)
+				}
+			}
+		})
+	}
+}
+
+// Drift otomatik düzeltilmez, sadece create ve alter uygulanır
+func TestApplySkipsDrift(t *testing.T) {
+	admin := &TopicAdmin{}
+	changes := []TopicChange{
+		{Topic: "payments", Action: TopicDrift, Field: "partitions", Want: "6", Have: "12"},
+		{Topic: "payments", Action: TopicDrift, Field: "replication_factor", Want: "3", Have: "1"},
+	}
+	// admin nil olduğu için uygulanmaya çalışılan bir değişiklik panic ederdi
+	if err := admin.Apply(changes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Silinen müşterilerin kişisel verisi compaction ile sınırlı süre içinde topic'ten kalkmalı
+func TestCustomerStateRetention(t *testing.T) {
+	cfg, err := LoadTopicConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, err := cfg.Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range specs {
+		if spec.Name != CustomerStateTopic {
+			continue
+		}
+		entries := spec.configEntries()
+		want := map[string]string{
+			"cleanup.policy":        "compact",
+			"max.compaction.lag.ms": "86400000",
+			"delete.retention.ms":   "86400000",
+		}
+		for key, value := range want {
+			if entries[key] != value {
+				t.Errorf("%s %s = %q, want %q", CustomerStateTopic, key, entries[key], value)
+			}
+		}
+		return
+	}
+	t.Fatalf("%s is not declared", CustomerStateTopic)
+}